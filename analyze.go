@@ -0,0 +1,156 @@
+package uuid
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Analysis is the report of Analyze and AnalyzeBinary on a dataset of UUIDs,
+// such as a column exported before a migration.
+type Analysis struct {
+	// Count is the number of well-formed UUIDs, and Malformed the number of
+	// lines, or trailing bytes, which are not UUIDs.
+	Count, Malformed int
+
+	// Nil and Max are the numbers of Nil and Max UUIDs, also counted in
+	// Variants.
+	Nil, Max int
+
+	// Variants are the numbers of UUIDs of each variant, and Versions the
+	// numbers of UUIDs of the RFC 9562 variant of each version.
+	Variants [4]int
+	Versions [16]int
+
+	// Timed is the number of UUIDs of versions 1, 6 and 7, whose embedded
+	// timestamps range from MinTime to MaxTime.
+	Timed            int
+	MinTime, MaxTime Timestamp
+
+	// Nodes is the number of distinct node IDs of the UUIDs of version 1,
+	// counted up to MaxAnalyzedNodes. Version 6 UUIDs are not counted, as
+	// their node IDs are usually random.
+	Nodes int
+}
+
+// MaxAnalyzedNodes is the largest number of distinct node IDs counted by
+// Analyze and AnalyzeBinary, bounding their memory use. Datasets with more
+// report Nodes as MaxAnalyzedNodes.
+const MaxAnalyzedNodes = 1 << 16
+
+// analyzer accumulates the analysis of a dataset.
+type analyzer struct {
+	Analysis
+	nodes map[[6]byte]struct{}
+}
+
+// Analyze returns the analysis of the UUIDs read from r, one per line in
+// any format accepted by FromString. Empty lines are malformed. It returns
+// an error if r cannot be read, with the analysis of the lines read before.
+func Analyze(r io.Reader) (Analysis, error) {
+	var a analyzer
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		var u UUID
+		if err := u.UnmarshalText(bytes.TrimSpace(sc.Bytes())); err != nil {
+			a.Malformed++
+			continue
+		}
+		a.add(u)
+	}
+	return a.Analysis, sc.Err()
+}
+
+// AnalyzeBinary returns the analysis of the UUIDs read from r as
+// consecutive 16-byte values, such as a dump of a binary column. Trailing
+// bytes shorter than a UUID count as one malformed UUID. It returns an error
+// if r cannot be read, with the analysis of the UUIDs read before.
+func AnalyzeBinary(r io.Reader) (Analysis, error) {
+	var a analyzer
+	br := bufio.NewReader(r)
+	for {
+		var u UUID
+		switch _, err := io.ReadFull(br, u[:]); err {
+		case nil:
+			a.add(u)
+		case io.EOF:
+			return a.Analysis, nil
+		case io.ErrUnexpectedEOF:
+			a.Malformed++
+			return a.Analysis, nil
+		default:
+			return a.Analysis, err
+		}
+	}
+}
+
+func (a *analyzer) add(u UUID) {
+	a.Count++
+	switch u {
+	case Nil:
+		a.Nil++
+	case Max:
+		a.Max++
+	}
+	a.Variants[u.Variant()]++
+	if u.Variant() != VariantRFC9562 {
+		return
+	}
+	a.Versions[u.Version()]++
+	if ts, ok := timestampOf(u); ok {
+		if a.Timed == 0 || ts < a.MinTime {
+			a.MinTime = ts
+		}
+		if a.Timed == 0 || ts > a.MaxTime {
+			a.MaxTime = ts
+		}
+		a.Timed++
+	}
+	if u.Version() == V1 && a.Nodes < MaxAnalyzedNodes {
+		f := u.Fields()
+		if a.nodes == nil {
+			a.nodes = make(map[[6]byte]struct{})
+		}
+		a.nodes[f.Node] = struct{}{}
+		a.Nodes = len(a.nodes)
+	}
+}
+
+// String returns a human-readable report of the analysis, in the layout of
+// Explain, listing the variants and versions found in the dataset.
+func (a Analysis) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "UUIDs:          %d\n", a.Count)
+	fmt.Fprintf(&b, "Malformed:      %d\n", a.Malformed)
+	if a.Nil > 0 {
+		fmt.Fprintf(&b, "Nil:            %d\n", a.Nil)
+	}
+	if a.Max > 0 {
+		fmt.Fprintf(&b, "Max:            %d\n", a.Max)
+	}
+	for v, n := range a.Variants {
+		if n > 0 {
+			fmt.Fprintf(&b, "%-16s%d (%s)\n", fmt.Sprintf("Variant %d:", v), n, variantDescriptions[v])
+		}
+	}
+	for v, n := range a.Versions {
+		if n > 0 {
+			fmt.Fprintf(&b, "%-16s%d (%s)\n", fmt.Sprintf("Version %d:", v), n, versionDescriptions[v])
+		}
+	}
+	if a.Timed > 0 {
+		minTime, _ := a.MinTime.Time()
+		maxTime, _ := a.MaxTime.Time()
+		fmt.Fprintf(&b, "Min time:       %s\n", minTime.UTC().Format(time.RFC3339Nano))
+		fmt.Fprintf(&b, "Max time:       %s\n", maxTime.UTC().Format(time.RFC3339Nano))
+	}
+	if a.Nodes >= MaxAnalyzedNodes {
+		fmt.Fprintf(&b, "Nodes:          %d or more\n", a.Nodes)
+	} else if a.Nodes > 0 {
+		fmt.Fprintf(&b, "Nodes:          %d\n", a.Nodes)
+	}
+	return b.String()
+}
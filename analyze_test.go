@@ -0,0 +1,129 @@
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestAnalyze(t *testing.T) {
+	v6 := Must(FromString("1ec9414c-232a-6b00-b3c8-9f6bdeced846"))
+	v7 := Must(FromString("018bcfe5-687b-7abc-8123-456789abcdef"))
+	v4 := Must(FromString("123e4567-e89b-42d3-a456-426614174000"))
+	microsoft := Must(FromString("968b80c3-a91b-11ee-cc32-baa7b68e1b32"))
+	uuids := []UUID{NamespaceDNS, NamespaceURL, v6, v7, v4, Nil, Max, microsoft, v7}
+
+	minTime, _ := TimestampFromV1(NamespaceDNS)
+	maxTime, _ := TimestampFromV7(v7)
+	want := Analysis{
+		Count:    len(uuids),
+		Nil:      1,
+		Max:      1,
+		Variants: [4]int{VariantNCS: 1, VariantRFC9562: 6, VariantMicrosoft: 1, VariantFuture: 1},
+		Versions: [16]int{V1: 2, V4: 1, V6: 1, V7: 2},
+		Timed:    5,
+		MinTime:  minTime,
+		MaxTime:  maxTime,
+		Nodes:    1,
+	}
+
+	t.Run("Text", func(t *testing.T) {
+		var lines []string
+		for _, u := range uuids[:len(uuids)-1] {
+			lines = append(lines, u.String())
+		}
+		lines = append(lines, " {"+strings.ToUpper(v7.String())+"}\r", "", "not a uuid")
+		got, err := Analyze(strings.NewReader(strings.Join(lines, "\n")))
+		if err != nil {
+			t.Fatalf("Analyze() unexpected error: %v", err)
+		}
+		want := want
+		want.Malformed = 2
+		if got != want {
+			t.Errorf("Analyze() = %+v, want %+v", got, want)
+		}
+	})
+	t.Run("Binary", func(t *testing.T) {
+		var b bytes.Buffer
+		for _, u := range uuids {
+			b.Write(u[:])
+		}
+		if got, err := AnalyzeBinary(bytes.NewReader(b.Bytes())); err != nil || got != want {
+			t.Errorf("AnalyzeBinary() = %+v, %v, want %+v, <nil>", got, err, want)
+		}
+		b.Write(v4[:3])
+		want := want
+		want.Malformed = 1
+		if got, err := AnalyzeBinary(&b); err != nil || got != want {
+			t.Errorf("AnalyzeBinary() with trailing bytes = %+v, %v, want %+v, <nil>", got, err, want)
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		if got, err := Analyze(strings.NewReader("")); err != nil || got != (Analysis{}) {
+			t.Errorf("Analyze(empty) = %+v, %v, want zero Analysis", got, err)
+		}
+		if got, err := AnalyzeBinary(strings.NewReader("")); err != nil || got != (Analysis{}) {
+			t.Errorf("AnalyzeBinary(empty) = %+v, %v, want zero Analysis", got, err)
+		}
+	})
+	t.Run("ReadError", func(t *testing.T) {
+		errRead := errors.New("read error")
+		r := io.MultiReader(strings.NewReader(v4.String()+"\n"), iotest.ErrReader(errRead))
+		if got, err := Analyze(r); !errors.Is(err, errRead) || got.Count != 1 {
+			t.Errorf("Analyze() = %+v, %v, want 1 UUID, %v", got, err, errRead)
+		}
+		r = io.MultiReader(bytes.NewReader(v4[:]), iotest.ErrReader(errRead))
+		if got, err := AnalyzeBinary(r); !errors.Is(err, errRead) || got.Count != 1 {
+			t.Errorf("AnalyzeBinary() = %+v, %v, want 1 UUID, %v", got, err, errRead)
+		}
+	})
+	t.Run("String", func(t *testing.T) {
+		got := want.String()
+		for _, line := range []string{
+			"UUIDs:          9",
+			"Malformed:      0",
+			"Nil:            1",
+			"Max:            1",
+			"Variant 1:      6 (RFC 9562)",
+			"Version 7:      2 (Unix epoch date-time)",
+			"Min time:       1998-02-04T22:13:53.1511824Z",
+			"Max time:       2023-11-14T22:13:20.123Z",
+			"Nodes:          1",
+		} {
+			if !strings.Contains(got, line+"\n") {
+				t.Errorf("Analysis.String() does not contain %q:\n%s", line, got)
+			}
+		}
+		if strings.Contains(got, "Version 3:") {
+			t.Errorf("Analysis.String() lists a version not found:\n%s", got)
+		}
+	})
+}
+
+func TestAnalyzeNodes(t *testing.T) {
+	// Each V6 UUID has a random node, which must not be accumulated.
+	var a analyzer
+	g := NewGen()
+	for i := 0; i < MaxAnalyzedNodes+10; i++ {
+		a.add(Must(g.NewV6()))
+	}
+	if a.Nodes != 0 || len(a.nodes) != 0 {
+		t.Errorf("analysis of V6 UUIDs: Nodes = %d, %d tracked, want 0", a.Nodes, len(a.nodes))
+	}
+
+	v1 := NamespaceDNS
+	for i := 0; i < MaxAnalyzedNodes+10; i++ {
+		v1[10], v1[11], v1[12] = byte(i), byte(i>>8), byte(i>>16)
+		a.add(v1)
+	}
+	if a.Nodes != MaxAnalyzedNodes || len(a.nodes) != MaxAnalyzedNodes {
+		t.Errorf("analysis of V1 UUIDs: Nodes = %d, %d tracked, want %d", a.Nodes, len(a.nodes), MaxAnalyzedNodes)
+	}
+	if got, want := a.String(), fmt.Sprintf("Nodes:          %d or more\n", MaxAnalyzedNodes); !strings.Contains(got, want) {
+		t.Errorf("Analysis.String() does not contain %q:\n%s", want, got)
+	}
+}
@@ -0,0 +1,144 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// BloomFilter is a probabilistic set of UUIDs. Contains never reports false
+// for a UUID that was added, but may report true for one that was not, at
+// roughly the false-positive rate the filter was sized for.
+//
+// Bit positions are derived by double hashing two 64-bit values taken
+// directly from the UUID bytes, with a single multiply-xorshift mixing step
+// of their combination so that time-ordered versions, whose leading bytes are
+// far from uniform, spread as well as random ones. A BloomFilter is not safe
+// for concurrent use.
+type BloomFilter struct {
+	bits []uint64
+	m    uint64 // number of bits
+	k    uint64 // number of hash functions
+	n    uint64 // number of Add calls that set at least one bit
+}
+
+// NewBloomFilter returns an empty BloomFilter sized to hold expected UUIDs
+// with a false-positive probability of about fpRate. It will return an error
+// if expected is not positive or fpRate is not in the open interval (0, 1).
+func NewBloomFilter(expected int, fpRate float64) (*BloomFilter, error) {
+	if expected <= 0 {
+		return nil, fmt.Errorf("%w: expected count %d must be positive", ErrInvalidArgument, expected)
+	}
+	if !(fpRate > 0 && fpRate < 1) {
+		return nil, fmt.Errorf("%w: false-positive rate %g must be between 0 and 1", ErrInvalidArgument, fpRate)
+	}
+	m := math.Ceil(-float64(expected) * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	// k is stored in a single byte by MarshalBinary.
+	k := math.Min(255, math.Max(1, math.Round(m/float64(expected)*math.Ln2)))
+	words := (uint64(m) + 63) / 64
+	return &BloomFilter{
+		bits: make([]uint64, words),
+		m:    words * 64,
+		k:    uint64(k),
+	}, nil
+}
+
+// bloomHashes returns the two base hashes used to derive bit positions.
+func bloomHashes(u UUID) (uint64, uint64) {
+	hi, lo := u.Uint64Pair()
+	h1 := hashMix(hi ^ lo)
+	h2 := bits.RotateLeft64(h1, 32) ^ lo
+	return h1, h2
+}
+
+// Add adds u to the filter.
+func (f *BloomFilter) Add(u UUID) {
+	h1, h2 := bloomHashes(u)
+	added := false
+	for i := uint64(0); i < f.k; i++ {
+		pos := (h1 + i*h2) % f.m
+		mask := uint64(1) << (pos % 64)
+		if f.bits[pos/64]&mask == 0 {
+			f.bits[pos/64] |= mask
+			added = true
+		}
+	}
+	if added {
+		f.n++
+	}
+}
+
+// Contains reports whether u may have been added to the filter. A false
+// result is definite; a true result is wrong with the filter's false-positive
+// probability.
+func (f *BloomFilter) Contains(u UUID) bool {
+	h1, h2 := bloomHashes(u)
+	for i := uint64(0); i < f.k; i++ {
+		pos := (h1 + i*h2) % f.m
+		if f.bits[pos/64]&(uint64(1)<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Len returns the number of distinct UUIDs added to the filter, as far as
+// the filter can tell. It undercounts when additions were false positives.
+func (f *BloomFilter) Len() int {
+	return int(f.n)
+}
+
+// Reset removes every UUID from the filter.
+func (f *BloomFilter) Reset() {
+	for i := range f.bits {
+		f.bits[i] = 0
+	}
+	f.n = 0
+}
+
+// bloomFormatVersion identifies the binary encoding produced by MarshalBinary.
+const bloomFormatVersion = 1
+
+// bloomHeaderSize is the size of the encoding before the bit array: the
+// format version, the number of hash functions, the number of bits and the
+// number of members.
+const bloomHeaderSize = 1 + 1 + 8 + 8
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+//
+// The encoding is a header of a format version byte, the number of hash
+// functions as a byte, and the number of bits and members as big-endian
+// uint64s, followed by the bit array as big-endian uint64 words.
+func (f *BloomFilter) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, bloomHeaderSize+8*len(f.bits))
+	buf[0] = bloomFormatVersion
+	buf[1] = byte(f.k)
+	binary.BigEndian.PutUint64(buf[2:], f.m)
+	binary.BigEndian.PutUint64(buf[10:], f.n)
+	for i, w := range f.bits {
+		binary.BigEndian.PutUint64(buf[bloomHeaderSize+8*i:], w)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// replaces the filter's size and contents with the decoded ones.
+func (f *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < bloomHeaderSize || data[0] != bloomFormatVersion {
+		return fmt.Errorf("%w: unsupported BloomFilter encoding", ErrInvalidFormat)
+	}
+	k := uint64(data[1])
+	m := binary.BigEndian.Uint64(data[2:])
+	n := binary.BigEndian.Uint64(data[10:])
+	data = data[bloomHeaderSize:]
+	if k == 0 || m == 0 || m%64 != 0 || m/8 != uint64(len(data)) {
+		return fmt.Errorf("%w: corrupt BloomFilter header", ErrInvalidFormat)
+	}
+	bits := make([]uint64, m/64)
+	for i := range bits {
+		bits[i] = binary.BigEndian.Uint64(data[8*i:])
+	}
+	f.bits, f.m, f.k, f.n = bits, m, k, n
+	return nil
+}
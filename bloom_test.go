@@ -0,0 +1,148 @@
+package uuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewBloomFilter(t *testing.T) {
+	invalid := []struct {
+		n int
+		p float64
+	}{
+		{n: 0, p: 0.01},
+		{n: -1, p: 0.01},
+		{n: 10, p: 0},
+		{n: 10, p: 1},
+		{n: 10, p: -0.5},
+	}
+	for _, tt := range invalid {
+		if _, err := NewBloomFilter(tt.n, tt.p); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("NewBloomFilter(%d, %g) error = %v, want %v", tt.n, tt.p, err, ErrInvalidArgument)
+		}
+	}
+
+	f, err := NewBloomFilter(1000, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// About 9.6 bits and 7 hash functions per element for a 1% rate.
+	if f.m < 9585 || f.m > 9585+64 || f.k != 7 {
+		t.Errorf("NewBloomFilter(1000, 0.01) has m = %d, k = %d", f.m, f.k)
+	}
+}
+
+func TestBloomFilter(t *testing.T) {
+	const n = 10000
+	const fpRate = 0.01
+	for name, gen := range map[string]func(g *Gen, i int) (UUID, error){
+		"V1": func(g *Gen, i int) (UUID, error) {
+			return g.NewV1AtTime(time.UnixMilli(1700000000000 + int64(i)))
+		},
+		"V4": func(g *Gen, i int) (UUID, error) { return g.NewV4() },
+		"V7": func(g *Gen, i int) (UUID, error) {
+			return g.NewV7AtTime(time.UnixMilli(1700000000000 + int64(i/16)))
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			f, err := NewBloomFilter(n, fpRate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			g := NewGenWithOptions(WithCustomPRNG(1))
+			for i := 0; i < n; i++ {
+				u, err := gen(g, i)
+				if err != nil {
+					t.Fatal(err)
+				}
+				f.Add(u)
+				if !f.Contains(u) {
+					t.Fatalf("Contains(%v) = false after Add", u)
+				}
+			}
+			if f.Len() < n*99/100 || f.Len() > n {
+				t.Errorf("Len() = %d, want about %d", f.Len(), n)
+			}
+
+			falsePositives := 0
+			for i := n; i < 2*n; i++ {
+				u, err := gen(g, i)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if f.Contains(u) {
+					falsePositives++
+				}
+			}
+			if rate := float64(falsePositives) / n; rate > 2*fpRate {
+				t.Errorf("false-positive rate = %g, want about %g", rate, fpRate)
+			}
+
+			f.Reset()
+			if f.Len() != 0 {
+				t.Errorf("Len() = %d after Reset", f.Len())
+			}
+		})
+	}
+}
+
+func TestBloomFilterBinary(t *testing.T) {
+	f, err := NewBloomFilter(100, 0.001)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var uuids []UUID
+	for i := 0; i < 100; i++ {
+		u := Must(NewV4())
+		uuids = append(uuids, u)
+		f.Add(u)
+	}
+	data, err := f.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got BloomFilter
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if got.m != f.m || got.k != f.k || got.n != f.n {
+		t.Errorf("UnmarshalBinary() = (m %d, k %d, n %d), want (m %d, k %d, n %d)", got.m, got.k, got.n, f.m, f.k, f.n)
+	}
+	for _, u := range uuids {
+		if !got.Contains(u) {
+			t.Errorf("Contains(%v) = false after UnmarshalBinary", u)
+		}
+	}
+
+	corrupt := map[string][]byte{
+		"Empty":     nil,
+		"Version":   append([]byte{0}, data[1:]...),
+		"Truncated": data[:len(data)-1],
+		"NoHashes":  append(append([]byte{bloomFormatVersion}, 0), data[2:]...),
+	}
+	for name, data := range corrupt {
+		if err := got.UnmarshalBinary(data); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("%s: UnmarshalBinary() error = %v, want %v", name, err, ErrInvalidFormat)
+		}
+	}
+}
+
+func BenchmarkBloomFilter(b *testing.B) {
+	f, err := NewBloomFilter(1000000, 0.01)
+	if err != nil {
+		b.Fatal(err)
+	}
+	u := Must(NewV7())
+	b.Run("Add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			f.Add(u)
+		}
+	})
+	b.Run("Contains", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			f.Contains(u)
+		}
+	})
+}
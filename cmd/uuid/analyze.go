@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func init() {
+	commands["analyze"] = &command{
+		summary: "Report the versions, variants and timestamps of UUIDs read from the standard input.",
+		doc: "The input holds one UUID per line, or consecutive 16-byte UUIDs with -binary.\n" +
+			"The report counts the malformed lines, the UUIDs of each variant and version,\n" +
+			"and the distinct node IDs of version 1, with the range of the times of\n" +
+			"versions 1, 6 and 7.\n",
+		run: runAnalyze,
+	}
+}
+
+func runAnalyze(e *env, args []string) int {
+	fs := newFlagSet(e, "analyze", "[-binary] < input")
+	binary := fs.Bool("binary", false, "read consecutive 16-byte UUIDs instead of lines")
+	if status, ok := parseFlags(fs, args); !ok {
+		return status
+	}
+	if fs.NArg() > 0 {
+		return usageError(e, "analyze", "unexpected argument %q", fs.Arg(0))
+	}
+
+	analyze := uuid.Analyze
+	if *binary {
+		analyze = uuid.AnalyzeBinary
+	}
+	a, err := analyze(e.stdin)
+	if err != nil {
+		return fail(e, "analyze", fmt.Errorf("reading input: %w", err))
+	}
+	if _, err := io.WriteString(e.stdout, a.String()); err != nil {
+		return fail(e, "analyze", fmt.Errorf("writing output: %w", err))
+	}
+	return exitOK
+}
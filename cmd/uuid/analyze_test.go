@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestAnalyze(t *testing.T) {
+	v1 := uuid.NamespaceDNS
+	v7 := uuid.Must(uuid.FromString("01890a5d-ac96-774b-bcce-b302099a8057"))
+	text := v1.String() + "\n" + v7.String() + "\nnot a uuid\n"
+	binary := string(v1[:]) + string(v7[:])
+
+	tests := []struct {
+		args  []string
+		stdin string
+		want  []string
+	}{
+		{[]string{"analyze"}, text, []string{
+			"UUIDs:          2",
+			"Malformed:      1",
+			"Version 1:      1 (date-time and MAC address)",
+			"Version 7:      1 (Unix epoch date-time)",
+			"Nodes:          1",
+		}},
+		{[]string{"analyze", "-binary"}, binary, []string{
+			"UUIDs:          2",
+			"Malformed:      0",
+			"Max time:       2023-06-30T",
+		}},
+	}
+	for _, tt := range tests {
+		status, stdout, stderr := runTest(tt.args, tt.stdin)
+		if status != exitOK || stderr != "" {
+			t.Errorf("uuid %s = %d, %q", strings.Join(tt.args, " "), status, stderr)
+		}
+		for _, line := range tt.want {
+			if !strings.Contains(stdout, line) {
+				t.Errorf("uuid %s output does not contain %q:\n%s", strings.Join(tt.args, " "), line, stdout)
+			}
+		}
+	}
+
+	if status, _, _ := runTest([]string{"analyze", "extra"}, ""); status != exitUsage {
+		t.Errorf("uuid analyze extra = %d, want %d", status, exitUsage)
+	}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bufio"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func init() {
+	commands["bench"] = &command{
+		summary: "Measure the throughput and allocations of UUID generation on this machine.",
+		doc: "The configurations are:\n" +
+			"  default    the package-level functions, with uuid.DefaultGenerator\n" +
+			"  buffered   a uuid.Gen reading crypto/rand through a shared, locked 4 KiB buffer\n" +
+			"  sharded    a uuid.Gen per goroutine, each reading crypto/rand through its own buffer\n" +
+			"  monotonic  uuid.MonotonicGen.GenerateBatchV7 in batches of 100, version 7 only\n",
+		run: runBench,
+	}
+}
+
+// benchBatchSize is the number of UUIDs generated by each call to
+// GenerateBatchV7 in the monotonic configuration.
+const benchBatchSize = 100
+
+// lockedReader is an io.Reader safe for concurrent use.
+type lockedReader struct {
+	mu sync.Mutex
+	r  io.Reader
+}
+
+func (r *lockedReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.r.Read(p)
+}
+
+// newBufferedGen returns a uuid.Gen reading crypto/rand through a buffer.
+func newBufferedGen() *uuid.Gen {
+	return uuid.NewGenWithOptions(uuid.WithRandomReader(&lockedReader{r: bufio.NewReaderSize(rand.Reader, 4096)}))
+}
+
+// benchConfig is a generator configuration measured by bench.
+type benchConfig struct {
+	// worker returns the function generating UUIDs of the given version for
+	// a goroutine, and the number of UUIDs it generates per call. It returns
+	// a nil function for versions the configuration does not support.
+	worker func(version byte) (func() error, int)
+}
+
+// generatorFunc returns the method of g generating UUIDs of the given
+// version.
+func generatorFunc(g uuid.Generator, version byte) func() error {
+	gen := map[byte]func() (uuid.UUID, error){
+		uuid.V1: g.NewV1,
+		uuid.V4: g.NewV4,
+		uuid.V6: g.NewV6,
+		uuid.V7: g.NewV7,
+	}[version]
+	return func() error {
+		_, err := gen()
+		return err
+	}
+}
+
+// newBenchConfigs returns the configurations of bench, by name, with new
+// generators.
+func newBenchConfigs() map[string]benchConfig {
+	buffered := newBufferedGen()
+	monotonic := uuid.NewMonotonicGen()
+	return map[string]benchConfig{
+		"default": {func(version byte) (func() error, int) {
+			return generatorFunc(uuid.DefaultGenerator, version), 1
+		}},
+		"buffered": {func(version byte) (func() error, int) {
+			return generatorFunc(buffered, version), 1
+		}},
+		"sharded": {func(version byte) (func() error, int) {
+			return generatorFunc(newBufferedGen(), version), 1
+		}},
+		"monotonic": {func(version byte) (func() error, int) {
+			if version != uuid.V7 {
+				return nil, 0
+			}
+			return func() error {
+				_, err := monotonic.GenerateBatchV7(benchBatchSize)
+				return err
+			}, benchBatchSize
+		}},
+	}
+}
+
+// benchResult is the result of a benchmark.
+type benchResult struct {
+	n       uint64
+	elapsed time.Duration
+	allocs  uint64
+	bytes   uint64
+}
+
+// nsPerOp returns the elapsed time per UUID, the inverse of the throughput
+// of all the goroutines.
+func (r benchResult) nsPerOp() float64 {
+	return float64(r.elapsed.Nanoseconds()) / float64(r.n)
+}
+
+// perSecond returns the number of UUIDs generated per second.
+func (r benchResult) perSecond() float64 {
+	return float64(r.n) / r.elapsed.Seconds()
+}
+
+// measure runs the functions returned by worker on the given number of
+// goroutines for duration d, and returns the number of UUIDs generated and
+// the memory allocated.
+func measure(worker func() (func() error, int), goroutines int, d time.Duration) (benchResult, error) {
+	var (
+		n       uint64
+		stop    atomic.Bool
+		wg      sync.WaitGroup
+		errOnce sync.Once
+		err     error
+		failed  = make(chan struct{})
+	)
+	fns := make([]func() error, goroutines)
+	perCall := 1
+	for i := range fns {
+		fns[i], perCall = worker()
+	}
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	for _, fn := range fns {
+		wg.Add(1)
+		go func(fn func() error) {
+			defer wg.Done()
+			var calls uint64
+			for !stop.Load() {
+				for i := 0; i < 64; i++ {
+					if e := fn(); e != nil {
+						errOnce.Do(func() {
+							err = e
+							close(failed)
+						})
+						stop.Store(true)
+						break
+					}
+					calls++
+				}
+			}
+			atomic.AddUint64(&n, calls*uint64(perCall))
+		}(fn)
+	}
+	select {
+	case <-time.After(d):
+	case <-failed:
+	}
+	stop.Store(true)
+	wg.Wait()
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+	if err != nil {
+		return benchResult{}, err
+	}
+	return benchResult{
+		n:       n,
+		elapsed: elapsed,
+		allocs:  after.Mallocs - before.Mallocs,
+		bytes:   after.TotalAlloc - before.TotalAlloc,
+	}, nil
+}
+
+// parseVersions returns the versions of a comma-separated list.
+func parseVersions(s string) ([]byte, error) {
+	var versions []byte
+	for _, f := range strings.Split(s, ",") {
+		v, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(f), "v"))
+		if err != nil || (v != 1 && v != 4 && v != 6 && v != 7) {
+			return nil, fmt.Errorf("invalid version %q: not one of 1, 4, 6 and 7", f)
+		}
+		versions = append(versions, byte(v))
+	}
+	return versions, nil
+}
+
+func runBench(e *env, args []string) int {
+	fs := newFlagSet(e, "bench", "[-versions list] [-configs list] [-duration d] [-goroutines n]")
+	versionsFlag := fs.String("versions", "1,4,6,7", "the comma-separated versions to measure")
+	configsFlag := fs.String("configs", "default,buffered,sharded,monotonic", "the comma-separated configurations to measure")
+	d := fs.Duration("duration", time.Second, "the duration of each measurement")
+	goroutines := fs.Int("goroutines", 1, "the number of goroutines generating UUIDs, or 0 for GOMAXPROCS")
+	if status, ok := parseFlags(fs, args); !ok {
+		return status
+	}
+	if fs.NArg() > 0 {
+		return usageError(e, "bench", "unexpected argument %q", fs.Arg(0))
+	}
+	versions, err := parseVersions(*versionsFlag)
+	if err != nil {
+		return usageError(e, "bench", "%v", err)
+	}
+	configs := newBenchConfigs()
+	names := strings.Split(*configsFlag, ",")
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+		if _, ok := configs[names[i]]; !ok {
+			return usageError(e, "bench", "unknown configuration %q: not one of default, buffered, sharded and monotonic", name)
+		}
+	}
+	if *d <= 0 {
+		return usageError(e, "bench", "invalid duration %v", *d)
+	}
+	if *goroutines < 0 {
+		return usageError(e, "bench", "invalid number of goroutines %d", *goroutines)
+	}
+	if *goroutines == 0 {
+		*goroutines = runtime.GOMAXPROCS(0)
+	}
+
+	fmt.Fprintf(e.stdout, "%s/%s, %d CPUs, %d goroutines\n\n", runtime.GOOS, runtime.GOARCH, runtime.NumCPU(), *goroutines)
+	w := tabwriter.NewWriter(e.stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "config\tversion\tUUIDs/s\tns/UUID\tB/UUID\tallocs/UUID\t")
+	for _, name := range names {
+		for _, version := range versions {
+			config := configs[name]
+			if fn, _ := config.worker(version); fn == nil {
+				continue
+			}
+			r, err := measure(func() (func() error, int) { return config.worker(version) }, *goroutines, *d)
+			if err != nil {
+				w.Flush()
+				return fail(e, "bench", fmt.Errorf("%s version %d: %w", name, version, err))
+			}
+			fmt.Fprintf(w, "%s\t%d\t%.0f\t%.1f\t%.1f\t%.2f\t\n", name, version, r.perSecond(), r.nsPerOp(),
+				float64(r.bytes)/float64(r.n), float64(r.allocs)/float64(r.n))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fail(e, "bench", fmt.Errorf("writing output: %w", err))
+	}
+	return exitOK
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBench(t *testing.T) {
+	status, stdout, stderr := runTest([]string{"bench", "-duration", "10ms", "-versions", "v4,7", "-configs", "default,sharded,monotonic", "-goroutines", "2"}, "")
+	if status != exitOK || stderr != "" {
+		t.Fatalf("uuid bench = %d, %q", status, stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	if !strings.Contains(lines[0], "2 goroutines") {
+		t.Errorf("uuid bench header = %q", lines[0])
+	}
+	var rows []string
+	for _, line := range lines[3:] {
+		f := strings.Fields(line)
+		if len(f) != 6 {
+			t.Fatalf("uuid bench row %q has %d columns", line, len(f))
+		}
+		rows = append(rows, f[0]+" "+f[1])
+	}
+	if got, want := strings.Join(rows, ","), "default 4,default 7,sharded 4,sharded 7,monotonic 7"; got != want {
+		t.Errorf("uuid bench rows = %s, want %s", got, want)
+	}
+}
+
+func TestBenchUsageErrors(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"bench", "-versions", "3"}, `invalid version "3"`},
+		{[]string{"bench", "-configs", "default,fast"}, `unknown configuration "fast"`},
+		{[]string{"bench", "-duration", "0s"}, "invalid duration 0s"},
+		{[]string{"bench", "-goroutines", "-1"}, "invalid number of goroutines -1"},
+	}
+	for _, tt := range tests {
+		status, _, stderr := runTest(tt.args, "")
+		if status != exitUsage || !strings.Contains(stderr, tt.want) {
+			t.Errorf("uuid %v = %d, %q, want usage error %q", tt.args, status, stderr, tt.want)
+		}
+	}
+}
+
+func TestMeasure(t *testing.T) {
+	r, err := measure(func() (func() error, int) { return func() error { return nil }, 10 }, 2, 10*time.Millisecond)
+	if err != nil || r.n == 0 || r.n%10 != 0 || r.elapsed < 10*time.Millisecond {
+		t.Errorf("measure() = %+v, %v", r, err)
+	}
+
+	errBoom := errors.New("boom")
+	calls := 0
+	_, err = measure(func() (func() error, int) {
+		return func() error {
+			calls++
+			if calls > 100 {
+				return errBoom
+			}
+			return nil
+		}, 1
+	}, 1, time.Second)
+	if err != errBoom {
+		t.Errorf("measure() error = %v, want %v", err, errBoom)
+	}
+}
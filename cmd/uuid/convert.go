@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func init() {
+	commands["convert"] = &command{
+		summary: "Convert UUIDs between formats, and version 1 UUIDs to version 6 and back.",
+		doc: "The UUIDs are read from the arguments, or one per line from the standard input.\n" +
+			"The msbytes format is the 32 hex digits of the bytes stored by Microsoft SQL Server,\n" +
+			"and base58 the integer value of the UUID in the Bitcoin alphabet.\n",
+		run: runConvert,
+	}
+}
+
+// base58Alphabet is the Bitcoin alphabet of base58.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var bigBase58 = big.NewInt(58)
+
+// encodeBase58 returns the 128-bit integer value of u in base58, without
+// padding: uuid.Nil is "1" and uuid.Max "YcVfxkQb6JRzqk5kF2tNLv".
+func encodeBase58(u uuid.UUID) string {
+	n := u.BigInt()
+	if n.Sign() == 0 {
+		return base58Alphabet[:1]
+	}
+	var buf [22]byte
+	i := len(buf)
+	mod := new(big.Int)
+	for n.Sign() > 0 {
+		n.DivMod(n, bigBase58, mod)
+		i--
+		buf[i] = base58Alphabet[mod.Int64()]
+	}
+	return string(buf[i:])
+}
+
+// decodeBase58 returns the UUID encoded by encodeBase58 as s.
+func decodeBase58(s string) (uuid.UUID, error) {
+	if s == "" {
+		return uuid.Nil, errors.New("empty base58 string")
+	}
+	n := new(big.Int)
+	for i := 0; i < len(s); i++ {
+		d := strings.IndexByte(base58Alphabet, s[i])
+		if d < 0 {
+			return uuid.Nil, fmt.Errorf("invalid base58 character %q", s[i])
+		}
+		n.Mul(n, bigBase58)
+		n.Add(n, big.NewInt(int64(d)))
+	}
+	return uuid.FromBigInt(n)
+}
+
+// decoders are the input formats accepted by -from.
+var decoders = map[string]func(string) (uuid.UUID, error){
+	"text":   uuid.FromString,
+	"base58": decodeBase58,
+	"msbytes": func(s string) (uuid.UUID, error) {
+		b, err := uuid.FromString(s)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		u, err := uuid.MSSQLUUIDFromBytes(b[:])
+		return uuid.UUID(u), err
+	},
+}
+
+// encoders are the output formats accepted by -to, in addition to formats.
+var encoders = map[string]func(uuid.UUID) string{
+	"base58": encodeBase58,
+	"msbytes": func(u uuid.UUID) string {
+		return hex.EncodeToString(uuid.MSSQLUUID(u).MSSQLBytes())
+	},
+}
+
+// convertVersion returns u converted to the given version, which is 0 for no
+// conversion.
+func convertVersion(u uuid.UUID, version int) (uuid.UUID, error) {
+	switch {
+	case version == 0 || int(u.Version()) == version:
+		return u, nil
+	case version == 6:
+		return uuid.V1ToV6(u)
+	default:
+		return uuid.V6ToV1(u)
+	}
+}
+
+func runConvert(e *env, args []string) int {
+	fs := newFlagSet(e, "convert", "[-from format] [-to format] [-version 1|6] [uuid ...]")
+	from := fs.String("from", "text", "the input format: text (any form accepted by uuid.FromString, also named canonical, hash, braced or urn), base58 or msbytes")
+	to := fs.String("to", "canonical", "the output format: canonical, hash, braced, urn, base58 or msbytes")
+	version := fs.Int("version", 0, "convert version 1 UUIDs to version 6, or version 6 UUIDs to version 1, as in RFC 9562")
+	if status, ok := parseFlags(fs, args); !ok {
+		return status
+	}
+
+	fromName := strings.ToLower(*from)
+	if _, ok := formats[fromName]; ok {
+		fromName = "text"
+	}
+	decode, ok := decoders[fromName]
+	if !ok {
+		return usageError(e, "convert", "unknown input format %q: not one of text, canonical, hash, braced, urn, base58 and msbytes", *from)
+	}
+	encode, ok := encoders[strings.ToLower(*to)]
+	if !ok {
+		f, err := parseFormat(*to)
+		if err != nil {
+			return usageError(e, "convert", "unknown output format %q: not one of canonical, hash, braced, urn, base58 and msbytes", *to)
+		}
+		encode = func(u uuid.UUID) string { return string(u.AppendFormat(nil, f)) }
+	}
+	if *version != 0 && *version != 1 && *version != 6 {
+		return usageError(e, "convert", "invalid version %d: only 1 and 6 are supported", *version)
+	}
+
+	w := bufio.NewWriter(e.stdout)
+	status := exitOK
+	convert := func(where, s string) {
+		u, err := decode(s)
+		if err == nil {
+			u, err = convertVersion(u, *version)
+		}
+		if err != nil {
+			fmt.Fprintf(e.stderr, "uuid convert: %s: %v\n", where, err)
+			status = exitError
+			return
+		}
+		w.WriteString(encode(u))
+		w.WriteByte('\n')
+	}
+	if fs.NArg() > 0 {
+		for i, arg := range fs.Args() {
+			convert(fmt.Sprintf("argument %d", i+1), arg)
+		}
+	} else {
+		sc := bufio.NewScanner(e.stdin)
+		for line := 1; sc.Scan(); line++ {
+			if s := strings.TrimSpace(sc.Text()); s != "" {
+				convert(fmt.Sprintf("line %d", line), s)
+			}
+		}
+		if err := sc.Err(); err != nil {
+			w.Flush()
+			return fail(e, "convert", fmt.Errorf("reading input: %w", err))
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fail(e, "convert", fmt.Errorf("writing output: %w", err))
+	}
+	return status
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const convertTestUUID = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+
+func TestBase58(t *testing.T) {
+	tests := []struct {
+		u    uuid.UUID
+		want string
+	}{
+		{uuid.Nil, "1"},
+		{uuid.Max, "YcVfxkQb6JRzqk5kF2tNLv"},
+		{uuid.Must(uuid.FromString(convertTestUUID)), "EJ34kCVxxF9jHMKD4EgrAK"},
+	}
+	for _, tt := range tests {
+		if got := encodeBase58(tt.u); got != tt.want {
+			t.Errorf("encodeBase58(%v) = %q, want %q", tt.u, got, tt.want)
+		}
+		if u, err := decodeBase58(tt.want); err != nil || u != tt.u {
+			t.Errorf("decodeBase58(%q) = %v, %v, want %v", tt.want, u, err, tt.u)
+		}
+	}
+	for _, s := range []string{"", "0", "Il", "YcVfxkQb6JRzqk5kF2tNLw"} {
+		if u, err := decodeBase58(s); err == nil {
+			t.Errorf("decodeBase58(%q) = %v, want an error", s, u)
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	u := uuid.Must(uuid.FromString(convertTestUUID))
+	v6, _ := uuid.V1ToV6(u)
+	tests := []struct {
+		args  []string
+		stdin string
+		want  string
+	}{
+		{[]string{"convert", "{" + convertTestUUID + "}"}, "", convertTestUUID + "\n"},
+		{[]string{"convert", "-to", "urn", convertTestUUID, "6BA7B8119DAD11D180B400C04FD430C8"}, "",
+			"urn:uuid:" + convertTestUUID + "\nurn:uuid:6ba7b811-9dad-11d1-80b4-00c04fd430c8\n"},
+		{[]string{"convert", "-to", "hash"}, convertTestUUID + "\n\n  urn:uuid:" + convertTestUUID + "  \n", strings.Repeat(u.HashString()+"\n", 2)},
+		{[]string{"convert", "-to", "base58", convertTestUUID}, "", "EJ34kCVxxF9jHMKD4EgrAK\n"},
+		{[]string{"convert", "--from", "base58", "--to", "braced", "EJ34kCVxxF9jHMKD4EgrAK"}, "", "{" + convertTestUUID + "}\n"},
+		{[]string{"convert", "-to", "msbytes", convertTestUUID}, "", "10b8a76bad9dd11180b400c04fd430c8\n"},
+		{[]string{"convert", "-from", "msbytes", "10b8a76bad9dd11180b400c04fd430c8"}, "", convertTestUUID + "\n"},
+		{[]string{"convert", "-from", "urn", "-version", "6", convertTestUUID}, "", v6.String() + "\n"},
+		{[]string{"convert", "-version", "1", v6.String()}, "", convertTestUUID + "\n"},
+		{[]string{"convert", "-version", "6", v6.String()}, "", v6.String() + "\n"},
+	}
+	for _, tt := range tests {
+		status, stdout, stderr := runTest(tt.args, tt.stdin)
+		if status != exitOK || stdout != tt.want || stderr != "" {
+			t.Errorf("uuid %v = %d, %q, %q, want %q", tt.args, status, stdout, stderr, tt.want)
+		}
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	status, stdout, stderr := runTest([]string{"convert"}, convertTestUUID+"\nnope\n"+convertTestUUID+"\n")
+	if status != exitError || stdout != strings.Repeat(convertTestUUID+"\n", 2) || !strings.Contains(stderr, "uuid convert: line 2: ") {
+		t.Errorf("uuid convert with an invalid line = %d, %q, %q", status, stdout, stderr)
+	}
+	status, _, stderr = runTest([]string{"convert", "-version", "6", convertTestUUID, uuid.Max.String()}, "")
+	if status != exitError || !strings.Contains(stderr, "uuid convert: argument 2: ") {
+		t.Errorf("uuid convert -version 6 of a version 15 UUID = %d, %q", status, stderr)
+	}
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"convert", "-from", "base64"}, `unknown input format "base64"`},
+		{[]string{"convert", "-to", "base64"}, `unknown output format "base64"`},
+		{[]string{"convert", "-version", "7"}, "invalid version 7"},
+	}
+	for _, tt := range tests {
+		status, _, stderr := runTest(tt.args, "")
+		if status != exitUsage || !strings.Contains(stderr, tt.want) {
+			t.Errorf("uuid %v = %d, %q, want usage error %q", tt.args, status, stderr, tt.want)
+		}
+	}
+}
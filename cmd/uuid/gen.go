@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func init() {
+	commands["gen"] = &command{
+		summary: "Generate UUIDs, version 4 by default.",
+		run:     runGen,
+	}
+}
+
+// namespaces are the names of the predefined namespaces accepted by -ns.
+var namespaces = map[string]uuid.UUID{
+	"dns":  uuid.NamespaceDNS,
+	"url":  uuid.NamespaceURL,
+	"oid":  uuid.NamespaceOID,
+	"x500": uuid.NamespaceX500,
+}
+
+// formats are the names of the text formats accepted by -format.
+var formats = map[string]uuid.Format{
+	"canonical": uuid.FormatCanonical,
+	"hash":      uuid.FormatHash,
+	"braced":    uuid.FormatBraced,
+	"urn":       uuid.FormatURN,
+}
+
+// parseNamespace returns the namespace named or formatted by s.
+func parseNamespace(s string) (uuid.UUID, error) {
+	if ns, ok := namespaces[strings.ToLower(s)]; ok {
+		return ns, nil
+	}
+	ns, err := uuid.FromString(s)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid namespace %q: not a UUID or one of dns, url, oid and x500", s)
+	}
+	return ns, nil
+}
+
+// streamFormat is an output format of gen.
+type streamFormat struct {
+	// header is written before the UUIDs.
+	header string
+	// append appends a UUID, and its separator, to b.
+	append func(b []byte, u uuid.UUID) []byte
+}
+
+// streamFormats are the formats accepted by the -format flag of gen, in
+// addition to formats.
+var streamFormats = map[string]streamFormat{
+	"ndjson": {append: func(b []byte, u uuid.UUID) []byte {
+		b = append(b, `{"uuid":"`...)
+		return append(u.AppendFormat(b, uuid.FormatCanonical), "\"}\n"...)
+	}},
+	"csv": {header: "uuid\n", append: func(b []byte, u uuid.UUID) []byte {
+		return append(u.AppendFormat(b, uuid.FormatCanonical), '\n')
+	}},
+	"binary": {append: func(b []byte, u uuid.UUID) []byte {
+		return append(b, u[:]...)
+	}},
+}
+
+// parseStreamFormat returns the format of gen named by s.
+func parseStreamFormat(s string) (streamFormat, error) {
+	if f, ok := streamFormats[strings.ToLower(s)]; ok {
+		return f, nil
+	}
+	f, err := parseFormat(s)
+	if err != nil {
+		return streamFormat{}, fmt.Errorf("unknown format %q: not one of canonical, hash, braced, urn, ndjson, csv and binary", s)
+	}
+	return streamFormat{append: func(b []byte, u uuid.UUID) []byte {
+		return append(u.AppendFormat(b, f), '\n')
+	}}, nil
+}
+
+// parseFormat returns the format named by s.
+func parseFormat(s string) (uuid.Format, error) {
+	f, ok := formats[strings.ToLower(s)]
+	if !ok {
+		return 0, fmt.Errorf("unknown format %q: not one of canonical, hash, braced and urn", s)
+	}
+	return f, nil
+}
+
+func runGen(e *env, args []string) int {
+	fs := newFlagSet(e, "gen", "[-v1|-v3|-v4|-v5|-v6|-v7] [-n count] [-ns namespace -name name] [-format format] [-rate rate]")
+	versions := []struct {
+		version byte
+		set     *bool
+	}{
+		{uuid.V1, fs.Bool("v1", false, "generate version 1 UUIDs, from the time and MAC address")},
+		{uuid.V3, fs.Bool("v3", false, "generate the version 3 UUID, the MD5 hash of -ns and -name")},
+		{uuid.V4, fs.Bool("v4", false, "generate version 4 UUIDs, random (the default)")},
+		{uuid.V5, fs.Bool("v5", false, "generate the version 5 UUID, the SHA-1 hash of -ns and -name")},
+		{uuid.V6, fs.Bool("v6", false, "generate version 6 UUIDs, from the time, sortable")},
+		{uuid.V7, fs.Bool("v7", false, "generate version 7 UUIDs, from the Unix time in milliseconds, sortable")},
+	}
+	n := fs.Int("n", 1, "the number of UUIDs to generate; name-based versions only accept 1")
+	nsFlag := fs.String("ns", "", "the namespace of -v3 and -v5: a UUID or one of dns, url, oid and x500")
+	name := fs.String("name", "", "the name of -v3 and -v5")
+	formatFlag := fs.String("format", "canonical", "the output format: canonical, hash, braced or urn, one per line, ndjson, csv with a header, or binary, 16 bytes per UUID")
+	rate := fs.Float64("rate", 0, "the maximum number of UUIDs generated per second, or 0 for no limit")
+	if status, ok := parseFlags(fs, args); !ok {
+		return status
+	}
+	if fs.NArg() > 0 {
+		return usageError(e, "gen", "unexpected argument %q", fs.Arg(0))
+	}
+
+	version := byte(uuid.V4)
+	count := 0
+	for _, v := range versions {
+		if *v.set {
+			version = v.version
+			count++
+		}
+	}
+	if count > 1 {
+		return usageError(e, "gen", "only one of -v1, -v3, -v4, -v5, -v6 and -v7 may be given")
+	}
+	format, err := parseStreamFormat(*formatFlag)
+	if err != nil {
+		return usageError(e, "gen", "%v", err)
+	}
+	if *n < 0 {
+		return usageError(e, "gen", "invalid count %d", *n)
+	}
+	if *rate < 0 {
+		return usageError(e, "gen", "invalid rate %g", *rate)
+	}
+
+	var gen func() (uuid.UUID, error)
+	switch version {
+	case uuid.V3, uuid.V5:
+		if *nsFlag == "" {
+			return usageError(e, "gen", "-v%d requires -ns", version)
+		}
+		ns, err := parseNamespace(*nsFlag)
+		if err != nil {
+			return usageError(e, "gen", "%v", err)
+		}
+		if *n != 1 {
+			return usageError(e, "gen", "-v%d generates a single UUID, not %d", version, *n)
+		}
+		newName := uuid.NewV5
+		if version == uuid.V3 {
+			newName = uuid.NewV3
+		}
+		gen = func() (uuid.UUID, error) { return newName(ns, *name), nil }
+	default:
+		if *nsFlag != "" || *name != "" {
+			return usageError(e, "gen", "-ns and -name only apply to -v3 and -v5")
+		}
+		gen = map[byte]func() (uuid.UUID, error){
+			uuid.V1: uuid.NewV1,
+			uuid.V4: uuid.NewV4,
+			uuid.V6: uuid.NewV6,
+			uuid.V7: uuid.NewV7,
+		}[version]
+	}
+
+	if err := generate(e, gen, *n, format, *rate); err != nil {
+		return fail(e, "gen", err)
+	}
+	return exitOK
+}
+
+// generate writes n UUIDs of gen to the standard output of e in the given
+// format, at most rate per second if rate is positive. The output is
+// buffered, and flushed whenever generate waits for the rate limit.
+func generate(e *env, gen func() (uuid.UUID, error), n int, format streamFormat, rate float64) error {
+	w := bufio.NewWriter(e.stdout)
+	buf := make([]byte, 0, 64)
+	w.WriteString(format.header)
+	start := time.Now()
+	for i := 0; i < n; i++ {
+		if rate > 0 {
+			if d := time.Until(start.Add(time.Duration(float64(i) / rate * float64(time.Second)))); d > 0 {
+				if err := w.Flush(); err != nil {
+					return fmt.Errorf("writing output: %w", err)
+				}
+				time.Sleep(d)
+			}
+		}
+		u, err := gen()
+		if err != nil {
+			return err
+		}
+		buf = format.append(buf[:0], u)
+		if _, err := w.Write(buf); err != nil {
+			return fmt.Errorf("writing output: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("writing output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestGen(t *testing.T) {
+	tests := []struct {
+		args    []string
+		version byte
+		n       int
+	}{
+		{[]string{"gen"}, uuid.V4, 1},
+		{[]string{"gen", "-v1"}, uuid.V1, 1},
+		{[]string{"gen", "-v4", "-n", "3"}, uuid.V4, 3},
+		{[]string{"gen", "-v6", "-n", "2"}, uuid.V6, 2},
+		{[]string{"-v7", "-n", "5"}, uuid.V7, 5},
+		{[]string{"gen", "-v7", "-n", "0"}, uuid.V7, 0},
+	}
+	for _, tt := range tests {
+		status, stdout, stderr := runTest(tt.args, "")
+		if status != exitOK || stderr != "" {
+			t.Errorf("uuid %v = %d, %q", tt.args, status, stderr)
+			continue
+		}
+		lines := strings.Fields(stdout)
+		if len(lines) != tt.n {
+			t.Errorf("uuid %v printed %d UUIDs, want %d", tt.args, len(lines), tt.n)
+		}
+		for _, line := range lines {
+			u, err := uuid.FromString(line)
+			if err != nil || u.Version() != tt.version || len(line) != 36 {
+				t.Errorf("uuid %v printed %q, want a canonical version %d UUID", tt.args, line, tt.version)
+			}
+		}
+	}
+}
+
+func TestGenNameBased(t *testing.T) {
+	if uuid.FIPS {
+		t.Skip("NewV3 and NewV5 are disabled by the uuid_fips build tag")
+	}
+	ns := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	tests := []struct {
+		args []string
+		want uuid.UUID
+	}{
+		{[]string{"-v5", "-ns", "dns", "-name", "example.com"}, uuid.NewV5(uuid.NamespaceDNS, "example.com")},
+		{[]string{"-v5", "-ns", "URL", "-name", "x"}, uuid.NewV5(uuid.NamespaceURL, "x")},
+		{[]string{"-v3", "-ns", ns, "-name", "example.com"}, uuid.NewV3(uuid.NamespaceDNS, "example.com")},
+		{[]string{"-v3", "-ns", "oid"}, uuid.NewV3(uuid.NamespaceOID, "")},
+	}
+	for _, tt := range tests {
+		status, stdout, stderr := runTest(tt.args, "")
+		if status != exitOK || stdout != tt.want.String()+"\n" {
+			t.Errorf("uuid %v = %d, %q, %q, want %v", tt.args, status, stdout, stderr, tt.want)
+		}
+	}
+}
+
+func TestGenFormat(t *testing.T) {
+	if uuid.FIPS {
+		t.Skip("NewV3 and NewV5 are disabled by the uuid_fips build tag")
+	}
+	u := uuid.NewV5(uuid.NamespaceDNS, "a")
+	for name, format := range formats {
+		status, stdout, _ := runTest([]string{"-v5", "-ns", "dns", "-name", "a", "-format", name}, "")
+		if want := string(u.AppendFormat(nil, format)) + "\n"; status != exitOK || stdout != want {
+			t.Errorf("uuid -format %s = %d, %q, want %q", name, status, stdout, want)
+		}
+	}
+}
+
+func TestGenUsageErrors(t *testing.T) {
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"-v1", "-v4"}, "only one of"},
+		{[]string{"-n", "-1"}, "invalid count -1"},
+		{[]string{"-format", "base64"}, `unknown format "base64"`},
+		{[]string{"-v5", "-name", "a"}, "-v5 requires -ns"},
+		{[]string{"-v3", "-ns", "nope", "-name", "a"}, `invalid namespace "nope"`},
+		{[]string{"-v5", "-ns", "dns", "-name", "a", "-n", "2"}, "single UUID, not 2"},
+		{[]string{"-v4", "-name", "a"}, "only apply to -v3 and -v5"},
+		{[]string{"gen", "extra"}, `unexpected argument "extra"`},
+		{[]string{"-v9"}, "flag provided but not defined: -v9"},
+	}
+	for _, tt := range tests {
+		status, stdout, stderr := runTest(tt.args, "")
+		if status != exitUsage || stdout != "" || !strings.Contains(stderr, tt.want) {
+			t.Errorf("uuid %v = %d, %q, %q, want usage error %q", tt.args, status, stdout, stderr, tt.want)
+		}
+	}
+}
+
+func TestGenStreamFormats(t *testing.T) {
+	status, stdout, _ := runTest([]string{"-v7", "-n", "3", "-format", "ndjson"}, "")
+	lines := strings.Split(strings.TrimSuffix(stdout, "\n"), "\n")
+	if status != exitOK || len(lines) != 3 {
+		t.Fatalf("uuid -format ndjson = %d, %q", status, stdout)
+	}
+	for _, line := range lines {
+		var v struct{ UUID uuid.UUID }
+		if err := json.Unmarshal([]byte(line), &v); err != nil || v.UUID.Version() != uuid.V7 {
+			t.Errorf("ndjson line %q = %v, %v", line, v.UUID, err)
+		}
+	}
+
+	status, stdout, _ = runTest([]string{"-v4", "-n", "2", "-format", "csv"}, "")
+	records, err := csv.NewReader(strings.NewReader(stdout)).ReadAll()
+	if status != exitOK || err != nil || len(records) != 3 || records[0][0] != "uuid" {
+		t.Fatalf("uuid -format csv = %d, %q, %v", status, stdout, err)
+	}
+	for _, rec := range records[1:] {
+		if u, err := uuid.FromString(rec[0]); err != nil || u.Version() != uuid.V4 {
+			t.Errorf("csv record %q = %v, %v", rec, u, err)
+		}
+	}
+	if status, stdout, _ = runTest([]string{"-n", "0", "-format", "csv"}, ""); status != exitOK || stdout != "uuid\n" {
+		t.Errorf("uuid -n 0 -format csv = %d, %q, want the header only", status, stdout)
+	}
+
+	if !uuid.FIPS {
+		status, stdout, _ = runTest([]string{"-v5", "-ns", "dns", "-name", "a", "-format", "binary"}, "")
+		if want := uuid.NewV5(uuid.NamespaceDNS, "a"); status != exitOK || stdout != string(want[:]) {
+			t.Errorf("uuid -format binary = %d, %x, want %x", status, stdout, want[:])
+		}
+	}
+	status, stdout, _ = runTest([]string{"-v7", "-n", "4", "-format", "binary"}, "")
+	if status != exitOK || len(stdout) != 4*uuid.Size {
+		t.Errorf("uuid -n 4 -format binary = %d, %d bytes", status, len(stdout))
+	}
+}
+
+func TestGenRate(t *testing.T) {
+	start := time.Now()
+	status, stdout, _ := runTest([]string{"-v7", "-n", "5", "-rate", "200"}, "")
+	if status != exitOK || strings.Count(stdout, "\n") != 5 {
+		t.Fatalf("uuid -rate 200 = %d, %q", status, stdout)
+	}
+	// The 5th UUID is generated 4/200 s after the first.
+	if d := time.Since(start); d < 20*time.Millisecond {
+		t.Errorf("uuid -n 5 -rate 200 took %v, want at least 20ms", d)
+	}
+	if status, _, stderr := runTest([]string{"-rate", "-1"}, ""); status != exitUsage || !strings.Contains(stderr, "invalid rate -1") {
+		t.Errorf("uuid -rate -1 = %d, %q", status, stderr)
+	}
+}
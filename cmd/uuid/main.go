@@ -0,0 +1,138 @@
+// Command uuid generates UUIDs with github.com/gofrs/uuid/v5, as a
+// replacement for uuidgen with the same behavior on every system:
+//
+//	uuid [gen] [-v1|-v3|-v4|-v5|-v6|-v7] [-n count] [-ns namespace -name name]
+//	           [-format format] [-rate rate]
+//
+// Run "uuid help" for the commands and "uuid help <command>" for their
+// flags. The gen command is the default, so that "uuid -v7 -n 10" prints ten
+// version 7 UUIDs.
+//
+// The exit status is 0 on success, 1 on errors and 2 on invalid usage.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// Exit statuses.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// env holds the standard streams of a command.
+type env struct {
+	stdin          io.Reader
+	stdout, stderr io.Writer
+}
+
+// command is a subcommand of uuid.
+type command struct {
+	// summary is a one-line description of the command.
+	summary string
+	// doc is printed after the summary in the usage of the command, if not
+	// empty.
+	doc string
+	// run runs the command with its arguments, and returns its exit status.
+	run func(e *env, args []string) int
+}
+
+// commands are the commands of uuid, by name.
+var commands = map[string]*command{}
+
+// defaultCommand is the command run when the first argument is not the
+// name of a command.
+const defaultCommand = "gen"
+
+func main() {
+	os.Exit(run(&env{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}, os.Args[1:]))
+}
+
+// run runs the command named by the first of args, or the default command
+// with all of args, and returns its exit status.
+func run(e *env, args []string) int {
+	if len(args) > 0 {
+		if args[0] == "help" {
+			return help(e, args[1:])
+		}
+		if cmd, ok := commands[args[0]]; ok {
+			return cmd.run(e, args[1:])
+		}
+	}
+	return commands[defaultCommand].run(e, args)
+}
+
+// help prints the usage of uuid, or of the commands named in args.
+func help(e *env, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintf(e.stderr, "usage: uuid [command] [flags]\n\nCommands:\n")
+		names := make([]string, 0, len(commands))
+		for name := range commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(e.stderr, "  %-10s %s\n", name, commands[name].summary)
+		}
+		fmt.Fprintf(e.stderr, "\nThe default command is %s. Run \"uuid help <command>\" for its flags.\n", defaultCommand)
+		return exitOK
+	}
+	for _, name := range args {
+		cmd, ok := commands[name]
+		if !ok {
+			fmt.Fprintf(e.stderr, "uuid: unknown command %q\n", name)
+			return exitUsage
+		}
+		cmd.run(e, []string{"-h"})
+	}
+	return exitOK
+}
+
+// newFlagSet returns a flag set for the named command, printing its errors
+// and usage to the standard error of e.
+func newFlagSet(e *env, name, usage string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	fs.Usage = func() {
+		cmd := commands[name]
+		fmt.Fprintf(e.stderr, "usage: uuid %s %s\n\n%s\n", name, usage, cmd.summary)
+		if cmd.doc != "" {
+			fmt.Fprintf(e.stderr, "\n%s", cmd.doc)
+		}
+		fmt.Fprintf(e.stderr, "\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args with fs. It returns false and the exit status if
+// the command must exit, for invalid flags or -h.
+func parseFlags(fs *flag.FlagSet, args []string) (int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK, false
+		}
+		return exitUsage, false
+	}
+	return exitOK, true
+}
+
+// usageError prints the error of invalid usage of the named command, and
+// returns exitUsage.
+func usageError(e *env, name string, format string, args ...interface{}) int {
+	fmt.Fprintf(e.stderr, "uuid %s: %s\n", name, fmt.Sprintf(format, args...))
+	return exitUsage
+}
+
+// fail prints err, and returns exitError.
+func fail(e *env, name string, err error) int {
+	fmt.Fprintf(e.stderr, "uuid %s: %v\n", name, err)
+	return exitError
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// runTest runs uuid with args and stdin, and returns its exit status and
+// output.
+func runTest(args []string, stdin string) (status int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	status = run(&env{stdin: strings.NewReader(stdin), stdout: &out, stderr: &errOut}, args)
+	return status, out.String(), errOut.String()
+}
+
+func TestHelp(t *testing.T) {
+	status, stdout, stderr := runTest([]string{"help"}, "")
+	if status != exitOK || stdout != "" {
+		t.Errorf("uuid help = %d, %q", status, stdout)
+	}
+	for name := range commands {
+		if !strings.Contains(stderr, "  "+name+" ") {
+			t.Errorf("uuid help does not list %s:\n%s", name, stderr)
+		}
+	}
+
+	status, _, stderr = runTest([]string{"help", "gen"}, "")
+	if status != exitOK || !strings.Contains(stderr, "usage: uuid gen") || !strings.Contains(stderr, "-v7") {
+		t.Errorf("uuid help gen = %d:\n%s", status, stderr)
+	}
+
+	status, _, stderr = runTest([]string{"help", "nope"}, "")
+	if status != exitUsage || !strings.Contains(stderr, `unknown command "nope"`) {
+		t.Errorf("uuid help nope = %d, %q", status, stderr)
+	}
+}
+
+func TestDefaultCommand(t *testing.T) {
+	status, stdout, _ := runTest(nil, "")
+	if status != exitOK || len(stdout) != 37 {
+		t.Errorf("uuid = %d, %q, want a UUID", status, stdout)
+	}
+	status, stdout, _ = runTest([]string{"-n", "2"}, "")
+	if status != exitOK || strings.Count(stdout, "\n") != 2 {
+		t.Errorf("uuid -n 2 = %d, %q, want 2 UUIDs", status, stdout)
+	}
+}
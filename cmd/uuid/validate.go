@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func init() {
+	commands["validate"] = &command{
+		summary: "Validate UUIDs read one per line from the standard input.",
+		doc: "Every invalid line is reported with its number and the reason it is invalid,\n" +
+			"and the exit status is 1 if any line is invalid.\n",
+		run: runValidate,
+	}
+}
+
+// variantNames are the names of the variants returned by UUID.Variant.
+var variantNames = map[byte]string{
+	uuid.VariantNCS:       "NCS",
+	uuid.VariantRFC9562:   "RFC 9562",
+	uuid.VariantMicrosoft: "Microsoft",
+	uuid.VariantFuture:    "future",
+}
+
+// validator validates the lines of the input of validate.
+type validator struct {
+	version byte
+	strict  bool
+}
+
+// validate returns the reason s is not valid, or an empty string if it is.
+func (v validator) validate(s string) string {
+	if s == "" {
+		return "empty line"
+	}
+	u, err := uuid.FromString(s)
+	if err != nil {
+		return err.Error()
+	}
+	if v.strict && s != u.String() {
+		return "not in the lowercase canonical form " + u.String()
+	}
+	if (v.strict || v.version != 0) && u.Variant() != uuid.VariantRFC9562 {
+		return fmt.Sprintf("%s variant, not the RFC 9562 variant", variantNames[u.Variant()])
+	}
+	if v.version != 0 && u.Version() != v.version {
+		return fmt.Sprintf("version %d, not version %d", u.Version(), v.version)
+	}
+	return ""
+}
+
+func runValidate(e *env, args []string) int {
+	fs := newFlagSet(e, "validate", "[-version version] [-strict] < input")
+	version := fs.Int("version", 0, "the version every UUID must have, with the RFC 9562 variant, or 0 for any")
+	strict := fs.Bool("strict", false, "only accept UUIDs in the lowercase canonical form, of the RFC 9562 variant")
+	if status, ok := parseFlags(fs, args); !ok {
+		return status
+	}
+	if fs.NArg() > 0 {
+		return usageError(e, "validate", "unexpected argument %q", fs.Arg(0))
+	}
+	if *version < 0 || *version > 15 {
+		return usageError(e, "validate", "invalid version %d", *version)
+	}
+
+	v := validator{version: byte(*version), strict: *strict}
+	w := bufio.NewWriter(e.stdout)
+	sc := bufio.NewScanner(e.stdin)
+	lines, invalid := 0, 0
+	for sc.Scan() {
+		lines++
+		s := strings.TrimSuffix(sc.Text(), "\r")
+		if reason := v.validate(s); reason != "" {
+			invalid++
+			fmt.Fprintf(w, "line %d: %s: %q\n", lines, reason, s)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fail(e, "validate", fmt.Errorf("writing output: %w", err))
+	}
+	if err := sc.Err(); err != nil {
+		return fail(e, "validate", fmt.Errorf("reading input after line %d: %w", lines, err))
+	}
+	if invalid > 0 {
+		fmt.Fprintf(e.stderr, "uuid validate: %d of %d lines invalid\n", invalid, lines)
+		return exitError
+	}
+	return exitOK
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	v1 := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	v7 := "01890a5d-ac96-774b-bcce-b302099a8057"
+	input := strings.Join([]string{
+		v1,
+		v7,
+		strings.ToUpper(v7),
+		"{" + v1 + "}",
+		"",
+		"not a uuid",
+		"01890a5d-ac96-774b-ccce-b302099a8057",
+	}, "\n") + "\r\n"
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"validate"}, []string{
+			`line 5: empty line: ""`,
+			`line 6: uuid: incorrect UUID length 10 in string "not a uuid": "not a uuid"`,
+		}},
+		{[]string{"validate", "-version", "7"}, []string{
+			`line 1: version 1, not version 7: "` + v1 + `"`,
+			`line 4: version 1, not version 7: "{` + v1 + `}"`,
+			`line 5: empty line: ""`,
+			`line 6: uuid: incorrect UUID length 10 in string "not a uuid": "not a uuid"`,
+			`line 7: Microsoft variant, not the RFC 9562 variant: "01890a5d-ac96-774b-ccce-b302099a8057"`,
+		}},
+		{[]string{"validate", "-strict"}, []string{
+			`line 3: not in the lowercase canonical form ` + v7 + `: "` + strings.ToUpper(v7) + `"`,
+			`line 4: not in the lowercase canonical form ` + v1 + `: "{` + v1 + `}"`,
+			`line 5: empty line: ""`,
+			`line 6: uuid: incorrect UUID length 10 in string "not a uuid": "not a uuid"`,
+			`line 7: Microsoft variant, not the RFC 9562 variant: "01890a5d-ac96-774b-ccce-b302099a8057"`,
+		}},
+	}
+	for _, tt := range tests {
+		status, stdout, stderr := runTest(tt.args, input)
+		want := strings.Join(tt.want, "\n") + "\n"
+		if status != exitError || stdout != want {
+			t.Errorf("uuid %v = %d, output:\n%s\nwant:\n%s", tt.args, status, stdout, want)
+		}
+		if wantErr := "of 7 lines invalid"; !strings.Contains(stderr, wantErr) {
+			t.Errorf("uuid %v stderr = %q, want %q", tt.args, stderr, wantErr)
+		}
+	}
+
+	status, stdout, stderr := runTest([]string{"validate", "-strict", "-version", "7"}, v7+"\n"+v7+"\r\n")
+	if status != exitOK || stdout != "" || stderr != "" {
+		t.Errorf("uuid validate of valid input = %d, %q, %q", status, stdout, stderr)
+	}
+	if status, _, _ := runTest([]string{"validate"}, ""); status != exitOK {
+		t.Errorf("uuid validate of empty input = %d", status)
+	}
+
+	for _, args := range [][]string{{"validate", "-version", "16"}, {"validate", v7}} {
+		if status, _, _ := runTest(args, ""); status != exitUsage {
+			t.Errorf("uuid %v = %d, want %d", args, status, exitUsage)
+		}
+	}
+}
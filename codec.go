@@ -44,21 +44,97 @@ func FromBytesOrNil(input []byte) UUID {
 	return uuid
 }
 
+// xvalues maps each byte to the value of the hex digit it represents, or to
+// 255 if it is not a hex digit.
+var xvalues = [256]byte{
+	255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+	0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 255, 255, 255, 255, 255, 255,
+	255, 10, 11, 12, 13, 14, 15, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+	255, 10, 11, 12, 13, 14, 15, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+	255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+}
+
 func fromHexChar(c byte) byte {
-	switch {
-	case '0' <= c && c <= '9':
-		return c - '0'
-	case 'a' <= c && c <= 'f':
-		return c - 'a' + 10
-	case 'A' <= c && c <= 'F':
-		return c - 'A' + 10
-	}
-	return 255
+	return xvalues[c]
 }
 
-// Parse parses the UUID stored in the string text. Parsing and supported
-// formats are the same as UnmarshalText.
-func (u *UUID) Parse(s string) error {
+// decodeHexPair returns the byte of the hex digits hi and lo, and bad with
+// the bits of their values set. The bits above the low four are only set in
+// bad if a digit is invalid, so that a sequence of pairs can be checked
+// once.
+func decodeHexPair(hi, lo byte, bad byte) (byte, byte) {
+	h, l := xvalues[hi], xvalues[lo]
+	return h<<4 | l, bad | h | l
+}
+
+// text is the type of the inputs of the parser.
+type text interface {
+	~string | ~[]byte
+}
+
+// decodeCanonical decodes the hex digits of s, of the form
+// xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx, and reports whether they are all
+// valid. The hyphens are not checked.
+func decodeCanonical[T text](s T) (u UUID, ok bool) {
+	_ = s[35]
+	var bad byte
+	u[0], bad = decodeHexPair(s[0], s[1], bad)
+	u[1], bad = decodeHexPair(s[2], s[3], bad)
+	u[2], bad = decodeHexPair(s[4], s[5], bad)
+	u[3], bad = decodeHexPair(s[6], s[7], bad)
+	u[4], bad = decodeHexPair(s[9], s[10], bad)
+	u[5], bad = decodeHexPair(s[11], s[12], bad)
+	u[6], bad = decodeHexPair(s[14], s[15], bad)
+	u[7], bad = decodeHexPair(s[16], s[17], bad)
+	u[8], bad = decodeHexPair(s[19], s[20], bad)
+	u[9], bad = decodeHexPair(s[21], s[22], bad)
+	u[10], bad = decodeHexPair(s[24], s[25], bad)
+	u[11], bad = decodeHexPair(s[26], s[27], bad)
+	u[12], bad = decodeHexPair(s[28], s[29], bad)
+	u[13], bad = decodeHexPair(s[30], s[31], bad)
+	u[14], bad = decodeHexPair(s[32], s[33], bad)
+	u[15], bad = decodeHexPair(s[34], s[35], bad)
+	return u, bad < 16
+}
+
+// decodeHashLike decodes the 32 hex digits of s, and reports whether they
+// are all valid.
+func decodeHashLike[T text](s T) (u UUID, ok bool) {
+	_ = s[31]
+	var bad byte
+	u[0], bad = decodeHexPair(s[0], s[1], bad)
+	u[1], bad = decodeHexPair(s[2], s[3], bad)
+	u[2], bad = decodeHexPair(s[4], s[5], bad)
+	u[3], bad = decodeHexPair(s[6], s[7], bad)
+	u[4], bad = decodeHexPair(s[8], s[9], bad)
+	u[5], bad = decodeHexPair(s[10], s[11], bad)
+	u[6], bad = decodeHexPair(s[12], s[13], bad)
+	u[7], bad = decodeHexPair(s[14], s[15], bad)
+	u[8], bad = decodeHexPair(s[16], s[17], bad)
+	u[9], bad = decodeHexPair(s[18], s[19], bad)
+	u[10], bad = decodeHexPair(s[20], s[21], bad)
+	u[11], bad = decodeHexPair(s[22], s[23], bad)
+	u[12], bad = decodeHexPair(s[24], s[25], bad)
+	u[13], bad = decodeHexPair(s[26], s[27], bad)
+	u[14], bad = decodeHexPair(s[28], s[29], bad)
+	u[15], bad = decodeHexPair(s[30], s[31], bad)
+	return u, bad < 16
+}
+
+// parse parses the UUID in s into u. Parsing and supported formats are the
+// same as UnmarshalText.
+func parse[T text](u *UUID, s T) error {
 	switch len(s) {
 	case 32: // hash
 	case 36: // canonical
@@ -68,46 +144,36 @@ func (u *UUID) Parse(s string) error {
 		}
 		s = s[1 : len(s)-1]
 	case 41, 45:
-		if s[:9] != "urn:uuid:" {
+		if string(s[:9]) != "urn:uuid:" {
 			return fmt.Errorf("%w %q", ErrIncorrectFormatInString, s[:9])
 		}
 		s = s[9:]
 	default:
 		return fmt.Errorf("%w %d in string %q", ErrIncorrectLength, len(s), s)
 	}
-	// canonical
+	var v UUID
+	var ok bool
 	if len(s) == 36 {
 		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
 			return fmt.Errorf("%w %q", ErrIncorrectFormatInString, s)
 		}
-		for i, x := range [16]byte{
-			0, 2, 4, 6,
-			9, 11,
-			14, 16,
-			19, 21,
-			24, 26, 28, 30, 32, 34,
-		} {
-			v1 := fromHexChar(s[x])
-			v2 := fromHexChar(s[x+1])
-			if v1|v2 == 255 {
-				return ErrInvalidFormat
-			}
-			u[i] = (v1 << 4) | v2
-		}
-		return nil
+		v, ok = decodeCanonical(s)
+	} else {
+		v, ok = decodeHashLike(s)
 	}
-	// hash like
-	for i := 0; i < 32; i += 2 {
-		v1 := fromHexChar(s[i])
-		v2 := fromHexChar(s[i+1])
-		if v1|v2 == 255 {
-			return ErrInvalidFormat
-		}
-		u[i/2] = (v1 << 4) | v2
+	if !ok {
+		return ErrInvalidFormat
 	}
+	*u = v
 	return nil
 }
 
+// Parse parses the UUID stored in the string text. Parsing and supported
+// formats are the same as UnmarshalText.
+func (u *UUID) Parse(s string) error {
+	return parse(u, s)
+}
+
 // FromString returns a UUID parsed from the input string.
 // Input is expected in a form accepted by UnmarshalText.
 func FromString(text string) (UUID, error) {
@@ -116,6 +182,17 @@ func FromString(text string) (UUID, error) {
 	return u, err
 }
 
+// ParseBytes returns a UUID parsed from the input byte slice without
+// converting it to a string first. Input is expected in a form accepted by
+// UnmarshalText. It returns uuid.Nil along with any parse error.
+func ParseBytes(b []byte) (UUID, error) {
+	var u UUID
+	if err := u.UnmarshalText(b); err != nil {
+		return Nil, err
+	}
+	return u, nil
+}
+
 // FromStringOrNil returns a UUID parsed from the input string.
 // Same behavior as FromString(), but returns uuid.Nil instead of an error.
 func FromStringOrNil(input string) UUID {
@@ -168,51 +245,7 @@ func (u UUID) MarshalText() ([]byte, error) {
 //	braced := '{' plain '}' | '{' hashlike  '}'
 //	urn := URN ':' UUID-NID ':' plain
 func (u *UUID) UnmarshalText(b []byte) error {
-	switch len(b) {
-	case 32: // hash
-	case 36: // canonical
-	case 34, 38:
-		if b[0] != '{' || b[len(b)-1] != '}' {
-			return fmt.Errorf("%w %q", ErrIncorrectFormatInString, b)
-		}
-		b = b[1 : len(b)-1]
-	case 41, 45:
-		if string(b[:9]) != "urn:uuid:" {
-			return fmt.Errorf("%w %q", ErrIncorrectFormatInString, b[:9])
-		}
-		b = b[9:]
-	default:
-		return fmt.Errorf("%w %d in string %q", ErrIncorrectLength, len(b), b)
-	}
-	if len(b) == 36 {
-		if b[8] != '-' || b[13] != '-' || b[18] != '-' || b[23] != '-' {
-			return fmt.Errorf("%w %q", ErrIncorrectFormatInString, b)
-		}
-		for i, x := range [16]byte{
-			0, 2, 4, 6,
-			9, 11,
-			14, 16,
-			19, 21,
-			24, 26, 28, 30, 32, 34,
-		} {
-			v1 := fromHexChar(b[x])
-			v2 := fromHexChar(b[x+1])
-			if v1|v2 == 255 {
-				return ErrInvalidFormat
-			}
-			u[i] = (v1 << 4) | v2
-		}
-		return nil
-	}
-	for i := 0; i < 32; i += 2 {
-		v1 := fromHexChar(b[i])
-		v2 := fromHexChar(b[i+1])
-		if v1|v2 == 255 {
-			return ErrInvalidFormat
-		}
-		u[i/2] = (v1 << 4) | v2
-	}
-	return nil
+	return parse(u, b)
 }
 
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
@@ -231,6 +264,24 @@ func (u *UUID) UnmarshalBinary(data []byte) error {
 	return nil
 }
 
+// GobEncode implements the gob.GobEncoder interface, encoding the UUID as its
+// 16 bytes.
+//
+// Releases before GobEncode was added encoded UUIDs in gob with
+// MarshalBinary, under a different gob wire type. Gob streams written by them
+// do not decode into a UUID, and must be decoded with such a release and
+// encoded again.
+func (u UUID) GobEncode() ([]byte, error) {
+	return u.Bytes(), nil
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+// It will return an error if the slice isn't 16 bytes long. Gob streams
+// written by releases before GobEncode was added do not decode; see GobEncode.
+func (u *UUID) GobDecode(data []byte) error {
+	return u.UnmarshalBinary(data)
+}
+
 // WithCustomPRNG provides a deterministic random number generator for testing.
 //
 // Allows users to specify a PRNG with a fixed seed, enabling
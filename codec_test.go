@@ -23,6 +23,10 @@ package uuid
 
 import (
 	"bytes"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"io"
 	"regexp"
 	"strings"
 	"testing"
@@ -112,6 +116,18 @@ func (fst fromStringTest) TestUnmarshalText(t *testing.T) {
 	})
 }
 
+func (fst fromStringTest) TestParseBytes(t *testing.T) {
+	t.Run(fst.variant, func(t *testing.T) {
+		got, err := ParseBytes([]byte(fst.input))
+		if err != nil {
+			t.Fatalf("ParseBytes(%q): %v", fst.input, err)
+		}
+		if want := codecTestUUID; got != want {
+			t.Fatalf("ParseBytes(%q) = %v, want %v", fst.input, got, want)
+		}
+	})
+}
+
 // fromStringTests contains UUID variants that are expected to be parsed
 // successfully by UnmarshalText / FromString.
 //
@@ -228,6 +244,36 @@ func TestUnmarshalText(t *testing.T) {
 	})
 }
 
+func TestParseBytes(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		for _, fst := range fromStringTests {
+			fst.TestParseBytes(t)
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, s := range invalidFromStringInputs {
+			got, err := ParseBytes([]byte(s))
+			if err == nil {
+				t.Errorf("ParseBytes(%q): want err != nil, got %v", s, got)
+			}
+			if got != Nil {
+				t.Errorf("ParseBytes(%q): got %v, want Nil", s, got)
+			}
+		}
+	})
+	t.Run("Allocs", func(t *testing.T) {
+		for _, fst := range fromStringTests {
+			b := []byte(fst.input)
+			allocs := testing.AllocsPerRun(100, func() {
+				_, _ = ParseBytes(b)
+			})
+			if allocs != 0 {
+				t.Errorf("ParseBytes(%q) allocated %v times, want 0", fst.input, allocs)
+			}
+		}
+	})
+}
+
 // Test that UnmarshalText() and Parse() return identical errors
 func TestUnmarshalTextParseErrors(t *testing.T) {
 	for _, s := range invalidFromStringInputs {
@@ -240,6 +286,19 @@ func TestUnmarshalTextParseErrors(t *testing.T) {
 	}
 }
 
+// Test that a failed parse leaves the UUID unchanged
+func TestParseErrorKeepsUUID(t *testing.T) {
+	for _, s := range invalidFromStringInputs {
+		u := codecTestUUID
+		if err := u.Parse(s); err == nil || u != codecTestUUID {
+			t.Errorf("Parse(%q) = %v, changed the UUID to %v", s, err, u)
+		}
+		if err := u.UnmarshalText([]byte(s)); err == nil || u != codecTestUUID {
+			t.Errorf("UnmarshalText(%q) = %v, changed the UUID to %v", s, err, u)
+		}
+	}
+}
+
 func TestMarshalBinary(t *testing.T) {
 	got, err := codecTestUUID.MarshalBinary()
 	if err != nil {
@@ -250,6 +309,53 @@ func TestMarshalBinary(t *testing.T) {
 	}
 }
 
+func TestGob(t *testing.T) {
+	got, err := codecTestUUID.GobEncode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, codecTestData) {
+		t.Fatalf("%v.GobEncode() = %x, want %x", codecTestUUID, got, codecTestData)
+	}
+
+	type record struct {
+		ID     UUID
+		Parent *UUID
+		IDs    []UUID
+	}
+	in := record{ID: codecTestUUID, Parent: &Max, IDs: []UUID{Nil, codecTestUUID}}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+		t.Fatal(err)
+	}
+	var out record
+	if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+		t.Fatal(err)
+	}
+	if out.ID != in.ID || out.Parent == nil || *out.Parent != *in.Parent ||
+		len(out.IDs) != len(in.IDs) || out.IDs[0] != in.IDs[0] || out.IDs[1] != in.IDs[1] {
+		t.Errorf("gob round trip of %+v = %+v", in, out)
+	}
+
+	var u UUID
+	if err := u.GobDecode(codecTestData[:8]); !errors.Is(err, ErrIncorrectByteLength) {
+		t.Errorf("GobDecode(%x) error = %v, want %v", codecTestData[:8], err, ErrIncorrectByteLength)
+	}
+}
+
+func TestGobLegacy(t *testing.T) {
+	// A struct { ID UUID } written by a release encoding UUIDs with
+	// MarshalBinary, which do not decode, as documented by GobEncode.
+	legacy, err := hex.DecodeString("187f0301010372656301ff800001010102494401ff8200000010ff81060101045555494401ff8200000015ff8001106ba7b8109dad11d180b400c04fd430c800")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out struct{ ID UUID }
+	if err := gob.NewDecoder(bytes.NewReader(legacy)).Decode(&out); err == nil {
+		t.Errorf("gob decoding of a legacy stream = %+v, want an error", out)
+	}
+}
+
 func TestMarshalText(t *testing.T) {
 	want := []byte("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
 	got, err := codecTestUUID.MarshalText()
@@ -384,6 +490,15 @@ func BenchmarkMarshalBinary(b *testing.B) {
 	}
 }
 
+func BenchmarkGobEncode(b *testing.B) {
+	enc := gob.NewEncoder(io.Discard)
+	for i := 0; i < b.N; i++ {
+		if err := enc.Encode(codecTestUUID); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func BenchmarkMarshalText(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		codecTestUUID.MarshalText()
@@ -400,6 +515,38 @@ func BenchmarkParseV4(b *testing.B) {
 	}
 }
 
+// parseBenchmarkInputs are the inputs of the parsing benchmarks, in each
+// text form.
+var parseBenchmarkInputs = []struct {
+	name, text string
+}{
+	{"canonical", "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+	{"hash", "6ba7b8109dad11d180b400c04fd430c8"},
+	{"braced", "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}"},
+	{"urn", "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+	{"invalid", "6ba7b810-9dad-11d1-80b4-00c04fd430cx"},
+}
+
+func BenchmarkParseForms(b *testing.B) {
+	for _, in := range parseBenchmarkInputs {
+		b.Run("string/"+in.name, func(b *testing.B) {
+			b.ReportAllocs()
+			var u UUID
+			for i := 0; i < b.N; i++ {
+				_ = u.Parse(in.text)
+			}
+		})
+		b.Run("bytes/"+in.name, func(b *testing.B) {
+			b.ReportAllocs()
+			text := []byte(in.text)
+			var u UUID
+			for i := 0; i < b.N; i++ {
+				_ = u.UnmarshalText(text)
+			}
+		})
+	}
+}
+
 const uuidPattern = "[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}"
 
 var fromBytesCorpus = [][]byte{
@@ -0,0 +1,46 @@
+package uuid
+
+import "crypto/sha256"
+
+// Domain separation bytes of the hashes computed by Combine and
+// CombineOrdered, so that the two never derive the same UUID for a pair.
+const (
+	combineUnordered byte = 0x01
+	combineOrdered   byte = 0x02
+)
+
+// Combine returns a version 8 UUID derived from the SHA-256 hash of the pair
+// of UUIDs a and b, independent of their order: Combine(a, b) equals
+// Combine(b, a). It suits identifiers of symmetric relationships, such as
+// the edge between two nodes of an undirected graph. The result is stable
+// across releases and platforms, and is available with the uuid_fips build
+// tag.
+func Combine(a, b UUID) UUID {
+	if a.Compare(b) > 0 {
+		a, b = b, a
+	}
+	return combine(combineUnordered, a, b)
+}
+
+// CombineOrdered returns a version 8 UUID derived from the SHA-256 hash of
+// the pair of UUIDs a and b, in this order, such as the identifier of the
+// (user, device) pair. CombineOrdered(a, b) and CombineOrdered(b, a) differ
+// unless a == b, and neither equals Combine(a, b).
+func CombineOrdered(a, b UUID) UUID {
+	return combine(combineOrdered, a, b)
+}
+
+func combine(domain byte, a, b UUID) UUID {
+	var buf [1 + 2*Size]byte
+	buf[0] = domain
+	copy(buf[1:], a[:])
+	copy(buf[1+Size:], b[:])
+	sum := sha256.Sum256(buf[:])
+
+	var u UUID
+	copy(u[:], sum[:])
+	u.SetVersion(8)
+	u.SetVariant(VariantRFC9562)
+
+	return u
+}
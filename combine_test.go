@@ -0,0 +1,51 @@
+package uuid
+
+import "testing"
+
+func TestCombine(t *testing.T) {
+	a, b := NamespaceDNS, NamespaceURL
+	t.Run("Unordered", func(t *testing.T) {
+		u := Combine(a, b)
+		if got := Combine(b, a); got != u {
+			t.Errorf("Combine(b, a) = %v, want Combine(a, b) = %v", got, u)
+		}
+		if got := Combine(a, b); got != u {
+			t.Errorf("Combine(a, b) = %v then %v", u, got)
+		}
+		if got := Combine(a, NamespaceOID); got == u {
+			t.Errorf("Combine returned %v for different pairs", got)
+		}
+		if err := u.Validate(); err != nil || u.Version() != 8 {
+			t.Errorf("Combine(a, b) = %v, version %d, Validate() = %v", u, u.Version(), err)
+		}
+	})
+	t.Run("Ordered", func(t *testing.T) {
+		u := CombineOrdered(a, b)
+		if got := CombineOrdered(b, a); got == u {
+			t.Errorf("CombineOrdered(b, a) = CombineOrdered(a, b) = %v", got)
+		}
+		if got := Combine(a, b); got == u {
+			t.Errorf("Combine(a, b) = CombineOrdered(a, b) = %v", got)
+		}
+		if got := CombineOrdered(a, a); got == Combine(a, a) {
+			t.Errorf("Combine(a, a) = CombineOrdered(a, a) = %v", got)
+		}
+		if err := u.Validate(); err != nil || u.Version() != 8 {
+			t.Errorf("CombineOrdered(a, b) = %v, version %d, Validate() = %v", u, u.Version(), err)
+		}
+	})
+	t.Run("Stable", func(t *testing.T) {
+		// The derived UUIDs must not change across releases.
+		if got, want := Combine(a, b), Must(FromString("f4047c3c-e9d0-80fb-a5f0-6358595b6618")); got != want {
+			t.Errorf("Combine(a, b) = %v, want %v", got, want)
+		}
+		if got, want := CombineOrdered(a, b), Must(FromString("8c971ab1-0571-8d60-be36-2871e6fc9c4c")); got != want {
+			t.Errorf("CombineOrdered(a, b) = %v, want %v", got, want)
+		}
+	})
+	t.Run("Allocs", func(t *testing.T) {
+		if allocs := testing.AllocsPerRun(100, func() { Combine(a, b) }); allocs != 0 {
+			t.Errorf("Combine allocated %v times, want 0", allocs)
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"net/netip"
+)
+
+// BigInt returns the UUID interpreted as a 128-bit unsigned big-endian
+// integer. This is the same value as Python's uuid.UUID.int.
+func (u UUID) BigInt() *big.Int {
+	return new(big.Int).SetBytes(u[:])
+}
+
+// FromBigInt returns the UUID whose 128-bit unsigned big-endian integer value
+// is i. Values with fewer than 128 significant bits are padded with leading
+// zeros. It will return an error if i is negative or does not fit in 128 bits.
+func FromBigInt(i *big.Int) (UUID, error) {
+	if i.Sign() < 0 || i.BitLen() > 8*Size {
+		return Nil, fmt.Errorf("%w, got %s", ErrIntegerOutOfRange, i)
+	}
+	var u UUID
+	i.FillBytes(u[:])
+	return u, nil
+}
+
+// Uint64Pair returns the high and low 64 bits of the UUID, each decoded as a
+// big-endian unsigned integer.
+func (u UUID) Uint64Pair() (hi, lo uint64) {
+	return binary.BigEndian.Uint64(u[:8]), binary.BigEndian.Uint64(u[8:])
+}
+
+// FromUint64Pair returns the UUID whose high and low 64 bits are hi and lo,
+// each encoded as a big-endian unsigned integer. It is the inverse of
+// Uint64Pair.
+func FromUint64Pair(hi, lo uint64) UUID {
+	var u UUID
+	binary.BigEndian.PutUint64(u[:8], hi)
+	binary.BigEndian.PutUint64(u[8:], lo)
+	return u
+}
+
+// ToAddr returns the UUID as an IPv6 address, treating its 16 bytes as the
+// address in network byte order.
+func (u UUID) ToAddr() netip.Addr {
+	return netip.AddrFrom16(u)
+}
+
+// FromAddr returns the UUID formed by the 16 bytes of the IPv6 address a. The
+// IPv6 zone, if any, is ignored. It will return an error if a is not an IPv6
+// address, including when it is an IPv4 address.
+func FromAddr(a netip.Addr) (UUID, error) {
+	if !a.Is6() {
+		return Nil, fmt.Errorf("%w %s to UUID", ErrTypeConvertError, a)
+	}
+	return a.As16(), nil
+}
+
+// JavaBits returns the most and least significant 64 bits of the UUID as
+// signed integers, matching java.util.UUID's getMostSignificantBits and
+// getLeastSignificantBits.
+func (u UUID) JavaBits() (msb, lsb int64) {
+	hi, lo := u.Uint64Pair()
+	return int64(hi), int64(lo)
+}
+
+// FromJavaBits returns the UUID built from the signed most and least
+// significant 64 bits, matching the java.util.UUID(long, long) constructor.
+func FromJavaBits(msb, lsb int64) UUID {
+	return FromUint64Pair(uint64(msb), uint64(lsb))
+}
+
+// V1ToV6 returns the version 6 UUID with the same timestamp, clock sequence
+// and node as the version 1 UUID u, as described in RFC 9562, section 5.6.
+// The conversion is lossless and can be reversed with V6ToV1. It will return
+// an error if u is not a version 1 UUID.
+func V1ToV6(u UUID) (UUID, error) {
+	ts, err := TimestampFromV1(u)
+	if err != nil {
+		return Nil, err
+	}
+	binary.BigEndian.PutUint32(u[0:], uint32(ts>>28))
+	binary.BigEndian.PutUint16(u[4:], uint16(ts>>12))
+	binary.BigEndian.PutUint16(u[6:], uint16(ts&0xfff))
+	u.SetVersion(V6)
+	return u, nil
+}
+
+// V6ToV1 returns the version 1 UUID with the same timestamp, clock sequence
+// and node as the version 6 UUID u. It is the inverse of V1ToV6. It will
+// return an error if u is not a version 6 UUID.
+func V6ToV1(u UUID) (UUID, error) {
+	ts, err := TimestampFromV6(u)
+	if err != nil {
+		return Nil, err
+	}
+	binary.BigEndian.PutUint32(u[0:], uint32(ts))
+	binary.BigEndian.PutUint16(u[4:], uint16(ts>>32))
+	binary.BigEndian.PutUint16(u[6:], uint16(ts>>48))
+	u.SetVersion(V1)
+	return u, nil
+}
@@ -0,0 +1,213 @@
+package uuid
+
+import (
+	"errors"
+	"math/big"
+	"net/netip"
+	"testing"
+)
+
+func TestBigInt(t *testing.T) {
+	tests := []struct {
+		u    UUID
+		want string
+	}{
+		{u: Nil, want: "0"},
+		{u: Max, want: "340282366920938463463374607431768211455"},
+		{u: NamespaceDNS, want: "143098242404177361603877621312831893704"},
+		{
+			u:    UUID{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00},
+			want: "256",
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.u.BigInt().String(); got != tt.want {
+			t.Errorf("%v.BigInt() = %s, want %s", tt.u, got, tt.want)
+		}
+	}
+}
+
+func TestFromBigInt(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		for _, want := range []UUID{Nil, Max, NamespaceDNS, {15: 0x01}, {0: 0x01}} {
+			got, err := FromBigInt(want.BigInt())
+			if err != nil {
+				t.Fatalf("FromBigInt(%s) unexpected error: %v", want.BigInt(), err)
+			}
+			if got != want {
+				t.Errorf("FromBigInt(%s) = %v, want %v", want.BigInt(), got, want)
+			}
+		}
+	})
+	t.Run("Decimal", func(t *testing.T) {
+		i, _ := new(big.Int).SetString("143098242404177361603877621312831893704", 10)
+		got, err := FromBigInt(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != NamespaceDNS {
+			t.Errorf("FromBigInt(%s) = %v, want %v", i, got, NamespaceDNS)
+		}
+	})
+	t.Run("OutOfRange", func(t *testing.T) {
+		tooLarge := new(big.Int).Lsh(big.NewInt(1), 128)
+		for _, i := range []*big.Int{big.NewInt(-1), tooLarge} {
+			got, err := FromBigInt(i)
+			if !errors.Is(err, ErrIntegerOutOfRange) {
+				t.Errorf("FromBigInt(%s) error = %v, want %v", i, err, ErrIntegerOutOfRange)
+			}
+			if got != Nil {
+				t.Errorf("FromBigInt(%s) = %v, want %v", i, got, Nil)
+			}
+		}
+	})
+}
+
+func TestUint64Pair(t *testing.T) {
+	tests := []struct {
+		u      UUID
+		hi, lo uint64
+	}{
+		{u: Nil, hi: 0, lo: 0},
+		{u: Max, hi: 0xffffffffffffffff, lo: 0xffffffffffffffff},
+		{u: NamespaceDNS, hi: 0x6ba7b8109dad11d1, lo: 0x80b400c04fd430c8},
+	}
+	for _, tt := range tests {
+		hi, lo := tt.u.Uint64Pair()
+		if hi != tt.hi || lo != tt.lo {
+			t.Errorf("%v.Uint64Pair() = (%#x, %#x), want (%#x, %#x)", tt.u, hi, lo, tt.hi, tt.lo)
+		}
+		if got := FromUint64Pair(tt.hi, tt.lo); got != tt.u {
+			t.Errorf("FromUint64Pair(%#x, %#x) = %v, want %v", tt.hi, tt.lo, got, tt.u)
+		}
+	}
+}
+
+func BenchmarkUint64Pair(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		hi, lo := codecTestUUID.Uint64Pair()
+		_ = FromUint64Pair(hi, lo)
+	}
+}
+
+func TestAddr(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		for _, u := range []UUID{Nil, Max, NamespaceDNS} {
+			a := u.ToAddr()
+			if !a.Is6() {
+				t.Fatalf("%v.ToAddr() = %v, want an IPv6 address", u, a)
+			}
+			got, err := FromAddr(a)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != u {
+				t.Errorf("FromAddr(%v) = %v, want %v", a, got, u)
+			}
+		}
+	})
+	t.Run("String", func(t *testing.T) {
+		if got, want := NamespaceDNS.ToAddr().String(), "6ba7:b810:9dad:11d1:80b4:c0:4fd4:30c8"; got != want {
+			t.Errorf("%v.ToAddr() = %s, want %s", NamespaceDNS, got, want)
+		}
+	})
+	t.Run("Zone", func(t *testing.T) {
+		a := netip.MustParseAddr("fe80::1%eth0")
+		got, err := FromAddr(a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := (UUID{0xfe, 0x80, 15: 0x01}); got != want {
+			t.Errorf("FromAddr(%v) = %v, want %v", a, got, want)
+		}
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		for _, a := range []netip.Addr{{}, netip.MustParseAddr("192.0.2.1")} {
+			if _, err := FromAddr(a); !errors.Is(err, ErrTypeConvertError) {
+				t.Errorf("FromAddr(%v) error = %v, want %v", a, err, ErrTypeConvertError)
+			}
+		}
+	})
+	t.Run("Allocs", func(t *testing.T) {
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = FromAddr(codecTestUUID.ToAddr())
+		})
+		if allocs != 0 {
+			t.Errorf("ToAddr/FromAddr allocated %v times, want 0", allocs)
+		}
+	})
+}
+
+func TestJavaBits(t *testing.T) {
+	// msb and lsb are the signed halves java.util.UUID reports for u.
+	tests := []struct {
+		u        UUID
+		msb, lsb int64
+	}{
+		{u: Nil, msb: 0, lsb: 0},
+		{u: Max, msb: -1, lsb: -1},
+		{u: NamespaceDNS, msb: 7757371264673321425, lsb: -9172705715073830712},
+		{u: Must(FromString("123e4567-e89b-12d3-a456-426614174000")), msb: 1314564453825188563, lsb: -6605018797301088256},
+	}
+	for _, tt := range tests {
+		msb, lsb := tt.u.JavaBits()
+		if msb != tt.msb || lsb != tt.lsb {
+			t.Errorf("%v.JavaBits() = (%d, %d), want (%d, %d)", tt.u, msb, lsb, tt.msb, tt.lsb)
+		}
+		if got := FromJavaBits(tt.msb, tt.lsb); got != tt.u {
+			t.Errorf("FromJavaBits(%d, %d) = %v, want %v", tt.msb, tt.lsb, got, tt.u)
+		}
+	}
+}
+
+func TestV1ToV6(t *testing.T) {
+	// Test vectors from RFC 9562, appendix A.1 and A.5.
+	v1 := Must(FromString("c232ab00-9414-11ec-b3c8-9f6bdeced846"))
+	v6 := Must(FromString("1ec9414c-232a-6b00-b3c8-9f6bdeced846"))
+
+	got, err := V1ToV6(v1)
+	if err != nil {
+		t.Fatalf("V1ToV6(%v) unexpected error: %v", v1, err)
+	}
+	if got != v6 {
+		t.Errorf("V1ToV6(%v) = %v, want %v", v1, got, v6)
+	}
+	got, err = V6ToV1(v6)
+	if err != nil {
+		t.Fatalf("V6ToV1(%v) unexpected error: %v", v6, err)
+	}
+	if got != v1 {
+		t.Errorf("V6ToV1(%v) = %v, want %v", v6, got, v1)
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		g := NewGen()
+		for i := 0; i < 100; i++ {
+			u := Must(g.NewV1())
+			v6, err := V1ToV6(u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ts1, _ := TimestampFromV1(u)
+			if ts6, _ := TimestampFromV6(v6); ts6 != ts1 {
+				t.Fatalf("V1ToV6(%v) = %v, timestamp %d, want %d", u, v6, ts6, ts1)
+			}
+			back, err := V6ToV1(v6)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if back != u {
+				t.Fatalf("V6ToV1(V1ToV6(%v)) = %v", u, back)
+			}
+		}
+	})
+
+	t.Run("WrongVersion", func(t *testing.T) {
+		if _, err := V1ToV6(v6); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("V1ToV6(%v) error = %v, want %v", v6, err, ErrInvalidVersion)
+		}
+		if _, err := V6ToV1(v1); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("V6ToV1(%v) error = %v, want %v", v1, err, ErrInvalidVersion)
+		}
+	})
+}
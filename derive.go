@@ -0,0 +1,19 @@
+package uuid
+
+// Derive returns the UUID of the descendant of parent at path, such as the
+// dataset of a project of a tenant:
+//
+//	dataset := uuid.Derive(tenant, "projects/web", "datasets/logs")
+//
+// Each element of path derives a child with NewV8SHA256, using the UUID
+// derived so far as the namespace, so that Derive(p, a, b) equals
+// Derive(Derive(p, a), b), and the IDs of sub-resources can be recomputed
+// from the parent without storing a mapping. Derive returns parent itself if
+// path is empty.
+func Derive(parent UUID, path ...string) UUID {
+	u := parent
+	for _, name := range path {
+		u = NewV8SHA256(u, name)
+	}
+	return u
+}
@@ -0,0 +1,33 @@
+package uuid
+
+import "testing"
+
+func TestDerive(t *testing.T) {
+	tenant := NamespaceDNS
+	if got := Derive(tenant); got != tenant {
+		t.Errorf("Derive(tenant) = %v, want %v", got, tenant)
+	}
+	if got, want := Derive(tenant, "www.example.com"), NewV8SHA256(tenant, "www.example.com"); got != want {
+		t.Errorf("Derive(tenant, www.example.com) = %v, want %v", got, want)
+	}
+
+	dataset := Derive(tenant, "projects/web", "datasets/logs")
+	if got := Derive(Derive(tenant, "projects/web"), "datasets/logs"); got != dataset {
+		t.Errorf("Derive(Derive(tenant, projects/web), datasets/logs) = %v, want %v", got, dataset)
+	}
+	if got := Derive(tenant, "projects/web", "datasets/logs"); got != dataset {
+		t.Errorf("Derive(tenant, ...) = %v then %v", dataset, got)
+	}
+	for _, other := range []UUID{
+		Derive(tenant, "datasets/logs", "projects/web"),
+		Derive(tenant, "projects/webdatasets/logs"),
+		Derive(NamespaceURL, "projects/web", "datasets/logs"),
+	} {
+		if other == dataset {
+			t.Errorf("Derive returned %v for different paths", other)
+		}
+	}
+	if err := dataset.Validate(); err != nil || dataset.Version() != 8 {
+		t.Errorf("Derive(tenant, ...) = %v, version %d, Validate() = %v", dataset, dataset.Version(), err)
+	}
+}
@@ -0,0 +1,107 @@
+package uuid
+
+import "fmt"
+
+// DupDetector reports the duplicates in a stream of UUIDs, such as an import
+// feed supposed to hold unique UUIDs, in bounded memory. It has two modes:
+//
+//   - exact, created with NewWindowDupDetector, reports a UUID if it is
+//     among the last UUIDs of a window of fixed size, and never reports a
+//     UUID that is not a duplicate;
+//   - probabilistic, created with NewBloomDupDetector, reports a UUID if it
+//     may have been seen before in the whole stream, using a BloomFilter,
+//     and reports UUIDs that are not duplicates at about the false-positive
+//     rate of the filter, as long as the stream holds no more UUIDs than the
+//     filter was sized for.
+//
+// A DupDetector is not safe for concurrent use.
+type DupDetector struct {
+	// Exact mode: the set of the UUIDs of the window, in a ring.
+	seen   map[UUID]int
+	window []UUID
+	next   int
+
+	// Probabilistic mode.
+	bloom *BloomFilter
+
+	count, dups int
+}
+
+// NewWindowDupDetector returns a DupDetector reporting the UUIDs seen among
+// the previous window UUIDs, which it holds in memory. It will return an
+// error if window is not positive.
+func NewWindowDupDetector(window int) (*DupDetector, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("%w: window %d must be positive", ErrInvalidArgument, window)
+	}
+	return &DupDetector{
+		seen:   make(map[UUID]int, window),
+		window: make([]UUID, 0, window),
+	}, nil
+}
+
+// NewBloomDupDetector returns a DupDetector reporting the UUIDs which may
+// have been seen before, with a BloomFilter sized for expected UUIDs with a
+// false-positive probability of about fpRate. It will return an error if
+// expected is not positive or fpRate is not in the open interval (0, 1).
+func NewBloomDupDetector(expected int, fpRate float64) (*DupDetector, error) {
+	f, err := NewBloomFilter(expected, fpRate)
+	if err != nil {
+		return nil, err
+	}
+	return &DupDetector{bloom: f}, nil
+}
+
+// Check records u and reports whether it is a duplicate of a UUID recorded
+// before, within the window of an exact detector.
+func (d *DupDetector) Check(u UUID) bool {
+	d.count++
+	var dup bool
+	if d.bloom != nil {
+		dup = d.bloom.Contains(u)
+		if !dup {
+			d.bloom.Add(u)
+		}
+	} else {
+		dup = d.seen[u] > 0
+		if len(d.window) < cap(d.window) {
+			d.window = append(d.window, u)
+		} else {
+			old := d.window[d.next]
+			if d.seen[old]--; d.seen[old] == 0 {
+				delete(d.seen, old)
+			}
+			d.window[d.next] = u
+			d.next = (d.next + 1) % len(d.window)
+		}
+		d.seen[u]++
+	}
+	if dup {
+		d.dups++
+	}
+	return dup
+}
+
+// Count returns the number of UUIDs checked.
+func (d *DupDetector) Count() int {
+	return d.count
+}
+
+// Duplicates returns the number of UUIDs reported as duplicates.
+func (d *DupDetector) Duplicates() int {
+	return d.dups
+}
+
+// Reset empties the detector, keeping its mode and memory bounds.
+func (d *DupDetector) Reset() {
+	if d.bloom != nil {
+		d.bloom.Reset()
+	} else {
+		for u := range d.seen {
+			delete(d.seen, u)
+		}
+		d.window = d.window[:0]
+		d.next = 0
+	}
+	d.count, d.dups = 0, 0
+}
@@ -0,0 +1,92 @@
+package uuid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDupDetector(t *testing.T) {
+	g := NewGenWithOptions(WithCustomPRNG(1))
+	uuids := make([]UUID, 1000)
+	for i := range uuids {
+		uuids[i] = Must(g.NewV4())
+	}
+
+	t.Run("Window", func(t *testing.T) {
+		d, err := NewWindowDupDetector(3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a, b, c, e := uuids[0], uuids[1], uuids[2], uuids[3]
+		for i, tt := range []struct {
+			u    UUID
+			want bool
+		}{
+			{a, false},
+			{b, false},
+			{a, true}, // window: a b a
+			{c, false},
+			{a, true},  // window: a c a
+			{e, false}, // window: c a e
+			{b, false}, // b left the window
+			{e, true},
+			{c, false}, // c left the window
+		} {
+			if got := d.Check(tt.u); got != tt.want {
+				t.Errorf("check %d: Check(%v) = %t, want %t", i, tt.u, got, tt.want)
+			}
+		}
+		if d.Count() != 9 || d.Duplicates() != 3 {
+			t.Errorf("Count(), Duplicates() = %d, %d, want 9, 3", d.Count(), d.Duplicates())
+		}
+		if len(d.seen) > 3 {
+			t.Errorf("the detector holds %d UUIDs, more than its window of 3", len(d.seen))
+		}
+
+		d.Reset()
+		if d.Count() != 0 || d.Duplicates() != 0 || d.Check(a) {
+			t.Errorf("Check(a) after Reset() = true")
+		}
+	})
+
+	t.Run("Bloom", func(t *testing.T) {
+		d, err := NewBloomDupDetector(len(uuids), 0.001)
+		if err != nil {
+			t.Fatal(err)
+		}
+		falsePositives := 0
+		for _, u := range uuids {
+			if d.Check(u) {
+				falsePositives++
+			}
+		}
+		if falsePositives > 5 {
+			t.Errorf("%d of %d unique UUIDs reported as duplicates", falsePositives, len(uuids))
+		}
+		for _, u := range uuids[:10] {
+			if !d.Check(u) {
+				t.Errorf("Check(%v) = false for a duplicate", u)
+			}
+		}
+		if d.Count() != len(uuids)+10 || d.Duplicates() != falsePositives+10 {
+			t.Errorf("Count(), Duplicates() = %d, %d, want %d, %d", d.Count(), d.Duplicates(), len(uuids)+10, falsePositives+10)
+		}
+
+		d.Reset()
+		if d.Count() != 0 || d.Duplicates() != 0 || d.Check(uuids[0]) {
+			t.Errorf("Check(%v) after Reset() = true", uuids[0])
+		}
+	})
+
+	t.Run("InvalidArgument", func(t *testing.T) {
+		if _, err := NewWindowDupDetector(0); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("NewWindowDupDetector(0) error = %v, want %v", err, ErrInvalidArgument)
+		}
+		if _, err := NewBloomDupDetector(0, 0.01); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("NewBloomDupDetector(0, 0.01) error = %v, want %v", err, ErrInvalidArgument)
+		}
+		if _, err := NewBloomDupDetector(10, 1); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("NewBloomDupDetector(10, 1) error = %v, want %v", err, ErrInvalidArgument)
+		}
+	})
+}
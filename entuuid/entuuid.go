@@ -0,0 +1,94 @@
+// Package entuuid provides helpers for using the UUID type of
+// github.com/gofrs/uuid/v5 in ent schemas.
+//
+// uuid.UUID implements the field.ValueScanner interface, so it can be used
+// directly as the type of a UUID field, with NewV7 or NewV4 as its default:
+//
+//	field.UUID("id", uuid.UUID{}).Default(entuuid.NewV7)
+//
+// The IDMixin adds such an "id" field to a schema. For databases without a
+// native uuid column type, UUIDs can be stored in bytes or string fields
+// with BinaryValueScanner or TextValueScanner:
+//
+//	field.Bytes("ref").GoType(uuid.UUID{}).ValueScanner(entuuid.BinaryValueScanner{})
+package entuuid
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+	"github.com/gofrs/uuid/v5"
+)
+
+// NewV7 returns a new version 7 UUID, for use as the default of a field. It
+// panics if the UUID cannot be generated, since ent default functions cannot
+// return errors.
+func NewV7() uuid.UUID {
+	return uuid.Must(uuid.NewV7())
+}
+
+// NewV4 returns a new version 4 UUID, for use as the default of a field. It
+// panics if the UUID cannot be generated, since ent default functions cannot
+// return errors.
+func NewV4() uuid.UUID {
+	return uuid.Must(uuid.NewV4())
+}
+
+// IDMixin is an ent.Mixin adding an immutable "id" field of type uuid.UUID,
+// defaulting to a new version 7 UUID.
+type IDMixin struct {
+	mixin.Schema
+}
+
+// Fields implements the ent.Mixin interface.
+func (IDMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(NewV7).
+			Immutable(),
+	}
+}
+
+// scanner implements the ScanValue and FromValue methods of
+// field.TypeValueScanner for uuid.UUID.
+type scanner struct{}
+
+// ScanValue implements the field.TypeValueScanner interface.
+func (scanner) ScanValue() field.ValueScanner {
+	return &uuid.NullUUID{}
+}
+
+// FromValue implements the field.TypeValueScanner interface.
+func (scanner) FromValue(v driver.Value) (uuid.UUID, error) {
+	nu, ok := v.(*uuid.NullUUID)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("entuuid: unexpected input for FromValue: %T", v)
+	}
+	return nu.UUID, nil
+}
+
+// BinaryValueScanner is a field.TypeValueScanner storing uuid.UUID values as
+// 16 bytes. It scans both the binary and text forms of UUIDs.
+type BinaryValueScanner struct {
+	scanner
+}
+
+// Value implements the field.TypeValueScanner interface.
+func (BinaryValueScanner) Value(u uuid.UUID) (driver.Value, error) {
+	return u.Bytes(), nil
+}
+
+// TextValueScanner is a field.TypeValueScanner storing uuid.UUID values in
+// their canonical string form. It scans both the binary and text forms of
+// UUIDs.
+type TextValueScanner struct {
+	scanner
+}
+
+// Value implements the field.TypeValueScanner interface.
+func (TextValueScanner) Value(u uuid.UUID) (driver.Value, error) {
+	return u.String(), nil
+}
@@ -0,0 +1,107 @@
+package entuuid
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"testing"
+
+	"entgo.io/ent/schema/field"
+	"github.com/gofrs/uuid/v5"
+)
+
+var testUUID = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+func TestDefaults(t *testing.T) {
+	if got := NewV7().Version(); got != uuid.V7 {
+		t.Errorf("NewV7() version = %d, want %d", got, uuid.V7)
+	}
+	if got := NewV4().Version(); got != uuid.V4 {
+		t.Errorf("NewV4() version = %d, want %d", got, uuid.V4)
+	}
+}
+
+func TestIDMixin(t *testing.T) {
+	fields := IDMixin{}.Fields()
+	if len(fields) != 1 {
+		t.Fatalf("IDMixin.Fields() returned %d fields, want 1", len(fields))
+	}
+	d := fields[0].Descriptor()
+	if d.Err != nil {
+		t.Fatalf("IDMixin id field error: %v", d.Err)
+	}
+	if d.Name != "id" || !d.Immutable || d.Info.Type != field.TypeUUID {
+		t.Errorf("IDMixin id field = %+v, want an immutable uuid field named id", d)
+	}
+	def, ok := d.Default.(func() uuid.UUID)
+	if !ok {
+		t.Fatalf("IDMixin id default is %T, want func() uuid.UUID", d.Default)
+	}
+	if got := def().Version(); got != uuid.V7 {
+		t.Errorf("IDMixin id default version = %d, want %d", got, uuid.V7)
+	}
+}
+
+func TestValueScanners(t *testing.T) {
+	tests := []struct {
+		name string
+		fld  interface{ Descriptor() *field.Descriptor }
+		vs   field.TypeValueScanner[uuid.UUID]
+		want driver.Value
+	}{
+		{
+			name: "Binary",
+			fld:  field.Bytes("ref").GoType(uuid.UUID{}).ValueScanner(BinaryValueScanner{}),
+			vs:   BinaryValueScanner{},
+			want: testUUID.Bytes(),
+		},
+		{
+			name: "Text",
+			fld:  field.String("ref").GoType(uuid.UUID{}).ValueScanner(TextValueScanner{}),
+			vs:   TextValueScanner{},
+			want: testUUID.String(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.fld.Descriptor().Err; err != nil {
+				t.Fatalf("field descriptor error: %v", err)
+			}
+			v, err := tt.vs.Value(testUUID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b, ok := v.([]byte); ok {
+				if !bytes.Equal(b, tt.want.([]byte)) {
+					t.Errorf("Value(%v) = %x, want %x", testUUID, b, tt.want)
+				}
+			} else if v != tt.want {
+				t.Errorf("Value(%v) = %v, want %v", testUUID, v, tt.want)
+			}
+
+			for _, src := range []interface{}{testUUID.Bytes(), testUUID.String()} {
+				sv := tt.vs.ScanValue()
+				if err := sv.Scan(src); err != nil {
+					t.Fatalf("Scan(%v) unexpected error: %v", src, err)
+				}
+				got, err := tt.vs.FromValue(sv)
+				if err != nil {
+					t.Fatalf("FromValue() unexpected error: %v", err)
+				}
+				if got != testUUID {
+					t.Errorf("FromValue() after Scan(%v) = %v, want %v", src, got, testUUID)
+				}
+			}
+
+			sv := tt.vs.ScanValue()
+			if err := sv.Scan(nil); err != nil {
+				t.Fatal(err)
+			}
+			if got, err := tt.vs.FromValue(sv); err != nil || got != uuid.Nil {
+				t.Errorf("FromValue() after Scan(nil) = %v, %v, want %v, <nil>", got, err, uuid.Nil)
+			}
+			if _, err := tt.vs.FromValue("unexpected"); err == nil {
+				t.Error("FromValue(string) succeeded, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+module github.com/gofrs/uuid/v5/entuuid
+
+go 1.24
+
+require github.com/gofrs/uuid/v5 v5.0.0
+
+require entgo.io/ent v0.14.6
+
+replace github.com/gofrs/uuid/v5 => ../
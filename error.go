@@ -32,6 +32,50 @@ const (
 
 	// ErrInvalidVersion indicates an unsupported or invalid UUID version.
 	ErrInvalidVersion = Error("uuid:")
+
+	// ErrInvalidVariant indicates a UUID that is not of the RFC 9562 variant.
+	ErrInvalidVariant = Error("uuid: invalid variant")
+
+	// ErrInvalidTimestamp indicates a UUID with a timestamp that could not
+	// have been generated.
+	ErrInvalidTimestamp = Error("uuid: invalid timestamp")
+
+	// ErrTimestampOutOfRange is returned when a UUID is generated for a time
+	// outside of the range of the timestamps of its version.
+	ErrTimestampOutOfRange = Error("uuid: timestamp out of range")
+
+	// ErrIntegerOutOfRange is returned when an integer cannot be represented
+	// as an unsigned 128-bit UUID value.
+	ErrIntegerOutOfRange = Error("uuid: integer out of range")
+
+	// ErrInvalidArgument is returned when a function is called with an
+	// argument outside of its accepted range.
+	ErrInvalidArgument = Error("uuid: invalid argument")
+
+	// ErrNotMonotonic is returned when a sequence of UUIDs is not in the
+	// order of their timestamps.
+	ErrNotMonotonic = Error("uuid: not monotonic")
+
+	// ErrBadRandSource is returned when a random source fails the checks of
+	// VerifyRandSource.
+	ErrBadRandSource = Error("uuid: bad random source")
+
+	// ErrNoNodeAvailable is returned when a NodeAllocator has no node ID
+	// left to lease.
+	ErrNoNodeAvailable = Error("uuid: no node ID available")
+
+	// ErrNodeLeaseLost is returned when a node ID lease cannot be renewed,
+	// as it expired or was released.
+	ErrNodeLeaseLost = Error("uuid: node ID lease lost")
+
+	// ErrNamespaceConflict is returned when a namespace is registered under
+	// a name, or with a UUID, already registered for another namespace.
+	ErrNamespaceConflict = Error("uuid: namespace already registered")
+
+	// ErrHashDisabled is the value of the panics of NewV3 and NewV5 when the
+	// package is built with the uuid_fips build tag, which removes their MD5
+	// and SHA-1 hashes. NewV8SHA256 can be used instead.
+	ErrHashDisabled = Error("uuid: MD5 and SHA-1 name-based UUIDs are disabled by the uuid_fips build tag")
 )
 
 // Error returns the string representation of the UUID error.
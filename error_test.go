@@ -3,7 +3,6 @@ package uuid
 import (
 	"errors"
 	"fmt"
-	"net"
 	"testing"
 )
 
@@ -179,23 +178,3 @@ func TestUUIDVersionErrors(t *testing.T) {
 		t.Errorf("unexpected error '%s' != '%s'", err.Error(), expectedErr)
 	}
 }
-
-// This test cannot be run in parallel with other tests since it modifies the
-// global state
-func TestErrNoHwAddressFound(t *testing.T) {
-	netInterfaces = func() ([]net.Interface, error) {
-		return nil, nil
-	}
-	defer func() {
-		netInterfaces = net.Interfaces
-	}()
-	_, err := defaultHWAddrFunc()
-	if err == nil {
-		t.Error("expected an error")
-		return
-	}
-	expectedErr := "uuid: no HW address found"
-	if err.Error() != expectedErr {
-		t.Errorf("unexpected error '%s' != '%s'", err.Error(), expectedErr)
-	}
-}
@@ -0,0 +1,228 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Fields is the decoded structure of a UUID, as laid out in RFC 9562. Only
+// the fields meaningful for the UUID's version are set; the others are zero.
+// Fields other than Version and Variant are only decoded for RFC 9562
+// variant UUIDs.
+type Fields struct {
+	Version byte
+	Variant byte
+
+	// Timestamp is the embedded time of version 1, 6 and 7 UUIDs, in the
+	// 100-nanosecond units used by version 1. Version 7 UUIDs only have
+	// millisecond precision.
+	Timestamp    Timestamp
+	HasTimestamp bool
+
+	// ClockSequence (14 bits) and Node are set for version 1 and 6 UUIDs.
+	ClockSequence uint16
+	Node          [6]byte
+	HasNode       bool
+
+	// The random segments, named after RFC 9562. For version 4 UUIDs,
+	// RandomA holds random_a (48 bits), RandomB random_b (12 bits) and
+	// RandomC random_c (62 bits). For version 7 UUIDs, RandomA holds rand_a
+	// (12 bits) and RandomB rand_b (62 bits).
+	RandomA uint64
+	RandomB uint64
+	RandomC uint64
+}
+
+// Fields returns the decoded structure of the UUID.
+func (u UUID) Fields() Fields {
+	f := Fields{
+		Version: u.Version(),
+		Variant: u.Variant(),
+	}
+	if f.Variant != VariantRFC9562 {
+		return f
+	}
+	randA := uint64(binary.BigEndian.Uint16(u[6:8]) & 0x0fff)
+	randB := binary.BigEndian.Uint64(u[8:16]) & (1<<62 - 1)
+	switch f.Version {
+	case V1, V6:
+		f.Timestamp, f.HasTimestamp = timestampOf(u)
+		f.ClockSequence = binary.BigEndian.Uint16(u[8:10]) & 0x3fff
+		copy(f.Node[:], u[10:16])
+		f.HasNode = true
+	case V4:
+		f.RandomA = uint64(u[0])<<40 | uint64(u[1])<<32 | uint64(u[2])<<24 |
+			uint64(u[3])<<16 | uint64(u[4])<<8 | uint64(u[5])
+		f.RandomB = randA
+		f.RandomC = randB
+	case V7:
+		f.Timestamp, f.HasTimestamp = timestampOf(u)
+		f.RandomA = randA
+		f.RandomB = randB
+	}
+	return f
+}
+
+// NodeID returns the 48-bit node of a version 1 or 6 UUID. For version 1
+// UUIDs this is usually the hardware address of the generating host. It
+// returns an error for UUIDs of any other version or variant.
+func (u UUID) NodeID() ([6]byte, error) {
+	f, err := u.nodeFields()
+	return f.Node, err
+}
+
+// ClockSequence returns the 14-bit clock sequence of a version 1 or 6 UUID.
+// It returns an error for UUIDs of any other version or variant.
+func (u UUID) ClockSequence() (uint16, error) {
+	f, err := u.nodeFields()
+	return f.ClockSequence, err
+}
+
+// nodeFields returns the decoded fields of u, or an error if u has no node
+// and clock sequence.
+func (u UUID) nodeFields() (Fields, error) {
+	f := u.Fields()
+	switch {
+	case f.Variant != VariantRFC9562:
+		return Fields{}, fmt.Errorf("%w: %s has variant %d, not the RFC 9562 variant", ErrInvalidVariant, u, f.Variant)
+	case !f.HasNode:
+		return Fields{}, fmt.Errorf("%w %s is version %d, not version 1 or 6", ErrInvalidVersion, u, f.Version)
+	}
+	return f, nil
+}
+
+// MicrosoftFields returns the decoded structure of a UUID of the Microsoft
+// variant, laid out as version 1 UUIDs are, but with its first three groups
+// in little-endian byte order, as in the GUID structure of Windows. u is
+// expected to hold the bytes of such a structure, as read by FromBytes; a
+// GUID parsed from its string form can be converted to this layout with
+// MSSQLUUID(u).MSSQLBytes() first.
+//
+// The timestamp, 13-bit clock sequence and node are only decoded for
+// version 1. It returns an error for UUIDs of any other variant.
+func (u UUID) MicrosoftFields() (Fields, error) {
+	f := Fields{Variant: u.Variant()}
+	if f.Variant != VariantMicrosoft {
+		return Fields{}, fmt.Errorf("%w: %s has variant %d, not the Microsoft variant", ErrInvalidVariant, u, f.Variant)
+	}
+	// The version is in the high nibble of time_hi_and_version, stored
+	// little-endian.
+	f.Version = u[7] >> 4
+	if f.Version == V1 {
+		timeLow := uint64(binary.LittleEndian.Uint32(u[0:4]))
+		timeMid := uint64(binary.LittleEndian.Uint16(u[4:6]))
+		timeHigh := uint64(binary.LittleEndian.Uint16(u[6:8]) & 0x0fff)
+		f.Timestamp = Timestamp(timeHigh<<48 | timeMid<<32 | timeLow)
+		f.HasTimestamp = true
+		f.ClockSequence = binary.BigEndian.Uint16(u[8:10]) & 0x1fff
+		copy(f.Node[:], u[10:16])
+		f.HasNode = true
+	}
+	return f, nil
+}
+
+// ncsEpoch is the epoch of the timestamps of NCS UUIDs, January 1, 1980, in
+// 100-nanosecond intervals since the UUID epoch (October 15, 1582).
+const ncsEpoch = epochStart + 315532800*_100nsPerSecond
+
+// NCSFields is the decoded structure of a UUID of the NCS variant, as
+// generated by the Network Computing System of Apollo Computer.
+type NCSFields struct {
+	// Timestamp is the time of the UUID, stored as a 48-bit count of
+	// 4-microsecond intervals since January 1, 1980, in the 100-nanosecond
+	// units of Fields.Timestamp.
+	Timestamp Timestamp
+
+	Reserved uint16
+
+	// Family is the address family of Host, such as 2 for IP or 13 for
+	// DDS.
+	Family byte
+	Host   [7]byte
+}
+
+// NCSFields returns the decoded structure of a UUID of the NCS variant. It
+// returns an error for UUIDs of any other variant, including the Nil UUID,
+// which is of the NCS variant but was never generated as an NCS UUID.
+func (u UUID) NCSFields() (NCSFields, error) {
+	if v := u.Variant(); v != VariantNCS || u == Nil {
+		return NCSFields{}, fmt.Errorf("%w: %s has variant %d, not the NCS variant", ErrInvalidVariant, u, v)
+	}
+	t := uint64(u[0])<<40 | uint64(u[1])<<32 | uint64(u[2])<<24 |
+		uint64(u[3])<<16 | uint64(u[4])<<8 | uint64(u[5])
+	f := NCSFields{
+		Timestamp: Timestamp(ncsEpoch + 40*t),
+		Reserved:  binary.BigEndian.Uint16(u[6:8]),
+		Family:    u[8],
+	}
+	copy(f.Host[:], u[9:16])
+	return f, nil
+}
+
+// versionDescriptions describes the UUID versions for Explain.
+var versionDescriptions = [16]string{
+	0:  "reserved for the Nil UUID",
+	V1: "date-time and MAC address",
+	2:  "DCE security",
+	V3: "namespace name-based, MD5",
+	V4: "random",
+	V5: "namespace name-based, SHA-1",
+	V6: "reordered date-time, field-compatible with version 1",
+	V7: "Unix epoch date-time",
+	8:  "custom",
+	9:  "reserved",
+	10: "reserved",
+	11: "reserved",
+	12: "reserved",
+	13: "reserved",
+	14: "reserved",
+	15: "reserved for the Max UUID",
+}
+
+// variantDescriptions describes the UUID variants for Explain.
+var variantDescriptions = [...]string{
+	VariantNCS:       "NCS backward compatibility",
+	VariantRFC9562:   "RFC 9562",
+	VariantMicrosoft: "Microsoft backward compatibility",
+	VariantFuture:    "reserved for future definition",
+}
+
+// Explain returns a human-readable, multi-line description of the UUID's
+// decoded structure, intended for debugging. The format is not stable.
+func (u UUID) Explain() string {
+	f := u.Fields()
+	var b strings.Builder
+	fmt.Fprintf(&b, "UUID:           %s\n", u)
+	fmt.Fprintf(&b, "Variant:        %d (%s)\n", f.Variant, variantDescriptions[f.Variant])
+	if ncs, err := u.NCSFields(); err == nil {
+		t, _ := ncs.Timestamp.Time()
+		fmt.Fprintf(&b, "Time:           %s\n", t.UTC().Format(time.RFC3339Nano))
+		fmt.Fprintf(&b, "Family:         %d\n", ncs.Family)
+		fmt.Fprintf(&b, "Host:           %x\n", ncs.Host)
+	}
+	if f.Variant != VariantRFC9562 {
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Version:        %d (%s)\n", f.Version, versionDescriptions[f.Version])
+	if f.HasTimestamp {
+		t, _ := f.Timestamp.Time()
+		fmt.Fprintf(&b, "Time:           %s\n", t.UTC().Format(time.RFC3339Nano))
+	}
+	if f.HasNode {
+		fmt.Fprintf(&b, "Clock sequence: %d\n", f.ClockSequence)
+		fmt.Fprintf(&b, "Node:           %02x:%02x:%02x:%02x:%02x:%02x\n",
+			f.Node[0], f.Node[1], f.Node[2], f.Node[3], f.Node[4], f.Node[5])
+	}
+	switch f.Version {
+	case V4:
+		fmt.Fprintf(&b, "random_a:       0x%012x\n", f.RandomA)
+		fmt.Fprintf(&b, "random_b:       0x%03x\n", f.RandomB)
+		fmt.Fprintf(&b, "random_c:       0x%016x\n", f.RandomC)
+	case V7:
+		fmt.Fprintf(&b, "rand_a:         0x%03x\n", f.RandomA)
+		fmt.Fprintf(&b, "rand_b:         0x%016x\n", f.RandomB)
+	}
+	return b.String()
+}
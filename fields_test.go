@@ -0,0 +1,232 @@
+package uuid
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFields(t *testing.T) {
+	t.Run("V1", func(t *testing.T) {
+		u := Must(FromString("968b80c3-a91b-11ee-8c32-baa7b68e1b32"))
+		f := u.Fields()
+		ts, _ := TimestampFromV1(u)
+		want := Fields{
+			Version:       V1,
+			Variant:       VariantRFC9562,
+			Timestamp:     ts,
+			HasTimestamp:  true,
+			ClockSequence: 0x0c32,
+			Node:          [6]byte{0xba, 0xa7, 0xb6, 0x8e, 0x1b, 0x32},
+			HasNode:       true,
+		}
+		if f != want {
+			t.Errorf("%v.Fields() = %+v, want %+v", u, f, want)
+		}
+	})
+	t.Run("V6", func(t *testing.T) {
+		u := Must(FromString("1ec9414c-232a-6b00-b3c8-9f6bdeced846"))
+		f := u.Fields()
+		if f.Version != V6 || !f.HasTimestamp || !f.HasNode {
+			t.Fatalf("%v.Fields() = %+v", u, f)
+		}
+		tm, _ := f.Timestamp.Time()
+		if want := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC); !tm.Equal(want) {
+			t.Errorf("%v.Fields().Timestamp.Time() = %v, want %v", u, tm, want)
+		}
+		if f.ClockSequence != 0x33c8 || f.Node != [6]byte{0x9f, 0x6b, 0xde, 0xce, 0xd8, 0x46} {
+			t.Errorf("%v.Fields() = %+v", u, f)
+		}
+	})
+	t.Run("V4", func(t *testing.T) {
+		u := Must(FromString("123e4567-e89b-42d3-a456-426614174000"))
+		want := Fields{
+			Version: V4,
+			Variant: VariantRFC9562,
+			RandomA: 0x123e4567e89b,
+			RandomB: 0x2d3,
+			RandomC: 0x2456426614174000,
+		}
+		if f := u.Fields(); f != want {
+			t.Errorf("%v.Fields() = %+v, want %+v", u, f, want)
+		}
+	})
+	t.Run("V7", func(t *testing.T) {
+		u := Must(FromString("018bcfe5-687b-7abc-8123-456789abcdef"))
+		f := u.Fields()
+		if f.Version != V7 || !f.HasTimestamp || f.HasNode || f.RandomA != 0xabc || f.RandomB != 0x0123456789abcdef || f.RandomC != 0 {
+			t.Errorf("%v.Fields() = %+v", u, f)
+		}
+		tm, _ := f.Timestamp.Time()
+		if want := time.UnixMilli(1700000000123); !tm.Equal(want) {
+			t.Errorf("%v.Fields().Timestamp.Time() = %v, want %v", u, tm, want)
+		}
+	})
+	t.Run("NonRFC", func(t *testing.T) {
+		u := Must(FromString("968b80c3-a91b-11ee-cc32-baa7b68e1b32"))
+		want := Fields{Version: V1, Variant: VariantMicrosoft}
+		if f := u.Fields(); f != want {
+			t.Errorf("%v.Fields() = %+v, want %+v", u, f, want)
+		}
+	})
+}
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		u    UUID
+		want []string
+	}{
+		{
+			u: Must(FromString("968b80c3-a91b-11ee-8c32-baa7b68e1b32")),
+			want: []string{
+				"Version:        1 (date-time and MAC address)",
+				"Time:           2024-01-02T03:04:05.0000067Z",
+				"Clock sequence: 3122",
+				"Node:           ba:a7:b6:8e:1b:32",
+			},
+		},
+		{
+			u: Must(FromString("018bcfe5-687b-7abc-8123-456789abcdef")),
+			want: []string{
+				"Variant:        1 (RFC 9562)",
+				"Time:           2023-11-14T22:13:20.123Z",
+				"rand_a:         0xabc",
+				"rand_b:         0x0123456789abcdef",
+			},
+		},
+		{
+			u: Must(FromString("123e4567-e89b-42d3-a456-426614174000")),
+			want: []string{
+				"random_a:       0x123e4567e89b",
+				"random_b:       0x2d3",
+				"random_c:       0x2456426614174000",
+			},
+		},
+		{
+			u:    Nil,
+			want: []string{"Variant:        0 (NCS backward compatibility)"},
+		},
+		{
+			u: Must(FromString("333a2276-0000-0000-0d00-00809c000000")),
+			want: []string{
+				"Variant:        0 (NCS backward compatibility)",
+				"Time:           1987-02-20T15:05:17.113344Z",
+				"Family:         13",
+				"Host:           0000809c000000",
+			},
+		},
+	}
+	for _, tt := range tests {
+		got := tt.u.Explain()
+		if !strings.HasPrefix(got, "UUID:           "+tt.u.String()+"\n") {
+			t.Errorf("%v.Explain() does not start with the UUID:\n%s", tt.u, got)
+		}
+		for _, line := range tt.want {
+			if !strings.Contains(got, line+"\n") {
+				t.Errorf("%v.Explain() does not contain %q:\n%s", tt.u, line, got)
+			}
+		}
+	}
+}
+
+func TestNodeIDAndClockSequence(t *testing.T) {
+	hw := net.HardwareAddr{0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e}
+	g := NewGenWithOptions(WithHWAddrFunc(func() (HWAddr, error) {
+		return hw, nil
+	}))
+	v1 := Must(g.NewV1())
+	node, err := v1.NodeID()
+	if err != nil {
+		t.Fatalf("%v.NodeID() unexpected error: %v", v1, err)
+	}
+	if want := [6]byte{0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e}; node != want {
+		t.Errorf("%v.NodeID() = %x, want %x", v1, node, want)
+	}
+	seq, err := v1.ClockSequence()
+	if err != nil {
+		t.Fatalf("%v.ClockSequence() unexpected error: %v", v1, err)
+	}
+	if want, _ := g.ClockSequence(); seq != want {
+		t.Errorf("%v.ClockSequence() = %d, want %d", v1, seq, want)
+	}
+
+	v6 := Must(FromString("1ec9414c-232a-6b00-b3c8-9f6bdeced846"))
+	if node, err := v6.NodeID(); err != nil || node != [6]byte{0x9f, 0x6b, 0xde, 0xce, 0xd8, 0x46} {
+		t.Errorf("%v.NodeID() = %x, %v, want 9f6bdeced846, <nil>", v6, node, err)
+	}
+	if seq, err := v6.ClockSequence(); err != nil || seq != 0x33c8 {
+		t.Errorf("%v.ClockSequence() = %#x, %v, want 0x33c8, <nil>", v6, seq, err)
+	}
+
+	microsoft := v1
+	microsoft.SetVariant(VariantMicrosoft)
+	for u, want := range map[UUID]error{
+		Nil:           ErrInvalidVariant,
+		microsoft:     ErrInvalidVariant,
+		Must(NewV4()): ErrInvalidVersion,
+		Must(NewV7()): ErrInvalidVersion,
+	} {
+		if _, err := u.NodeID(); !errors.Is(err, want) {
+			t.Errorf("%v.NodeID() error = %v, want %v", u, err, want)
+		}
+		if _, err := u.ClockSequence(); !errors.Is(err, want) {
+			t.Errorf("%v.ClockSequence() error = %v, want %v", u, err, want)
+		}
+	}
+}
+
+func TestMicrosoftFields(t *testing.T) {
+	// NamespaceDNS, a version 1 UUID, with the Microsoft variant, in the
+	// layout of the GUID structure of Windows.
+	u := NamespaceDNS
+	u.SetVariant(VariantMicrosoft)
+	guid := Must(FromBytes(MSSQLUUID(u).MSSQLBytes()))
+
+	f, err := guid.MicrosoftFields()
+	if err != nil {
+		t.Fatalf("%v.MicrosoftFields() unexpected error: %v", guid, err)
+	}
+	want := NamespaceDNS.Fields()
+	want.Variant = VariantMicrosoft
+	want.ClockSequence &= 0x1fff
+	if f != want {
+		t.Errorf("%v.MicrosoftFields() = %+v, want %+v", guid, f, want)
+	}
+
+	u = codecTestUUID
+	u.SetVariant(VariantMicrosoft)
+	u.SetVersion(V4)
+	guid = Must(FromBytes(MSSQLUUID(u).MSSQLBytes()))
+	if f, err := guid.MicrosoftFields(); err != nil || f != (Fields{Version: V4, Variant: VariantMicrosoft}) {
+		t.Errorf("%v.MicrosoftFields() = %+v, %v, want version 4 only", guid, f, err)
+	}
+
+	for _, u := range []UUID{NamespaceDNS, Nil, Max} {
+		if _, err := u.MicrosoftFields(); !errors.Is(err, ErrInvalidVariant) {
+			t.Errorf("%v.MicrosoftFields() error = %v, want %v", u, err, ErrInvalidVariant)
+		}
+	}
+}
+
+func TestNCSFields(t *testing.T) {
+	u := Must(FromString("333a2276-0000-0000-0d00-00809c000000"))
+	f, err := u.NCSFields()
+	if err != nil {
+		t.Fatalf("%v.NCSFields() unexpected error: %v", u, err)
+	}
+	if f.Family != 13 || f.Reserved != 0 || f.Host != [7]byte{0x00, 0x00, 0x80, 0x9c, 0x00, 0x00, 0x00} {
+		t.Errorf("%v.NCSFields() = %+v", u, f)
+	}
+	want := time.Date(1987, 2, 20, 15, 5, 17, 113344000, time.UTC)
+	if got, _ := f.Timestamp.Time(); !got.Equal(want) {
+		t.Errorf("%v.NCSFields().Timestamp = %v, want %v", u, got, want)
+	}
+
+	for _, u := range []UUID{NamespaceDNS, Nil, Max} {
+		if _, err := u.NCSFields(); !errors.Is(err, ErrInvalidVariant) {
+			t.Errorf("%v.NCSFields() error = %v, want %v", u, err, ErrInvalidVariant)
+		}
+	}
+}
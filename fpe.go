@@ -0,0 +1,160 @@
+package uuid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"fmt"
+)
+
+type fpeConfig struct {
+	tweak    []byte
+	preserve bool
+}
+
+// FPEOption configures EncryptUUID and DecryptUUID. A UUID must be decrypted
+// with the options it was encrypted with.
+type FPEOption func(*fpeConfig)
+
+// WithFPETweak sets the tweak of the FF1 cipher, which is empty by default.
+// Encrypting the same UUID with the same key and different tweaks returns
+// unrelated UUIDs, so that a tweak per partner, for instance, keeps the
+// pseudonyms given to each partner from being correlated.
+func WithFPETweak(tweak []byte) FPEOption {
+	return func(c *fpeConfig) {
+		c.tweak = tweak
+	}
+}
+
+// WithFPEPreserveVersion preserves the version bits and the two most
+// significant variant bits, encrypting only the other 122 bits. The
+// encryption of a version 4 UUID of the RFC 9562 variant is then also a
+// version 4 UUID of the RFC 9562 variant.
+func WithFPEPreserveVersion() FPEOption {
+	return func(c *fpeConfig) {
+		c.preserve = true
+	}
+}
+
+// EncryptUUID encrypts u with the FF1 format-preserving encryption mode of
+// NIST SP 800-38G, using AES with key, which must be 16, 24 or 32 bytes long.
+// EncryptUUID is a bijection of the UUIDs: it maps every UUID to a UUID, and
+// distinct UUIDs to distinct UUIDs, so that identifiers can be pseudonymized
+// and recovered with DecryptUUID rather than a lookup table.
+//
+// The 128 bits of u are encrypted as a binary numeral string, unless
+// WithFPEPreserveVersion is given.
+func EncryptUUID(key []byte, u UUID, opts ...FPEOption) (UUID, error) {
+	return cryptUUID(key, u, opts, false)
+}
+
+// DecryptUUID decrypts u, encrypted by EncryptUUID with the same key and
+// options.
+func DecryptUUID(key []byte, u UUID, opts ...FPEOption) (UUID, error) {
+	return cryptUUID(key, u, opts, true)
+}
+
+func cryptUUID(key []byte, u UUID, opts []FPEOption, decrypt bool) (UUID, error) {
+	var c fpeConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return Nil, fmt.Errorf("%w: %v", ErrInvalidArgument, err)
+	}
+	f := ff1{block: block, tweak: c.tweak}
+
+	hi, lo := u.Uint64Pair()
+	if !c.preserve {
+		hi, lo = f.crypt(hi, lo, 128, decrypt)
+		return FromUint64Pair(hi, lo), nil
+	}
+
+	// The 122 encrypted bits are the 60 bits of hi around the version and
+	// the 62 bits of lo after the variant.
+	const (
+		versionMask = 0xf << 12
+		variantMask = 0x3 << 62
+	)
+	h60 := hi>>16<<12 | hi&0xfff
+	l62 := lo &^ variantMask
+	a, b := f.crypt(h60<<1|l62>>61, l62&(1<<61-1), 122, decrypt)
+	h60, l62 = a>>1, a&1<<61|b
+	hi = h60>>12<<16 | hi&versionMask | h60&0xfff
+	lo = lo&variantMask | l62
+	return FromUint64Pair(hi, lo), nil
+}
+
+// ff1 is the FF1 mode of NIST SP 800-38G for binary numeral strings of up to
+// 128 bits.
+type ff1 struct {
+	block cipher.Block
+	tweak []byte
+}
+
+// crypt encrypts, or decrypts, the numeral string of n bits whose first
+// n/2 bits are a and last n-n/2 bits are b, and returns the halves of the
+// result.
+func (f *ff1) crypt(a, b uint64, n int, decrypt bool) (uint64, uint64) {
+	u := n / 2
+	v := n - u
+	nb := (v + 7) / 8
+	t := len(f.tweak)
+
+	// P is the first block of every PRF input.
+	var p [aes.BlockSize]byte
+	p[0], p[1], p[2] = 1, 2, 1
+	p[5] = 2 // radix
+	p[6] = 10
+	p[7] = byte(u)
+	binary.BigEndian.PutUint32(p[8:], uint32(n))
+	binary.BigEndian.PutUint32(p[12:], uint32(t))
+	var y0 [aes.BlockSize]byte
+	f.block.Encrypt(y0[:], p[:])
+
+	pad := ((-t-nb-1)%16 + 16) % 16
+	q := make([]byte, t+pad+1+nb)
+	copy(q, f.tweak)
+
+	mask := func(m int) uint64 {
+		return ^uint64(0) >> (64 - m)
+	}
+	round := func(i int, x uint64) uint64 {
+		q[t+pad] = byte(i)
+		for j := 0; j < nb; j++ {
+			q[len(q)-1-j] = byte(x >> (8 * j))
+		}
+		r := y0
+		for k := 0; k < len(q); k += aes.BlockSize {
+			for j := range r {
+				r[j] ^= q[k+j]
+			}
+			f.block.Encrypt(r[:], r[:])
+		}
+		// S is the first 12 bytes of R, and y mod 2^m depends only on its
+		// last 8 bytes.
+		return binary.BigEndian.Uint64(r[4:12])
+	}
+
+	if !decrypt {
+		for i := 0; i < 10; i++ {
+			m := u
+			if i%2 == 1 {
+				m = v
+			}
+			c := (a + round(i, b)) & mask(m)
+			a, b = b, c
+		}
+		return a, b
+	}
+	for i := 9; i >= 0; i-- {
+		m := u
+		if i%2 == 1 {
+			m = v
+		}
+		c := (b - round(i, a)) & mask(m)
+		b, a = a, c
+	}
+	return a, b
+}
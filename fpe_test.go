@@ -0,0 +1,225 @@
+package uuid
+
+import (
+	"crypto/aes"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+var fpeTestKey = []byte{0x2b, 0x7e, 0x15, 0x16, 0x28, 0xae, 0xd2, 0xa6, 0xab, 0xf7, 0x15, 0x88, 0x09, 0xcf, 0x4f, 0x3c}
+
+// refFF1 is a straightforward implementation of FF1 encryption of NIST
+// SP 800-38G for any radix, against which ff1 is checked.
+func refFF1(t *testing.T, key, tweak []byte, radix int, x []int) []int {
+	t.Helper()
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := len(x)
+	u, v := n/2, n-n/2
+	bigRadix := big.NewInt(int64(radix))
+	num := func(s []int) *big.Int {
+		z := new(big.Int)
+		for _, d := range s {
+			z.Mul(z, bigRadix).Add(z, big.NewInt(int64(d)))
+		}
+		return z
+	}
+	str := func(z *big.Int, m int) []int {
+		s := make([]int, m)
+		z = new(big.Int).Set(z)
+		r := new(big.Int)
+		for i := m - 1; i >= 0; i-- {
+			z.QuoRem(z, bigRadix, r)
+			s[i] = int(r.Int64())
+		}
+		return s
+	}
+	// The bit length of radix^v - 1 is ceil(v * log2(radix)).
+	maxB := new(big.Int).Exp(bigRadix, big.NewInt(int64(v)), nil)
+	b := (maxB.Sub(maxB, big.NewInt(1)).BitLen() + 7) / 8
+	d := 4*((b+3)/4) + 4
+	p := []byte{1, 2, 1, byte(radix >> 16), byte(radix >> 8), byte(radix), 10, byte(u), byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n), byte(len(tweak) >> 24), byte(len(tweak) >> 16), byte(len(tweak) >> 8), byte(len(tweak))}
+	ciph := func(in []byte) []byte {
+		out := make([]byte, 16)
+		block.Encrypt(out, in)
+		return out
+	}
+	a, bb := x[:u], x[u:]
+	for i := 0; i < 10; i++ {
+		q := append([]byte(nil), tweak...)
+		q = append(q, make([]byte, ((-len(tweak)-b-1)%16+16)%16)...)
+		q = append(q, byte(i))
+		nbytes := num(bb).Bytes()
+		q = append(q, make([]byte, b-len(nbytes))...)
+		q = append(q, nbytes...)
+		pq := append(append([]byte(nil), p...), q...)
+		r := make([]byte, 16)
+		for k := 0; k < len(pq); k += 16 {
+			for j := range r {
+				r[j] ^= pq[k+j]
+			}
+			r = ciph(r)
+		}
+		s := append([]byte(nil), r...)
+		for j := 1; len(s) < d; j++ {
+			blk := append([]byte(nil), r...)
+			blk[15] ^= byte(j)
+			s = append(s, ciph(blk)...)
+		}
+		y := new(big.Int).SetBytes(s[:d])
+		m := u
+		if i%2 == 1 {
+			m = v
+		}
+		mod := new(big.Int).Exp(bigRadix, big.NewInt(int64(m)), nil)
+		c := new(big.Int).Add(num(a), y)
+		c.Mod(c, mod)
+		a, bb = bb, str(c, m)
+	}
+	return append(append([]int(nil), a...), bb...)
+}
+
+func digits(s string) []int {
+	x := make([]int, len(s))
+	for i, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			x[i] = int(c - '0')
+		default:
+			x[i] = int(c-'a') + 10
+		}
+	}
+	return x
+}
+
+func TestRefFF1(t *testing.T) {
+	// Samples of the NIST FF1 examples.
+	tweak, _ := hex.DecodeString("39383736353433323130")
+	tweak3, _ := hex.DecodeString("3737373770717273373737")
+	tests := []struct {
+		tweak  []byte
+		radix  int
+		pt, ct string
+	}{
+		{nil, 10, "0123456789", "2433477484"},
+		{tweak, 10, "0123456789", "6124200773"},
+		{tweak3, 36, "0123456789abcdefghi", "a9tv40mll9kdu509eum"},
+	}
+	for _, tt := range tests {
+		got := refFF1(t, fpeTestKey, tt.tweak, tt.radix, digits(tt.pt))
+		want := digits(tt.ct)
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("refFF1(%s) = %v, want %s", tt.pt, got, tt.ct)
+				break
+			}
+		}
+	}
+}
+
+// bitsOf returns the bits of u, most significant first, skipping the bit
+// positions in skip.
+func bitsOf(u UUID, skip map[int]bool) []int {
+	var x []int
+	for i := 0; i < 128; i++ {
+		if !skip[i] {
+			x = append(x, int(u[i/8]>>(7-i%8)&1))
+		}
+	}
+	return x
+}
+
+// fpePreserved are the bit positions preserved by WithFPEPreserveVersion.
+var fpePreserved = map[int]bool{48: true, 49: true, 50: true, 51: true, 64: true, 65: true}
+
+func TestEncryptUUIDReference(t *testing.T) {
+	tweak := []byte("partner-1")
+	g := NewGen()
+	for i := 0; i < 20; i++ {
+		u, _ := g.NewV4()
+		for _, tt := range []struct {
+			opts []FPEOption
+			skip map[int]bool
+			tw   []byte
+		}{
+			{nil, nil, nil},
+			{[]FPEOption{WithFPETweak(tweak)}, nil, tweak},
+			{[]FPEOption{WithFPEPreserveVersion()}, fpePreserved, nil},
+			{[]FPEOption{WithFPEPreserveVersion(), WithFPETweak(tweak)}, fpePreserved, tweak},
+		} {
+			got, err := EncryptUUID(fpeTestKey, u, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := refFF1(t, fpeTestKey, tt.tw, 2, bitsOf(u, tt.skip))
+			gotBits := bitsOf(got, tt.skip)
+			for j := range want {
+				if gotBits[j] != want[j] {
+					t.Fatalf("EncryptUUID(%v) = %v, does not match the reference FF1 encryption", u, got)
+				}
+			}
+			for j := range tt.skip {
+				if got[j/8]>>(7-j%8)&1 != u[j/8]>>(7-j%8)&1 {
+					t.Fatalf("EncryptUUID(%v) = %v, preserved bit %d changed", u, got, j)
+				}
+			}
+		}
+	}
+}
+
+func TestEncryptUUID(t *testing.T) {
+	keys := [][]byte{fpeTestKey, make([]byte, 24), make([]byte, 32)}
+	g := NewGen()
+	for _, key := range keys {
+		for _, u := range []UUID{Nil, Max, codecTestUUID, Must(g.NewV4()), Must(g.NewV7())} {
+			for _, opts := range [][]FPEOption{nil, {WithFPEPreserveVersion()}, {WithFPETweak([]byte{1, 2, 3})}} {
+				e, err := EncryptUUID(key, u, opts...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if e == u {
+					t.Errorf("EncryptUUID(%v) = %v, unchanged", u, e)
+				}
+				d, err := DecryptUUID(key, e, opts...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if d != u {
+					t.Errorf("DecryptUUID(EncryptUUID(%v)) = %v", u, d)
+				}
+			}
+		}
+	}
+
+	u := Must(g.NewV4())
+	e, _ := EncryptUUID(fpeTestKey, u, WithFPEPreserveVersion())
+	if e.Version() != V4 || e.Variant() != VariantRFC9562 {
+		t.Errorf("EncryptUUID(%v, WithFPEPreserveVersion()) = %v, want a version 4 UUID", u, e)
+	}
+	e1, _ := EncryptUUID(fpeTestKey, u, WithFPETweak([]byte("a")))
+	e2, _ := EncryptUUID(fpeTestKey, u, WithFPETweak([]byte("b")))
+	if e1 == e2 {
+		t.Errorf("EncryptUUID(%v) is the same with different tweaks", u)
+	}
+}
+
+func TestEncryptUUIDErrors(t *testing.T) {
+	for _, key := range [][]byte{nil, make([]byte, 15)} {
+		if _, err := EncryptUUID(key, codecTestUUID); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("EncryptUUID(%d-byte key) error = %v, want %v", len(key), err, ErrInvalidArgument)
+		}
+		if _, err := DecryptUUID(key, codecTestUUID); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("DecryptUUID(%d-byte key) error = %v, want %v", len(key), err, ErrInvalidArgument)
+		}
+	}
+}
+
+func BenchmarkEncryptUUID(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		EncryptUUID(fpeTestKey, codecTestUUID)
+	}
+}
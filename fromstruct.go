@@ -0,0 +1,203 @@
+package uuid
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// maxStructDepth bounds the nesting of the values encoded by NewFromStruct,
+// which would otherwise recurse forever on cyclic pointers.
+const maxStructDepth = 64
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// NewFromStruct returns a name-based UUID of the content of v, usually a
+// struct, so that records with the same content get the same ID, such as in
+// idempotent ingestion. It is the version 8 UUID of the SHA-256 hash of the
+// namespace UUID and a canonical binary encoding of v:
+//
+//   - the exported fields of structs are encoded under their names, or the
+//     name in their uuid struct tag, in the order of these names, and the
+//     fields tagged `uuid:"-"` are skipped, so that the UUID does not change
+//     when fields are reordered or renamed with a tag;
+//   - the entries of maps are encoded in the order of their encoded keys;
+//   - values implementing encoding.TextMarshaler, such as UUIDs, are encoded
+//     as their text, and times as their text in UTC;
+//   - pointers and interfaces are encoded as the values they point to, and
+//     integers as 64-bit values, so that changing the width of a field or
+//     making it a pointer does not change the UUID.
+//
+// It returns an error wrapping ErrInvalidArgument for values that cannot be
+// encoded: channels, functions, complex numbers and values nested too deep,
+// such as cyclic data structures.
+func NewFromStruct(ns UUID, v interface{}) (UUID, error) {
+	b, err := appendCanonical(nil, reflect.ValueOf(v), 0)
+	if err != nil {
+		return Nil, err
+	}
+
+	u := newFromHash(sha256.New(), ns, b)
+	u.SetVersion(8)
+	u.SetVariant(VariantRFC9562)
+
+	return u, nil
+}
+
+// Type bytes of the canonical encoding of NewFromStruct, each followed by
+// the encoding of the value.
+const (
+	canonicalNil    = 'n'
+	canonicalBool   = 'b'
+	canonicalInt    = 'i' // varint
+	canonicalUint   = 'u' // uvarint
+	canonicalFloat  = 'f' // IEEE 754 bits, big-endian
+	canonicalString = 's' // uvarint length, bytes
+	canonicalBytes  = 'y' // uvarint length, bytes
+	canonicalList   = 'l' // uvarint length, elements
+	canonicalMap    = 'm' // uvarint length, sorted keys and values
+	canonicalStruct = 'r' // uvarint length, sorted names and values
+)
+
+func appendCanonical(b []byte, v reflect.Value, depth int) ([]byte, error) {
+	if depth > maxStructDepth {
+		return nil, fmt.Errorf("%w: value nested deeper than %d levels", ErrInvalidArgument, maxStructDepth)
+	}
+	if !v.IsValid() {
+		return append(b, canonicalNil), nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return append(b, canonicalNil), nil
+		}
+		return appendCanonical(b, v.Elem(), depth+1)
+	}
+	if v.Type() == timeType {
+		v = reflect.ValueOf(v.Interface().(time.Time).UTC())
+	}
+	if v.Type().Implements(textMarshalerType) && v.CanInterface() {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return appendCanonicalBytes(b, canonicalString, text), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			return append(b, canonicalBool, 1), nil
+		}
+		return append(b, canonicalBool, 0), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(append(b, canonicalInt), v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return binary.AppendUvarint(append(b, canonicalUint), v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return binary.BigEndian.AppendUint64(append(b, canonicalFloat), math.Float64bits(v.Float())), nil
+	case reflect.String:
+		return appendCanonicalBytes(b, canonicalString, []byte(v.String())), nil
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if v.Kind() == reflect.Slice && v.IsNil() {
+				return append(b, canonicalNil), nil
+			}
+			raw := make([]byte, v.Len())
+			reflect.Copy(reflect.ValueOf(raw), v)
+			return appendCanonicalBytes(b, canonicalBytes, raw), nil
+		}
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return append(b, canonicalNil), nil
+		}
+		b = binary.AppendUvarint(append(b, canonicalList), uint64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			var err error
+			if b, err = appendCanonical(b, v.Index(i), depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return append(b, canonicalNil), nil
+		}
+		entries := make([][2][]byte, 0, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			k, err := appendCanonical(nil, iter.Key(), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			e, err := appendCanonical(nil, iter.Value(), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, [2][]byte{k, e})
+		}
+		sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i][0], entries[j][0]) < 0 })
+		b = binary.AppendUvarint(append(b, canonicalMap), uint64(len(entries)))
+		for _, e := range entries {
+			b = append(append(b, e[0]...), e[1]...)
+		}
+		return b, nil
+	case reflect.Struct:
+		return appendCanonicalStruct(b, v, depth)
+	}
+	return nil, fmt.Errorf("%w: cannot encode value of type %s", ErrInvalidArgument, v.Type())
+}
+
+func appendCanonicalStruct(b []byte, v reflect.Value, depth int) ([]byte, error) {
+	type field struct {
+		name  string
+		index int
+	}
+	t := v.Type()
+	fields := make([]field, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("uuid"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				name = tag
+			}
+		}
+		fields = append(fields, field{name, i})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].name < fields[j].name })
+	for i := 1; i < len(fields); i++ {
+		if fields[i].name == fields[i-1].name {
+			return nil, fmt.Errorf("%w: duplicate field name %q in %s", ErrInvalidArgument, fields[i].name, t)
+		}
+	}
+
+	b = binary.AppendUvarint(append(b, canonicalStruct), uint64(len(fields)))
+	for _, f := range fields {
+		b = appendCanonicalBytes(b, canonicalString, []byte(f.name))
+		var err error
+		if b, err = appendCanonical(b, v.Field(f.index), depth+1); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func appendCanonicalBytes(b []byte, typ byte, s []byte) []byte {
+	b = binary.AppendUvarint(append(b, typ), uint64(len(s)))
+	return append(b, s...)
+}
@@ -0,0 +1,152 @@
+package uuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type fromStructRecord struct {
+	Account UUID
+	Name    string
+	Amount  int64
+	Tags    []string
+	Labels  map[string]string
+	At      time.Time
+	Note    *string
+	Skipped string `uuid:"-"`
+	secret  string
+}
+
+type fromStructReordered struct {
+	Name    string
+	Labels  map[string]string
+	Amount  int32
+	Tags    []string
+	Account UUID
+	At      time.Time
+	Note    string
+	Renamed string `uuid:"Skipped2"`
+}
+
+func TestNewFromStruct(t *testing.T) {
+	ns := NamespaceDNS
+	at := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	rec := fromStructRecord{
+		Account: NamespaceURL,
+		Name:    "invoice",
+		Amount:  42,
+		Tags:    []string{"a", "b"},
+		Labels:  map[string]string{"x": "1", "y": "2", "z": "3"},
+		At:      at,
+		Skipped: "skipped",
+		secret:  "secret",
+	}
+	u, err := NewFromStruct(ns, rec)
+	if err != nil {
+		t.Fatalf("NewFromStruct(%v, %+v) unexpected error: %v", ns, rec, err)
+	}
+	if err := u.Validate(); err != nil || u.Version() != 8 {
+		t.Errorf("NewFromStruct() = %v, version %d, Validate() = %v", u, u.Version(), err)
+	}
+	// The UUID must not change across releases.
+	if want := Must(FromString("57fba008-9f71-8005-b40f-c342c1edd919")); u != want {
+		t.Errorf("NewFromStruct(%v, %+v) = %v, want %v", ns, rec, u, want)
+	}
+
+	t.Run("Equal", func(t *testing.T) {
+		note := "note"
+		withNote := rec
+		withNote.Note = &note
+		noted := Must(NewFromStruct(ns, withNote))
+		for name, v := range map[string]interface{}{
+			"Pointer":  &rec,
+			"Skipped":  fromStructRecord{Account: rec.Account, Name: rec.Name, Amount: rec.Amount, Tags: rec.Tags, Labels: rec.Labels, At: rec.At, Skipped: "other", secret: "other"},
+			"TimeZone": fromStructRecord{Account: rec.Account, Name: rec.Name, Amount: rec.Amount, Tags: rec.Tags, Labels: rec.Labels, At: at.In(time.FixedZone("UTC+2", 2*60*60))},
+			"Map": fromStructRecord{Account: rec.Account, Name: rec.Name, Amount: rec.Amount, Tags: rec.Tags, At: rec.At, Labels: map[string]string{
+				"z": "3", "y": "2", "x": "1",
+			}},
+		} {
+			if got, err := NewFromStruct(ns, v); err != nil || got != u {
+				t.Errorf("%s: NewFromStruct() = %v, %v, want %v, <nil>", name, got, err, u)
+			}
+		}
+
+		reordered := fromStructReordered{
+			Name:    rec.Name,
+			Labels:  rec.Labels,
+			Amount:  int32(rec.Amount),
+			Tags:    rec.Tags,
+			Account: rec.Account,
+			At:      rec.At,
+			Note:    note,
+			Renamed: "skipped",
+		}
+		type renamed struct {
+			Account  UUID
+			Name     string
+			Amount   int64
+			Tags     []string
+			Labels   map[string]string
+			At       time.Time
+			Note     *string
+			Skipped2 string
+		}
+		want := Must(NewFromStruct(ns, renamed{
+			rec.Account, rec.Name, rec.Amount, rec.Tags, rec.Labels, rec.At, &note, "skipped",
+		}))
+		if got, err := NewFromStruct(ns, reordered); err != nil || got != want {
+			t.Errorf("NewFromStruct() of reordered struct = %v, %v, want %v, <nil>", got, err, want)
+		}
+		if want == noted {
+			t.Errorf("NewFromStruct() ignored the field Skipped2")
+		}
+	})
+
+	t.Run("Different", func(t *testing.T) {
+		for name, v := range map[string]interface{}{
+			"Name":      fromStructRecord{Account: rec.Account, Name: "invoices", Amount: rec.Amount, Tags: rec.Tags, Labels: rec.Labels, At: rec.At},
+			"Amount":    fromStructRecord{Account: rec.Account, Name: rec.Name, Amount: 43, Tags: rec.Tags, Labels: rec.Labels, At: rec.At},
+			"Tags":      fromStructRecord{Account: rec.Account, Name: rec.Name, Amount: rec.Amount, Tags: []string{"ab"}, Labels: rec.Labels, At: rec.At},
+			"TagsOrder": fromStructRecord{Account: rec.Account, Name: rec.Name, Amount: rec.Amount, Tags: []string{"b", "a"}, Labels: rec.Labels, At: rec.At},
+			"NilTags":   fromStructRecord{Account: rec.Account, Name: rec.Name, Amount: rec.Amount, Labels: rec.Labels, At: rec.At},
+			"Labels":    fromStructRecord{Account: rec.Account, Name: rec.Name, Amount: rec.Amount, Tags: rec.Tags, Labels: map[string]string{"x": "1", "y": "23"}, At: rec.At},
+			"At":        fromStructRecord{Account: rec.Account, Name: rec.Name, Amount: rec.Amount, Tags: rec.Tags, Labels: rec.Labels, At: at.Add(1)},
+			"Empty":     struct{}{},
+		} {
+			if got, err := NewFromStruct(ns, v); err != nil || got == u {
+				t.Errorf("%s: NewFromStruct() = %v, %v, want a different UUID", name, got, err)
+			}
+		}
+		if got := Must(NewFromStruct(NamespaceURL, rec)); got == u {
+			t.Errorf("NewFromStruct returned %v for different namespaces", got)
+		}
+		if a, b := Must(NewFromStruct(ns, "1")), Must(NewFromStruct(ns, 1)); a == b {
+			t.Errorf("NewFromStruct(1) = NewFromStruct(\"1\") = %v", a)
+		}
+		if a, b := Must(NewFromStruct(ns, []byte("ab"))), Must(NewFromStruct(ns, "ab")); a == b {
+			t.Errorf("NewFromStruct([]byte(ab)) = NewFromStruct(ab) = %v", a)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		type cycle struct{ Next *cycle }
+		c := &cycle{}
+		c.Next = c
+		type duplicate struct {
+			A string
+			B string `uuid:"A"`
+		}
+		for name, v := range map[string]interface{}{
+			"Func":      struct{ F func() }{F: func() {}},
+			"Chan":      map[string]chan int{"c": make(chan int)},
+			"Complex":   []complex128{1i},
+			"Cycle":     c,
+			"Duplicate": duplicate{},
+		} {
+			if got, err := NewFromStruct(ns, v); !errors.Is(err, ErrInvalidArgument) || got != Nil {
+				t.Errorf("%s: NewFromStruct() = %v, %v, want Nil, %v", name, got, err, ErrInvalidArgument)
+			}
+		}
+	})
+}
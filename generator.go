@@ -22,15 +22,16 @@
 package uuid
 
 import (
-	"crypto/md5"
 	"crypto/rand"
-	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"hash"
 	"io"
-	"net"
+	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -41,63 +42,151 @@ const epochStart = 122192928000000000
 // EpochFunc is the function type used to provide the current time.
 type EpochFunc func() time.Time
 
-// HWAddrFunc is the function type used to provide hardware (MAC) addresses.
-type HWAddrFunc func() (net.HardwareAddr, error)
-
-// DefaultGenerator is the default UUID Generator used by this package.
+// DefaultGenerator is the default UUID Generator used by this package, for
+// the versions without a generator set with SetVersionGenerator.
 var DefaultGenerator Generator = NewGen()
 
 // NewV1 returns a UUID based on the current timestamp and MAC address.
+//
+// With the uuid_nonet build tag, the MAC address is not looked up, and a
+// random node, with the multicast bit set, is used instead.
 func NewV1() (UUID, error) {
-	return DefaultGenerator.NewV1()
+	return VersionGenerator(V1).NewV1()
 }
 
 // NewV1 returns a UUID based on the provided timestamp and MAC address.
 func NewV1AtTime(atTime time.Time) (UUID, error) {
-	return DefaultGenerator.NewV1AtTime(atTime)
+	return VersionGenerator(V1).NewV1AtTime(atTime)
 }
 
 // NewV3 returns a UUID based on the MD5 hash of the namespace UUID and name.
+// It panics with ErrHashDisabled if the package is built with the uuid_fips
+// build tag.
 func NewV3(ns UUID, name string) UUID {
-	return DefaultGenerator.NewV3(ns, name)
+	return VersionGenerator(V3).NewV3(ns, name)
+}
+
+// NewV3Bytes is like NewV3 but takes the name as a byte slice, such as a
+// serialized key, without converting it to a string. As name-based UUIDs do
+// not depend on the state of a generator, it does not use DefaultGenerator.
+// It panics with ErrHashDisabled if the package is built with the uuid_fips
+// build tag.
+func NewV3Bytes(ns UUID, name []byte) UUID {
+	return newV3(ns, name)
 }
 
 // NewV4 returns a randomly generated UUID.
 func NewV4() (UUID, error) {
-	return DefaultGenerator.NewV4()
+	return VersionGenerator(V4).NewV4()
 }
 
 // NewV5 returns a UUID based on SHA-1 hash of the namespace UUID and name.
+// It panics with ErrHashDisabled if the package is built with the uuid_fips
+// build tag.
 func NewV5(ns UUID, name string) UUID {
-	return DefaultGenerator.NewV5(ns, name)
+	return VersionGenerator(V5).NewV5(ns, name)
+}
+
+// NewV5Bytes is like NewV5 but takes the name as a byte slice, such as a
+// serialized key, without converting it to a string. As name-based UUIDs do
+// not depend on the state of a generator, it does not use DefaultGenerator.
+// It panics with ErrHashDisabled if the package is built with the uuid_fips
+// build tag.
+func NewV5Bytes(ns UUID, name []byte) UUID {
+	return newV5(ns, name)
+}
+
+// NewV5Multi returns a UUID based on the SHA-1 hash of the namespace UUID and
+// a name made of several parts, such as the columns of a composite key. Each
+// part is hashed after its length, so that the parts ("ab", "c") and
+// ("a", "bc") give different UUIDs, unlike a concatenation. NewV5Multi(ns, s)
+// differs from NewV5(ns, s). It panics with ErrHashDisabled if the package is
+// built with the uuid_fips build tag.
+func NewV5Multi(ns UUID, parts ...string) UUID {
+	h := newSHA1()
+	h.Write(ns[:])
+	var n [binary.MaxVarintLen64]byte
+	for _, part := range parts {
+		h.Write(n[:binary.PutUvarint(n[:], uint64(len(part)))])
+		io.WriteString(h, part)
+	}
+
+	u := UUID{}
+	copy(u[:], h.Sum(nil))
+	u.SetVersion(V5)
+	u.SetVariant(VariantRFC9562)
+
+	return u
+}
+
+// NewV5FromReader is like NewV5 but reads the name from r until EOF,
+// streaming it through the hash, so that the UUID of large content can be
+// computed without holding it in memory. It returns the first error of r,
+// and panics with ErrHashDisabled if the package is built with the uuid_fips
+// build tag.
+func NewV5FromReader(ns UUID, r io.Reader) (UUID, error) {
+	u, err := newFromHashReader(newSHA1(), ns, r)
+	if err != nil {
+		return Nil, err
+	}
+	u.SetVersion(V5)
+	u.SetVariant(VariantRFC9562)
+
+	return u, nil
+}
+
+// NewV8SHA256 returns a version 8 UUID based on the SHA-256 hash of the
+// namespace UUID and name, as in the name-based example of RFC-9562 Appendix
+// B.2: the first 128 bits of the hash, with the version and variant set. It
+// is the name-based UUID available with the uuid_fips build tag, as SHA-256
+// is approved by FIPS 140.
+func NewV8SHA256(ns UUID, name string) UUID {
+	u := newFromHash(sha256.New(), ns, []byte(name))
+	u.SetVersion(8)
+	u.SetVariant(VariantRFC9562)
+
+	return u
+}
+
+// NewV8SHA256FromReader is like NewV8SHA256 but reads the name from r until
+// EOF, streaming it through the hash, as NewV5FromReader does.
+func NewV8SHA256FromReader(ns UUID, r io.Reader) (UUID, error) {
+	u, err := newFromHashReader(sha256.New(), ns, r)
+	if err != nil {
+		return Nil, err
+	}
+	u.SetVersion(8)
+	u.SetVariant(VariantRFC9562)
+
+	return u, nil
 }
 
 // NewV6 returns a k-sortable UUID based on the current timestamp and 48 bits of
 // pseudorandom data. The timestamp in a V6 UUID is the same as V1, with the bit
 // order being adjusted to allow the UUID to be k-sortable.
 func NewV6() (UUID, error) {
-	return DefaultGenerator.NewV6()
+	return VersionGenerator(V6).NewV6()
 }
 
 // NewV6 returns a k-sortable UUID based on the provided timestamp and 48 bits of
 // pseudorandom data. The timestamp in a V6 UUID is the same as V1, with the bit
 // order being adjusted to allow the UUID to be k-sortable.
 func NewV6AtTime(atTime time.Time) (UUID, error) {
-	return DefaultGenerator.NewV6AtTime(atTime)
+	return VersionGenerator(V6).NewV6AtTime(atTime)
 }
 
 // NewV7 returns a k-sortable UUID based on the current millisecond-precision
 // UNIX epoch and 74 bits of pseudorandom data. It supports single-node batch
 // generation (multiple UUIDs in the same timestamp) with a Monotonic Random counter.
 func NewV7() (UUID, error) {
-	return DefaultGenerator.NewV7()
+	return VersionGenerator(V7).NewV7()
 }
 
 // NewV7 returns a k-sortable UUID based on the provided millisecond-precision
 // UNIX epoch and 74 bits of pseudorandom data. It supports single-node batch
 // generation (multiple UUIDs in the same timestamp) with a Monotonic Random counter.
 func NewV7AtTime(atTime time.Time) (UUID, error) {
-	return DefaultGenerator.NewV7AtTime(atTime)
+	return VersionGenerator(V7).NewV7AtTime(atTime)
 }
 
 // Generator provides an interface for generating UUIDs.
@@ -133,9 +222,55 @@ type Gen struct {
 
 	epochFunc     EpochFunc
 	hwAddrFunc    HWAddrFunc
-	lastTime      uint64
-	clockSequence uint16
+	hwAddrTimeout time.Duration
 	hardwareAddr  [6]byte
+
+	// lastTime and clockSequence, whose low 14 bits are the clock
+	// sequence, are read and updated without storageMutex while the time
+	// advances. See getClockSequence.
+	lastTime      atomic.Uint64
+	clockSequence atomic.Uint32
+
+	// hasClockSequence reports whether the clock sequence has been
+	// initialized or set, for ExportState.
+	hasClockSequence atomic.Bool
+
+	nodeMutex    sync.Mutex
+	randomNode   bool
+	nodeInterval time.Duration
+	node         [6]byte
+	nodeExpiry   time.Time
+
+	// nodeAllocator, if any, leases the node of V1 and V6 UUIDs, and the
+	// v7NodeBits high bits of the rand_b of V7 UUIDs, for nodeTTL.
+	nodeAllocator NodeAllocator
+	nodeTTL       time.Duration
+	nodeLease     NodeLease
+	hasNodeLease  bool
+	v7NodeBits    int
+
+	v7Granularity time.Duration
+	v7Jitter      time.Duration
+
+	coordinator Coordinator
+
+	clampTimestamps bool
+
+	checkRand bool
+}
+
+// Coordinator reserves the timestamps and counters of the V7 UUIDs generated
+// by MonotonicGen generators, so that the UUIDs of all the generators sharing
+// a Coordinator, possibly in different processes, are strictly monotonic.
+//
+// The timestamp and counter of a UUID are held in a state, as
+// unix_ts_ms<<12 | counter, the counter being the 12 bits of rand_a.
+type Coordinator interface {
+	// Reserve reserves n consecutive states for UUIDs generated at the Unix
+	// time ms, in milliseconds, and returns the first. The first state is
+	// ms<<12, or the state following the last one reserved before if it is
+	// not lower.
+	Reserve(ms, n uint64) (uint64, error)
 }
 
 // GenOption is a function type that can be used to configure a Gen generator.
@@ -190,22 +325,40 @@ func NewGenWithOptions(opts ...GenOption) *Gen {
 	for _, opt := range opts {
 		opt(gen)
 	}
+	if gen.checkRand {
+		if err := VerifyRandSource(gen.rand); err != nil {
+			gen.rand = errReader{err}
+		}
+	}
 
 	return gen
 }
 
 // MonotonicGen extends the Gen struct with a counter for batch generation.
 //
-// MonotonicGen ensures the generation of strictly monotonic UUIDs within a
-// batch by utilizing a counter in conjunction with timestamps. This is
-// particularly useful for applications requiring ordered identifiers, such
-// as database indices or log sequencing.
+// MonotonicGen ensures the generation of strictly monotonic UUIDs, by its
+// NewV7 method and within and across the batches of GenerateBatchV7, by
+// utilizing a counter in conjunction with timestamps. This is particularly
+// useful for applications requiring ordered identifiers, such as database
+// indices or log sequencing.
+//
+// The counter is the 12 bits of rand_a, as described in RFC 9562 section
+// 6.2, Method 1. It restarts from zero when the timestamp advances, and
+// overflows into the timestamp, as does a timestamp lower than the last
+// one generated, for instance when the clock goes back. WithCoordinator
+// extends these guarantees to the UUIDs of other generators, possibly in
+// other processes.
 type MonotonicGen struct {
 	Gen
-	monotonicCounter uint16
-	monotonicMutex   sync.Mutex
+
+	// state holds the timestamp and counter of the last V7 UUID generated,
+	// as unix_ts_ms<<12 | counter, unless the generator has a Coordinator.
+	state atomic.Uint64
 }
 
+// interface check -- build will fail if *MonotonicGen doesn't satisfy Generator
+var _ Generator = (*MonotonicGen)(nil)
+
 // NewMonotonicGen creates a MonotonicGen instance with configurable options.
 //
 // Arguments:
@@ -233,6 +386,18 @@ func WithHWAddrFunc(hwaf HWAddrFunc) GenOption {
 	}
 }
 
+// WithHWAddrTimeout is a GenOption that bounds the time the generator waits
+// for the HWAddrFunc, which the default HWAddrFunc can spend listing the
+// network interfaces, when generating its first V1 UUID. If the HWAddrFunc
+// has not returned after d, the generator uses a random node, as when the
+// HWAddrFunc returns an error, and the result of the HWAddrFunc is ignored. A
+// non-positive d disables the option.
+func WithHWAddrTimeout(d time.Duration) GenOption {
+	return func(gen *Gen) {
+		gen.hwAddrTimeout = d
+	}
+}
+
 // WithEpochFunc is a GenOption that allows you to provide your own EpochFunc
 // function.
 // When this option is nil, time.Now is used.
@@ -259,12 +424,141 @@ func WithRandomReader(reader io.Reader) GenOption {
 	}
 }
 
+// WithRandSourceCheck is a GenOption that makes NewGenWithOptions run
+// VerifyRandSource on the random reader of the generator once, so that a
+// source that is not random, such as a fixture of tests left in a production
+// configuration, is caught before it generates colliding UUIDs. If the check
+// fails, every method of the generator reading random data returns its
+// error. The check consumes a few kilobytes of the reader.
+func WithRandSourceCheck() GenOption {
+	return func(gen *Gen) {
+		gen.checkRand = true
+	}
+}
+
+// WithRandomNode is a GenOption that makes the generator use a fresh random
+// node, with the multicast bit set as recommended by RFC-9562, for every V1
+// UUID, rather than the MAC address or a random node cached for the lifetime
+// of the generator. V1 UUIDs generated by a long-lived process then cannot be
+// linked to each other by their node. The HWAddrFunc is not called.
+//
+// V6 UUIDs always have a random node, and are not affected by this option.
+func WithRandomNode() GenOption {
+	return func(gen *Gen) {
+		gen.randomNode = true
+	}
+}
+
+// WithNodeRotation is a GenOption that makes the generator use a random node,
+// with the multicast bit set as recommended by RFC-9562, for its V1 UUIDs, and
+// replace it with a new random node once it has been used for the interval d,
+// as measured by the EpochFunc. Only the V1 UUIDs generated within an interval
+// can then be linked to each other by their node. The HWAddrFunc is not
+// called. A non-positive interval disables the option.
+//
+// V6 UUIDs always have a random node, and are not affected by this option.
+func WithNodeRotation(d time.Duration) GenOption {
+	return func(gen *Gen) {
+		gen.nodeInterval = d
+	}
+}
+
+// WithNodeAllocator is a GenOption that makes the generator lease a node ID
+// from a for ttl, and use it for the node of its V1 and V6 UUIDs, in place of
+// the hardware address or random node, and, with WithV7NodeBits, for some
+// bits of its V7 UUIDs. The UUIDs of the generators sharing an allocator
+// then differ by their node as long as the IDs fit in the node.
+//
+// The node of the UUIDs is the node ID as a 48-bit big-endian integer, with
+// the multicast bit set, as recommended by RFC-9562 for nodes that are not
+// hardware addresses: distinct node IDs below 2^40 give distinct nodes. The
+// lease is acquired with the first UUID, renewed once half of ttl has
+// passed, as measured by the EpochFunc, and acquired again if it is lost.
+// An error of the allocator is returned by the methods generating UUIDs,
+// unless the current lease has not expired yet. ReleaseNode releases the
+// lease. A non-positive ttl disables the option.
+func WithNodeAllocator(a NodeAllocator, ttl time.Duration) GenOption {
+	return func(gen *Gen) {
+		if ttl <= 0 {
+			a = nil
+		}
+		gen.nodeAllocator = a
+		gen.nodeTTL = ttl
+	}
+}
+
+// WithV7NodeBits is a GenOption that makes the generator replace the high
+// bits of the rand_b field of its V7 UUIDs, after the variant, with the low
+// bits of the node ID leased with WithNodeAllocator, so that the UUIDs of the
+// generators sharing an allocator never collide within a millisecond. bits
+// is at most 32, leaving 30 random bits in rand_b, and has no effect without
+// WithNodeAllocator.
+func WithV7NodeBits(bits int) GenOption {
+	return func(gen *Gen) {
+		if bits < 0 {
+			bits = 0
+		}
+		if bits > 32 {
+			bits = 32
+		}
+		gen.v7NodeBits = bits
+	}
+}
+
+// WithV7TimestampGranularity is a GenOption that truncates the timestamps of
+// V7 UUIDs to a multiple of d since the Unix epoch, so that the UUIDs do not
+// reveal when they were created to millisecond precision. UUIDs of different
+// intervals remain ordered by their timestamps, and UUIDs of the same
+// interval are ordered by their clock sequence, as UUIDs generated within the
+// same millisecond are without this option. Granularities below a
+// millisecond have no effect.
+func WithV7TimestampGranularity(d time.Duration) GenOption {
+	return func(gen *Gen) {
+		gen.v7Granularity = d
+	}
+}
+
+// WithV7TimestampJitter is a GenOption that moves the timestamps of V7 UUIDs
+// back by a random duration of less than max, before any truncation by
+// WithV7TimestampGranularity, so that the creation time of a UUID cannot be
+// inferred from the interval it falls in. UUIDs then remain ordered by
+// creation time only to within max, including those of a MonotonicGen batch.
+// A non-positive max disables the option.
+func WithV7TimestampJitter(max time.Duration) GenOption {
+	return func(gen *Gen) {
+		gen.v7Jitter = max
+	}
+}
+
+// WithTimestampClamping is a GenOption that makes the generator clamp times
+// outside of the range of the timestamps of UUIDs, from October 15, 1582 to
+// the year 5236 for V1 and V6 UUIDs, and from the Unix epoch to the year
+// 10889 for V7 UUIDs, to the nearest timestamp, rather than return
+// ErrTimestampOutOfRange.
+func WithTimestampClamping() GenOption {
+	return func(gen *Gen) {
+		gen.clampTimestamps = true
+	}
+}
+
+// WithCoordinator is a GenOption that makes a MonotonicGen reserve the
+// timestamps and counters of its V7 UUIDs with c, rather than keep them for
+// itself, so that its UUIDs are ordered with those of the other generators
+// using c. Generators other than MonotonicGen are not affected.
+func WithCoordinator(c Coordinator) GenOption {
+	return func(gen *Gen) {
+		gen.coordinator = c
+	}
+}
+
 // NewV1 returns a UUID based on the current timestamp and MAC address.
 func (g *Gen) NewV1() (UUID, error) {
 	return g.NewV1AtTime(g.epochFunc())
 }
 
 // NewV1AtTime returns a UUID based on the provided timestamp and current MAC address.
+// It returns ErrTimestampOutOfRange for a time before October 15, 1582 or
+// after the year 5236, unless the generator clamps timestamps.
 func (g *Gen) NewV1AtTime(atTime time.Time) (UUID, error) {
 	u := UUID{}
 
@@ -277,11 +571,11 @@ func (g *Gen) NewV1AtTime(atTime time.Time) (UUID, error) {
 	binary.BigEndian.PutUint16(u[6:], uint16(timeNow>>48))
 	binary.BigEndian.PutUint16(u[8:], clockSeq)
 
-	hardwareAddr, err := g.getHardwareAddr()
+	node, err := g.getNode()
 	if err != nil {
 		return Nil, err
 	}
-	copy(u[10:], hardwareAddr)
+	copy(u[10:], node)
 
 	u.SetVersion(V1)
 	u.SetVariant(VariantRFC9562)
@@ -290,8 +584,21 @@ func (g *Gen) NewV1AtTime(atTime time.Time) (UUID, error) {
 }
 
 // NewV3 returns a UUID based on the MD5 hash of the namespace UUID and name.
+// It panics with ErrHashDisabled if the package is built with the uuid_fips
+// build tag.
 func (g *Gen) NewV3(ns UUID, name string) UUID {
-	u := newFromHash(md5.New(), ns, name)
+	return newV3(ns, []byte(name))
+}
+
+// NewV3Bytes is like NewV3 but takes the name as a byte slice.
+// It panics with ErrHashDisabled if the package is built with the uuid_fips
+// build tag.
+func (g *Gen) NewV3Bytes(ns UUID, name []byte) UUID {
+	return newV3(ns, name)
+}
+
+func newV3(ns UUID, name []byte) UUID {
+	u := newFromHash(newMD5(), ns, name)
 	u.SetVersion(V3)
 	u.SetVariant(VariantRFC9562)
 
@@ -311,8 +618,21 @@ func (g *Gen) NewV4() (UUID, error) {
 }
 
 // NewV5 returns a UUID based on SHA-1 hash of the namespace UUID and name.
+// It panics with ErrHashDisabled if the package is built with the uuid_fips
+// build tag.
 func (g *Gen) NewV5(ns UUID, name string) UUID {
-	u := newFromHash(sha1.New(), ns, name)
+	return newV5(ns, []byte(name))
+}
+
+// NewV5Bytes is like NewV5 but takes the name as a byte slice.
+// It panics with ErrHashDisabled if the package is built with the uuid_fips
+// build tag.
+func (g *Gen) NewV5Bytes(ns UUID, name []byte) UUID {
+	return newV5(ns, name)
+}
+
+func newV5(ns UUID, name []byte) UUID {
+	u := newFromHash(newSHA1(), ns, name)
 	u.SetVersion(V5)
 	u.SetVariant(VariantRFC9562)
 
@@ -328,7 +648,8 @@ func (g *Gen) NewV6() (UUID, error) {
 
 // NewV6 returns a k-sortable UUID based on the provided timestamp and 48 bits of
 // pseudorandom data. The timestamp in a V6 UUID is the same as V1, with the bit
-// order being adjusted to allow the UUID to be k-sortable.
+// order being adjusted to allow the UUID to be k-sortable. It returns
+// ErrTimestampOutOfRange for the times NewV1AtTime does.
 func (g *Gen) NewV6AtTime(atTime time.Time) (UUID, error) {
 	/* https://datatracker.ietf.org/doc/html/rfc9562#name-uuid-version-6
 	    0                   1                   2                   3
@@ -359,6 +680,13 @@ func (g *Gen) NewV6AtTime(atTime time.Time) (UUID, error) {
 	if _, err = io.ReadFull(g.rand, u[8:]); err != nil {
 		return Nil, err
 	}
+	if g.nodeAllocator != nil {
+		node, err := g.getAllocatedNode()
+		if err != nil {
+			return Nil, err
+		}
+		copy(u[10:], node)
+	}
 
 	u.SetVersion(V6)
 
@@ -375,7 +703,9 @@ func (g *Gen) NewV7() (UUID, error) {
 }
 
 // NewV7 returns a k-sortable UUID based on the provided millisecond-precision
-// UNIX epoch and 74 bits of pseudorandom data.
+// UNIX epoch and 74 bits of pseudorandom data. It returns
+// ErrTimestampOutOfRange for a time before the Unix epoch or after the year
+// 10889, unless the generator clamps timestamps.
 func (g *Gen) NewV7AtTime(atTime time.Time) (UUID, error) {
 	var u UUID
 	/* https://datatracker.ietf.org/doc/html/rfc9562#name-uuid-version-7
@@ -391,6 +721,10 @@ func (g *Gen) NewV7AtTime(atTime time.Time) (UUID, error) {
 	   |                            rand_b                             |
 	   +-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+ */
 
+	atTime, err := g.v7Time(atTime)
+	if err != nil {
+		return Nil, err
+	}
 	ms, clockSeq, err := g.getClockSequence(true, atTime)
 	if err != nil {
 		return Nil, err
@@ -417,6 +751,9 @@ func (g *Gen) NewV7AtTime(atTime time.Time) (UUID, error) {
 	if _, err = io.ReadFull(g.rand, u[8:16]); err != nil {
 		return Nil, err
 	}
+	if err := g.setV7Node(u[8:16]); err != nil {
+		return Nil, err
+	}
 	// override first 2 bits of byte[8] for the variant
 	u.SetVariant(VariantRFC9562)
 
@@ -425,7 +762,8 @@ func (g *Gen) NewV7AtTime(atTime time.Time) (UUID, error) {
 
 // GenerateBatchV7 creates a batch of k-sortable Version 7 UUIDs.
 //
-// Ensures strict monotonic ordering within the batch.
+// Ensures strict monotonic ordering within the batch. The random bits of
+// the whole batch are read from the generator's random source at once.
 //
 // Arguments:
 // - batchSize: Number of UUIDs to generate.
@@ -439,10 +777,23 @@ func (g *MonotonicGen) GenerateBatchV7(batchSize int) ([]UUID, error) {
 		return nil, errors.New("batch size must be greater than zero")
 	}
 
+	n := g.v7EntropySize()
+	entropy := make([]byte, batchSize*n)
+	if _, err := io.ReadFull(g.rand, entropy); err != nil {
+		return nil, err
+	}
+	nodeID, err := g.v7NodeID()
+	if err != nil {
+		return nil, err
+	}
+	for i := n; i <= len(entropy); i += n {
+		setV7NodeBits(entropy[i-8:i], nodeID, g.v7NodeBits)
+	}
+
 	uuids := make([]UUID, batchSize)
 
-	for i := 0; i < batchSize; i++ {
-		uuid, err := g.newMonotonicV7()
+	for i := range uuids {
+		uuid, err := g.newMonotonicV7FromEntropy(entropy[i*n : (i+1)*n])
 		if err != nil {
 			return nil, err
 		}
@@ -451,20 +802,149 @@ func (g *MonotonicGen) GenerateBatchV7(batchSize int) ([]UUID, error) {
 	return uuids, nil
 }
 
+// parallelBlockSize is the number of UUIDs whose random bits a worker of
+// GenerateBatchV7Parallel reads at once.
+const parallelBlockSize = 4096
+
+// GenerateBatchV7Parallel creates a batch of k-sortable Version 7 UUIDs, like
+// GenerateBatchV7, with workers goroutines.
+//
+// The timestamps and counters of the whole batch are reserved at once, and
+// each worker fills a contiguous part of the batch, so that the batch is
+// sorted and ordered with the other UUIDs generated by g. As the counter
+// overflows into the timestamp every 4096 UUIDs, the timestamps of a large
+// batch run ahead of the clock, by a millisecond per 4096 UUIDs.
+//
+// The random source of the generator is read by one worker at a time, unless
+// it is crypto/rand.Reader, which is safe for concurrent use.
+//
+// Arguments:
+// - n: Number of UUIDs to generate.
+// - workers: Number of goroutines generating them, GOMAXPROCS if not positive.
+//
+// Returns:
+// - []UUID: The generated UUIDs.
+// - error: If batch generation fails.
+func (g *MonotonicGen) GenerateBatchV7Parallel(n, workers int) ([]UUID, error) {
+	if n <= 0 {
+		return nil, errors.New("batch size must be greater than zero")
+	}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		workers = n
+	}
+
+	atTime, err := g.v7Time(g.epochFunc())
+	if err != nil {
+		return nil, err
+	}
+	nodeID, err := g.v7NodeID()
+	if err != nil {
+		return nil, err
+	}
+	first, err := g.reserveMonotonicV7(atTime, uint64(n))
+	if err != nil {
+		return nil, err
+	}
+
+	uuids := make([]UUID, n)
+
+	var (
+		wg       sync.WaitGroup
+		randMu   sync.Mutex
+		errOnce  sync.Once
+		firstErr error
+	)
+	lockRand := g.rand != rand.Reader
+	part := (n + workers - 1) / workers
+	for lo := 0; lo < n; lo += part {
+		hi := lo + part
+		if hi > n {
+			hi = n
+		}
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			var randB [parallelBlockSize * 8]byte
+			for i := lo; i < hi; i += parallelBlockSize {
+				block := uuids[i:hi]
+				if len(block) > parallelBlockSize {
+					block = block[:parallelBlockSize]
+				}
+				if lockRand {
+					randMu.Lock()
+				}
+				_, err := io.ReadFull(g.rand, randB[:len(block)*8])
+				if lockRand {
+					randMu.Unlock()
+				}
+				if err != nil {
+					errOnce.Do(func() { firstErr = err })
+					return
+				}
+				for j := range block {
+					setV7NodeBits(randB[j*8:(j+1)*8], nodeID, g.v7NodeBits)
+					block[j] = monotonicV7(first+uint64(i+j), randB[j*8:(j+1)*8])
+				}
+			}
+		}(lo, hi)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return uuids, nil
+}
+
+// NewV7 returns a k-sortable UUID based on the current millisecond-precision
+// UNIX epoch, a counter and 62 bits of pseudorandom data, greater than all
+// the Version 7 UUIDs previously generated by g. It returns
+// ErrTimestampOutOfRange for the times NewV7AtTime does, unless the generator
+// clamps timestamps.
+//
+// NewV7AtTime, which generates UUIDs for the time provided, is not affected
+// and behaves as with Gen.
+func (g *MonotonicGen) NewV7() (UUID, error) {
+	return g.newMonotonicV7()
+}
+
 // newMonotonicV7 generates a Version 7 UUID with a monotonic counter for ordering.
 //
 // Returns:
 // - UUID: The generated UUID.
 // - error: If UUID generation fails.
 func (g *MonotonicGen) newMonotonicV7() (UUID, error) {
-	var u UUID
+	entropy := make([]byte, g.v7EntropySize())
+	if _, err := io.ReadFull(g.rand, entropy); err != nil {
+		return Nil, err
+	}
+	if err := g.setV7Node(entropy[len(entropy)-8:]); err != nil {
+		return Nil, err
+	}
+	return g.newMonotonicV7FromEntropy(entropy)
+}
 
-	ms, clockSeq, err := g.getMonotonicClockSequence(true, g.epochFunc())
+// newMonotonicV7FromEntropy generates a Version 7 UUID with a monotonic
+// counter for ordering, taking its random bits from entropy, which holds
+// v7EntropySize bytes.
+func (g *MonotonicGen) newMonotonicV7FromEntropy(entropy []byte) (UUID, error) {
+	atTime := g.v7TimeWithJitter(g.epochFunc(), entropy[:len(entropy)-8])
+	state, err := g.reserveMonotonicV7(atTime, 1)
 	if err != nil {
 		return Nil, err
 	}
+	return monotonicV7(state, entropy[len(entropy)-8:]), nil
+}
+
+// monotonicV7 returns the Version 7 UUID with the timestamp and counter held
+// by state, as in MonotonicGen, and the 8 bytes of randB as rand_b.
+func monotonicV7(state uint64, randB []byte) UUID {
+	var u UUID
 
 	// set the timestamp (48 bits)
+	ms := state >> 12
 	u[0] = byte(ms >> 40)
 	u[1] = byte(ms >> 32)
 	u[2] = byte(ms >> 24)
@@ -472,19 +952,93 @@ func (g *MonotonicGen) newMonotonicV7() (UUID, error) {
 	u[4] = byte(ms >> 8)
 	u[5] = byte(ms)
 
-	// set rand_a (clockSeq ensures monotonicity)
-	binary.BigEndian.PutUint16(u[6:8], clockSeq)
+	// set rand_a (the counter ensures monotonicity)
+	binary.BigEndian.PutUint16(u[6:8], uint16(state&0xfff))
 
 	// override version and variant bits
 	u.SetVersion(V7)
 
 	// set rand_b (64 random bits)
-	if _, err := io.ReadFull(g.rand, u[8:16]); err != nil {
-		return Nil, err
-	}
+	copy(u[8:16], randB)
 	u.SetVariant(VariantRFC9562)
 
-	return u, nil
+	return u
+}
+
+// v7EntropySize returns the number of random bytes used to generate a
+// monotonic V7 UUID: 8 for the jitter, if any, followed by 8 for rand_b.
+func (g *Gen) v7EntropySize() int {
+	if g.v7Jitter > 0 {
+		return 16
+	}
+	return 8
+}
+
+// v7Time returns the time of a V7 UUID generated at atTime, with the jitter
+// and granularity options of the generator applied.
+func (g *Gen) v7Time(atTime time.Time) (time.Time, error) {
+	var buf [8]byte
+	if g.v7Jitter > 0 {
+		if _, err := io.ReadFull(g.rand, buf[:]); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return g.v7TimeWithJitter(atTime, buf[:]), nil
+}
+
+// v7TimeWithJitter is v7Time taking the random jitter from the 8 bytes of
+// jitter, which are ignored if the generator has no jitter.
+func (g *Gen) v7TimeWithJitter(atTime time.Time, jitter []byte) time.Time {
+	if g.v7Jitter > 0 {
+		atTime = atTime.Add(-time.Duration(binary.BigEndian.Uint64(jitter) % uint64(g.v7Jitter)))
+	}
+	if granularity := g.v7Granularity.Milliseconds(); granularity > 1 {
+		ms := atTime.UnixMilli()
+		r := ms % granularity
+		if r < 0 {
+			r += granularity
+		}
+		atTime = time.UnixMilli(ms - r)
+	}
+	return atTime
+}
+
+// clockSequenceMask masks the 14 bits of the clock sequence.
+const clockSequenceMask = 0x3fff
+
+// ClockSequence returns the 14-bit clock sequence of g, used for the V1
+// UUIDs it generates. The clock sequence is initialized randomly when first
+// needed, unless set with SetClockSequence, and incremented, wrapping around
+// within 14 bits, when the clock does not advance between two UUIDs.
+func (g *Gen) ClockSequence() (uint16, error) {
+	if err := g.initClockSequence(); err != nil {
+		return 0, err
+	}
+	return uint16(g.clockSequence.Load()) & clockSequenceMask, nil
+}
+
+// SetClockSequence sets the clock sequence of g to the low 14 bits of seq,
+// for instance to a value persisted across restarts, or assigned to each of
+// the processes sharing a node, as described in RFC-9562 section 6.3.
+func (g *Gen) SetClockSequence(seq uint16) {
+	g.clockSequenceOnce.Do(func() {})
+	g.clockSequence.Store(uint32(seq & clockSequenceMask))
+	g.hasClockSequence.Store(true)
+}
+
+// initClockSequence initializes the clock sequence of g randomly, unless it
+// has already been initialized or set.
+func (g *Gen) initClockSequence() error {
+	var err error
+	g.clockSequenceOnce.Do(func() {
+		buf := make([]byte, 2)
+		if _, err = io.ReadFull(g.rand, buf); err != nil {
+			return
+		}
+		g.clockSequence.Store(uint32(binary.BigEndian.Uint16(buf) & clockSequenceMask))
+		g.hasClockSequence.Store(true)
+	})
+	return err
 }
 
 // getClockSequence returns the epoch and clock sequence of the provided time,
@@ -493,78 +1047,232 @@ func (g *MonotonicGen) newMonotonicV7() (UUID, error) {
 // When useUnixTSMs is false, it uses the Coordinated Universal Time (UTC) as a count of
 // 100-nanosecond intervals since 00:00:00.00, 15 October 1582 (the date of Gregorian
 // reform to the Christian calendar).
+//
+// While the time advances, the common case, the time is recorded with a
+// compare-and-swap, and the current clock sequence returned, without taking
+// storageMutex, as long as the clock sequence did not change during the swap.
+// Otherwise the clock sequence is incremented under the mutex. Every
+// timestamp recorded by a successful swap is greater than the last time
+// observed, and the clock sequence returned with a timestamp lower than or
+// equal to it has been incremented since, so that no two calls return the
+// same timestamp and clock sequence until the clock sequence wraps.
 func (g *Gen) getClockSequence(useUnixTSMs bool, atTime time.Time) (uint64, uint16, error) {
+	if err := g.initClockSequence(); err != nil {
+		return 0, 0, err
+	}
+
+	var timeNow uint64
 	var err error
-	g.clockSequenceOnce.Do(func() {
-		buf := make([]byte, 2)
-		if _, err = io.ReadFull(g.rand, buf); err != nil {
-			return
-		}
-		g.clockSequence = binary.BigEndian.Uint16(buf)
-	})
+	if useUnixTSMs {
+		timeNow, err = g.getUnixTSMs(atTime)
+	} else {
+		timeNow, err = g.getEpoch(atTime)
+	}
 	if err != nil {
 		return 0, 0, err
 	}
 
+	// Fast path: the clock advanced since the last UUID generation.
+	lastTime := g.lastTime.Load()
+	clockSeq := g.clockSequence.Load()
+	if timeNow > lastTime && g.lastTime.CompareAndSwap(lastTime, timeNow) {
+		// The swap also succeeds if lastTime was set back to the value
+		// loaded since, after UUIDs were returned with the clock sequence
+		// loaded. Setting lastTime back increments the clock sequence first,
+		// so the clock sequence loaded is only valid if it did not change.
+		if g.clockSequence.Load() == clockSeq {
+			return timeNow, uint16(clockSeq) & clockSequenceMask, nil
+		}
+	}
+
 	g.storageMutex.Lock()
 	defer g.storageMutex.Unlock()
+	for {
+		lastTime = g.lastTime.Load()
+		if timeNow > lastTime {
+			clockSeq = g.clockSequence.Load()
+		} else {
+			// Clock didn't change since last UUID generation.
+			// Should increase clock sequence.
+			clockSeq = g.clockSequence.Add(1)
+		}
+		if g.lastTime.CompareAndSwap(lastTime, timeNow) {
+			return timeNow, uint16(clockSeq) & clockSequenceMask, nil
+		}
+	}
+}
 
-	var timeNow uint64
-	if useUnixTSMs {
-		timeNow = uint64(atTime.UnixMilli())
-	} else {
-		timeNow = g.getEpoch(atTime)
+// reserveMonotonicV7 reserves the timestamps and counters of n monotonic V7
+// UUIDs generated at atTime, with the Coordinator of the generator if any, and
+// returns the first of them as held by MonotonicGen.state. It returns
+// ErrTimestampOutOfRange for the times NewV7AtTime does.
+func (g *MonotonicGen) reserveMonotonicV7(atTime time.Time, n uint64) (uint64, error) {
+	ms, err := g.getUnixTSMs(atTime)
+	if err != nil {
+		return 0, err
 	}
-	// Clock didn't change since last UUID generation.
-	// Should increase clock sequence.
-	if timeNow <= g.lastTime {
-		g.clockSequence++
+	if g.coordinator != nil {
+		return g.coordinator.Reserve(ms, n)
 	}
-	g.lastTime = timeNow
+	next := ms << 12
+	for {
+		last := g.state.Load()
+		first := next
+		if first <= last {
+			first = last + 1
+		}
+		if g.state.CompareAndSwap(last, first+n-1) {
+			return first, nil
+		}
+	}
+}
 
-	return timeNow, g.clockSequence, nil
+// getNode returns the node of a V1 UUID: the node leased from the
+// NodeAllocator, a fresh random node, the current rotating random node, or
+// the hardware address, depending on the options of the generator.
+func (g *Gen) getNode() ([]byte, error) {
+	switch {
+	case g.nodeAllocator != nil:
+		return g.getAllocatedNode()
+	case g.randomNode:
+		var node [6]byte
+		if err := g.readRandomNode(&node); err != nil {
+			return nil, err
+		}
+		return node[:], nil
+	case g.nodeInterval > 0:
+		return g.getRotatingNode()
+	}
+	return g.getHardwareAddr()
 }
 
-// getMonotonicClockSequence returns a timestamp and clock sequence to ensure
-// monotonic UUID generation, even when timestamps are identical.
-//
-// Arguments:
-// - useUnixTSMs: Whether to use millisecond precision for the timestamp.
-// - atTime: The reference time.
-//
-// Returns:
-// - uint64: The timestamp.
-// - uint16: The clock sequence.
-// - error: If the sequence generation fails.
-func (g *MonotonicGen) getMonotonicClockSequence(useUnixTSMs bool, atTime time.Time) (uint64, uint16, error) {
-	g.monotonicMutex.Lock()
-	defer g.monotonicMutex.Unlock()
+// getRotatingNode returns the current random node, replacing it if it has
+// expired.
+func (g *Gen) getRotatingNode() ([]byte, error) {
+	g.nodeMutex.Lock()
+	defer g.nodeMutex.Unlock()
 
-	var timeNow uint64
-	if useUnixTSMs {
-		timeNow = uint64(atTime.UnixMilli())
-	} else {
-		timeNow = g.getEpoch(atTime)
+	now := g.epochFunc()
+	if g.nodeExpiry.IsZero() || !now.Before(g.nodeExpiry) {
+		var node [6]byte
+		if err := g.readRandomNode(&node); err != nil {
+			return nil, err
+		}
+		g.node = node
+		g.nodeExpiry = now.Add(g.nodeInterval)
 	}
+	node := g.node
+	return node[:], nil
+}
 
-	// If timeNow <= lastTime, increment the counter to ensure monotonicity.
-	if timeNow <= g.lastTime {
-		g.monotonicCounter++
-	} else {
-		g.monotonicCounter = 0
+// getAllocatedNode returns the node of the ID leased from the NodeAllocator
+// of the generator, acquiring or renewing the lease if needed.
+func (g *Gen) getAllocatedNode() ([]byte, error) {
+	lease, err := g.leaseNode()
+	if err != nil {
+		return nil, err
+	}
+	var node [8]byte
+	binary.BigEndian.PutUint64(node[:], lease.ID)
+	node[2] |= 0x01
+	return node[2:], nil
+}
+
+// leaseNode returns the current node ID lease of the generator, acquiring a
+// lease if it has none or lost it, and renewing it once half of its TTL has
+// passed.
+func (g *Gen) leaseNode() (NodeLease, error) {
+	g.nodeMutex.Lock()
+	defer g.nodeMutex.Unlock()
+
+	now := g.epochFunc()
+	if g.hasNodeLease {
+		if now.Before(g.nodeLease.Expiry.Add(-g.nodeTTL / 2)) {
+			return g.nodeLease, nil
+		}
+		lease, err := g.nodeAllocator.Renew(g.nodeLease, g.nodeTTL)
+		switch {
+		case err == nil:
+			g.nodeLease = lease
+			return lease, nil
+		case !errors.Is(err, ErrNodeLeaseLost) && now.Before(g.nodeLease.Expiry):
+			// Renew again with the next UUID.
+			return g.nodeLease, nil
+		}
+		g.hasNodeLease = false
+	}
+	lease, err := g.nodeAllocator.Acquire(g.nodeTTL)
+	if err != nil {
+		return NodeLease{}, err
+	}
+	g.nodeLease, g.hasNodeLease = lease, true
+	return lease, nil
+}
+
+// ReleaseNode releases the node ID leased with WithNodeAllocator, if any,
+// for instance when the process shuts down. A later UUID acquires a new
+// lease.
+func (g *Gen) ReleaseNode() error {
+	g.nodeMutex.Lock()
+	defer g.nodeMutex.Unlock()
+
+	if !g.hasNodeLease {
+		return nil
+	}
+	g.hasNodeLease = false
+	return g.nodeAllocator.Release(g.nodeLease)
+}
+
+// v7NodeID returns the node ID to set in the V7 UUIDs of the generator, or
+// zero if it does not set node bits.
+func (g *Gen) v7NodeID() (uint64, error) {
+	if g.nodeAllocator == nil || g.v7NodeBits == 0 {
+		return 0, nil
 	}
+	lease, err := g.leaseNode()
+	return lease.ID, err
+}
 
-	g.lastTime = timeNow
+// setV7Node sets the node bits of randB, the rand_b of a V7 UUID, if the
+// generator sets node bits.
+func (g *Gen) setV7Node(randB []byte) error {
+	id, err := g.v7NodeID()
+	if err != nil {
+		return err
+	}
+	setV7NodeBits(randB, id, g.v7NodeBits)
+	return nil
+}
 
-	return timeNow, g.monotonicCounter, nil
+// setV7NodeBits replaces the bits of randB, the 8 bytes of the rand_b of a
+// V7 UUID, following the 2 bits of the variant with the low bits of id. It
+// has no effect if bits is zero.
+func setV7NodeBits(randB []byte, id uint64, bits int) {
+	if bits == 0 {
+		return
+	}
+	shift := 62 - bits
+	mask := uint64(1)<<bits - 1
+	v := binary.BigEndian.Uint64(randB)
+	v = v&^(mask<<shift) | (id&mask)<<shift
+	binary.BigEndian.PutUint64(randB, v)
+}
+
+// readRandomNode reads a random node with the multicast bit set into node.
+func (g *Gen) readRandomNode(node *[6]byte) error {
+	if _, err := io.ReadFull(g.rand, node[:]); err != nil {
+		return err
+	}
+	node[0] |= 0x01
+	return nil
 }
 
 // Returns the hardware address.
 func (g *Gen) getHardwareAddr() ([]byte, error) {
 	var err error
 	g.hardwareAddrOnce.Do(func() {
-		var hwAddr net.HardwareAddr
-		if hwAddr, err = g.hwAddrFunc(); err == nil {
+		var hwAddr []byte
+		if hwAddr, err = g.lookupHardwareAddr(); err == nil {
 			copy(g.hardwareAddr[:], hwAddr)
 			return
 		}
@@ -583,34 +1291,96 @@ func (g *Gen) getHardwareAddr() ([]byte, error) {
 	return g.hardwareAddr[:], nil
 }
 
+// lookupHardwareAddr calls the HWAddrFunc of the generator, giving up after
+// its timeout, if any.
+func (g *Gen) lookupHardwareAddr() ([]byte, error) {
+	if g.hwAddrTimeout <= 0 {
+		return g.hwAddrFunc()
+	}
+
+	type result struct {
+		hwAddr []byte
+		err    error
+	}
+	// The channel is buffered so that the goroutine can exit after a
+	// timeout.
+	c := make(chan result, 1)
+	go func() {
+		hwAddr, err := g.hwAddrFunc()
+		c <- result{hwAddr, err}
+	}()
+
+	timer := time.NewTimer(g.hwAddrTimeout)
+	defer timer.Stop()
+	select {
+	case r := <-c:
+		return r.hwAddr, r.err
+	case <-timer.C:
+		return nil, fmt.Errorf("%w: lookup timed out after %v", ErrNoHwAddressFound, g.hwAddrTimeout)
+	}
+}
+
+// maxEpochTimestamp is the greatest timestamp of V1 and V6 UUIDs, in
+// 100-nanosecond intervals since the UUID epoch (October 15, 1582). That of
+// V7 UUIDs is maxV7Millis.
+const maxEpochTimestamp = 1<<60 - 1
+
 // Returns the difference between UUID epoch (October 15, 1582)
 // and the provided time in 100-nanosecond intervals.
-func (g *Gen) getEpoch(atTime time.Time) uint64 {
-	return epochStart + uint64(atTime.UnixNano()/100)
+func (g *Gen) getEpoch(atTime time.Time) (uint64, error) {
+	// atTime.UnixNano overflows outside of the years 1678 to 2262.
+	secs := atTime.Unix() + epochStart/_100nsPerSecond
+	switch {
+	case secs < 0:
+		return g.outOfRange(atTime, 0)
+	case secs > maxEpochTimestamp/_100nsPerSecond:
+		return g.outOfRange(atTime, maxEpochTimestamp)
+	}
+	ts := uint64(secs)*_100nsPerSecond + uint64(atTime.Nanosecond()/100)
+	if ts > maxEpochTimestamp {
+		return g.outOfRange(atTime, maxEpochTimestamp)
+	}
+	return ts, nil
+}
+
+// getUnixTSMs returns the provided time in milliseconds since the Unix
+// epoch.
+func (g *Gen) getUnixTSMs(atTime time.Time) (uint64, error) {
+	ms := v7Millis(atTime)
+	if int64(ms) != atTime.UnixMilli() {
+		return g.outOfRange(atTime, ms)
+	}
+	return ms, nil
+}
+
+// outOfRange returns clamped, the nearest timestamp to the out of range time
+// atTime, if the generator clamps timestamps, or an error.
+func (g *Gen) outOfRange(atTime time.Time, clamped uint64) (uint64, error) {
+	if g.clampTimestamps {
+		return clamped, nil
+	}
+	return 0, fmt.Errorf("%w: %s", ErrTimestampOutOfRange, atTime.UTC().Format(time.RFC3339Nano))
 }
 
 // Returns the UUID based on the hashing of the namespace UUID and name.
-func newFromHash(h hash.Hash, ns UUID, name string) UUID {
+func newFromHash(h hash.Hash, ns UUID, name []byte) UUID {
 	u := UUID{}
 	h.Write(ns[:])
-	h.Write([]byte(name))
+	h.Write(name)
 	copy(u[:], h.Sum(nil))
 
 	return u
 }
 
-var netInterfaces = net.Interfaces
-
-// Returns the hardware address.
-func defaultHWAddrFunc() (net.HardwareAddr, error) {
-	ifaces, err := netInterfaces()
-	if err != nil {
-		return []byte{}, err
-	}
-	for _, iface := range ifaces {
-		if len(iface.HardwareAddr) >= 6 {
-			return iface.HardwareAddr, nil
-		}
+// Returns the UUID based on the hashing of the namespace UUID and the name
+// read from r.
+func newFromHashReader(h hash.Hash, ns UUID, r io.Reader) (UUID, error) {
+	u := UUID{}
+	h.Write(ns[:])
+	if _, err := io.Copy(h, r); err != nil {
+		return Nil, err
 	}
-	return []byte{}, ErrNoHwAddressFound
+	copy(u[:], h.Sum(nil))
+
+	return u, nil
 }
@@ -27,12 +27,24 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
 
+// skipFIPS skips a test of the MD5 or SHA-1 name-based UUIDs, which panic
+// with the uuid_fips build tag.
+func skipFIPS(t testing.TB) {
+	t.Helper()
+	if FIPS {
+		t.Skip("MD5 and SHA-1 are disabled by the uuid_fips build tag")
+	}
+}
+
 func TestGenerator(t *testing.T) {
 	t.Run("NewV1", testNewV1)
 	t.Run("NewV3", testNewV3)
@@ -47,6 +59,7 @@ func testNewV1(t *testing.T) {
 	t.Run("BasicWithOptions", testNewV1BasicWithOptions)
 	t.Run("DifferentAcrossCalls", testNewV1DifferentAcrossCalls)
 	t.Run("StaleEpoch", testNewV1StaleEpoch)
+	t.Run("Concurrent", testNewV1Concurrent)
 	t.Run("FaultyRand", testNewV1FaultyRand)
 	t.Run("FaultyRandWithOptions", testNewV1FaultyRandWithOptions)
 	t.Run("MissingNetwork", testNewV1MissingNetwork)
@@ -54,12 +67,38 @@ func testNewV1(t *testing.T) {
 	t.Run("MissingNetworkFaultyRand", testNewV1MissingNetworkFaultyRand)
 	t.Run("MissingNetworkFaultyRandWithOptions", testNewV1MissingNetworkFaultyRandWithOptions)
 	t.Run("AtSpecificTime", testNewV1AtTime)
+	t.Run("RandomNode", testNewV1RandomNode)
+	t.Run("NodeRotation", testNewV1NodeRotation)
+	t.Run("HWAddrTimeout", testNewV1HWAddrTimeout)
+}
+
+func TestNewV8SHA256(t *testing.T) {
+	// RFC-9562 Appendix B.2.
+	want := Must(FromString("5c146b14-3c52-8afd-938a-375d0df1fbf6"))
+	if got := NewV8SHA256(NamespaceDNS, "www.example.com"); got != want {
+		t.Errorf("NewV8SHA256(NamespaceDNS, www.example.com) = %v, want %v", got, want)
+	}
+	if got := NewV8SHA256(NamespaceURL, "www.example.com"); got == want {
+		t.Errorf("NewV8SHA256 returned %v for different namespaces", got)
+	}
+	if got := NewV8SHA256(NamespaceDNS, "example.com"); got == want {
+		t.Errorf("NewV8SHA256 returned %v for different names", got)
+	}
+
+	r := io.MultiReader(strings.NewReader("www."), strings.NewReader("example.com"))
+	if got, err := NewV8SHA256FromReader(NamespaceDNS, r); err != nil || got != want {
+		t.Errorf("NewV8SHA256FromReader(NamespaceDNS, www.example.com) = %v, %v, want %v, <nil>", got, err, want)
+	}
+	r = io.MultiReader(strings.NewReader("www."), &faultyReader{readToFail: 0})
+	if got, err := NewV8SHA256FromReader(NamespaceDNS, r); err == nil || got != Nil {
+		t.Errorf("NewV8SHA256FromReader(NamespaceDNS) of faulty reader = %v, %v, want Nil, error", got, err)
+	}
 }
 
 func TestNewGenWithHWAF(t *testing.T) {
 	addr := []byte{0, 1, 2, 3, 4, 42}
 
-	fn := func() (net.HardwareAddr, error) {
+	fn := func() (HWAddr, error) {
 		return addr, nil
 	}
 
@@ -151,6 +190,57 @@ func testNewV1StaleEpoch(t *testing.T) {
 	}
 }
 
+// testNewV1Concurrent checks that concurrent calls, whose times advance,
+// repeat and go back, including with NewV1AtTime, never return the same
+// timestamp and clock sequence.
+func testNewV1Concurrent(t *testing.T) {
+	const goroutines, calls = 8, 1000
+	var tick atomic.Int64
+	g := NewGenWithOptions(
+		WithEpochFunc(func() time.Time {
+			// Advance by a tick on most calls, and go back 3 ticks on
+			// every 8th.
+			n := tick.Add(1)
+			if n%8 == 0 {
+				n -= 3
+			}
+			return time.Unix(0, 100*(n/2))
+		}),
+		WithHWAddrFunc(func() (HWAddr, error) {
+			return net.HardwareAddr{0, 1, 2, 3, 4, 5}, nil
+		}),
+	)
+	results := make([][]UUID, goroutines)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < calls; j++ {
+				u, err := g.NewV1()
+				if j%5 == 4 {
+					u, err = g.NewV1AtTime(time.Unix(0, 100*(tick.Load()/2-2)))
+				}
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				results[i] = append(results[i], u)
+			}
+		}(i)
+	}
+	wg.Wait()
+	seen := make(map[UUID]bool, goroutines*calls)
+	for _, us := range results {
+		for _, u := range us {
+			if seen[u] {
+				t.Fatalf("generated %v twice", u)
+			}
+			seen[u] = true
+		}
+	}
+}
+
 func testNewV1FaultyRand(t *testing.T) {
 	g := &Gen{
 		epochFunc:  time.Now,
@@ -171,7 +261,7 @@ func testNewV1FaultyRand(t *testing.T) {
 func testNewV1MissingNetwork(t *testing.T) {
 	g := &Gen{
 		epochFunc: time.Now,
-		hwAddrFunc: func() (net.HardwareAddr, error) {
+		hwAddrFunc: func() (HWAddr, error) {
 			return []byte{}, fmt.Errorf("uuid: no hw address found")
 		},
 		rand: rand.Reader,
@@ -184,7 +274,7 @@ func testNewV1MissingNetwork(t *testing.T) {
 
 func testNewV1MissingNetworkWithOptions(t *testing.T) {
 	g := NewGenWithOptions(
-		WithHWAddrFunc(func() (net.HardwareAddr, error) {
+		WithHWAddrFunc(func() (HWAddr, error) {
 			return []byte{}, fmt.Errorf("uuid: no hw address found")
 		}),
 	)
@@ -197,7 +287,7 @@ func testNewV1MissingNetworkWithOptions(t *testing.T) {
 func testNewV1MissingNetworkFaultyRand(t *testing.T) {
 	g := &Gen{
 		epochFunc: time.Now,
-		hwAddrFunc: func() (net.HardwareAddr, error) {
+		hwAddrFunc: func() (HWAddr, error) {
 			return []byte{}, fmt.Errorf("uuid: no hw address found")
 		},
 		rand: &faultyReader{
@@ -212,7 +302,7 @@ func testNewV1MissingNetworkFaultyRand(t *testing.T) {
 
 func testNewV1MissingNetworkFaultyRandWithOptions(t *testing.T) {
 	g := NewGenWithOptions(
-		WithHWAddrFunc(func() (net.HardwareAddr, error) {
+		WithHWAddrFunc(func() (HWAddr, error) {
 			return []byte{}, fmt.Errorf("uuid: no hw address found")
 		}),
 		WithRandomReader(&faultyReader{
@@ -273,6 +363,118 @@ func testNewV1AtTime(t *testing.T) {
 	}
 }
 
+func testNewV1RandomNode(t *testing.T) {
+	hwAddr := net.HardwareAddr{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}
+	g := NewGenWithOptions(
+		WithHWAddrFunc(func() (HWAddr, error) { return hwAddr, nil }),
+		WithRandomNode(),
+	)
+	seen := make(map[[6]byte]bool)
+	for i := 0; i < 100; i++ {
+		u, err := g.NewV1()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var node [6]byte
+		copy(node[:], u[10:])
+		if node[0]&0x01 == 0 {
+			t.Fatalf("node %x of %v does not have the multicast bit set", node, u)
+		}
+		if bytes.Equal(node[:], hwAddr) || seen[node] {
+			t.Fatalf("node %x of %v was used before", node, u)
+		}
+		seen[node] = true
+	}
+
+	g = NewGenWithOptions(WithRandomNode(), WithRandomReader(&faultyReader{readToFail: 1}))
+	if u, err := g.NewV1(); err == nil {
+		t.Errorf("did not error on faulty reader with random nodes, got %v", u)
+	}
+}
+
+func testNewV1NodeRotation(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := NewGenWithOptions(
+		WithHWAddrFunc(func() (HWAddr, error) {
+			t.Error("HWAddrFunc called with node rotation")
+			return nil, ErrNoHwAddressFound
+		}),
+		WithEpochFunc(func() time.Time { return now }),
+		WithNodeRotation(time.Hour),
+	)
+	node := func() [6]byte {
+		t.Helper()
+		u, err := g.NewV1()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var n [6]byte
+		copy(n[:], u[10:])
+		if n[0]&0x01 == 0 {
+			t.Fatalf("node %x of %v does not have the multicast bit set", n, u)
+		}
+		return n
+	}
+
+	first := node()
+	now = now.Add(59 * time.Minute)
+	if got := node(); got != first {
+		t.Errorf("node rotated to %x within the interval, want %x", got, first)
+	}
+	now = now.Add(time.Minute)
+	second := node()
+	if second == first {
+		t.Errorf("node %x did not rotate after the interval", first)
+	}
+	now = now.Add(30 * time.Minute)
+	if got := node(); got != second {
+		t.Errorf("node rotated to %x within the interval, want %x", got, second)
+	}
+}
+
+func testNewV1HWAddrTimeout(t *testing.T) {
+	hwAddr := net.HardwareAddr{0x02, 1, 2, 3, 4, 5}
+	g := NewGenWithOptions(
+		WithHWAddrFunc(func() (HWAddr, error) { return hwAddr, nil }),
+		WithHWAddrTimeout(time.Minute),
+	)
+	u, err := g.NewV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := net.HardwareAddr(u[10:]); !bytes.Equal(got, hwAddr) {
+		t.Errorf("node = %v, want %v", got, hwAddr)
+	}
+
+	block := make(chan struct{})
+	defer close(block)
+	g = NewGenWithOptions(
+		WithHWAddrFunc(func() (HWAddr, error) {
+			<-block
+			return hwAddr, nil
+		}),
+		WithHWAddrTimeout(10*time.Millisecond),
+	)
+	start := time.Now()
+	u, err = g.NewV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d > 10*time.Second {
+		t.Errorf("NewV1() took %v with a blocked HWAddrFunc", d)
+	}
+	if u[10]&0x01 == 0 {
+		t.Errorf("node %v of a blocked HWAddrFunc does not have the multicast bit set", net.HardwareAddr(u[10:]))
+	}
+	u2, err := g.NewV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(u[10:], u2[10:]) {
+		t.Errorf("random node changed from %v to %v", net.HardwareAddr(u[10:]), net.HardwareAddr(u2[10:]))
+	}
+}
+
 func testNewV1FaultyRandWithOptions(t *testing.T) {
 	g := NewGenWithOptions(WithRandomReader(&faultyReader{
 		readToFail: 0, // fail immediately
@@ -285,9 +487,11 @@ func testNewV1FaultyRandWithOptions(t *testing.T) {
 }
 
 func testNewV3(t *testing.T) {
+	skipFIPS(t)
 	t.Run("Basic", testNewV3Basic)
 	t.Run("EqualNames", testNewV3EqualNames)
 	t.Run("DifferentNamespaces", testNewV3DifferentNamespaces)
+	t.Run("Bytes", testNewV3Bytes)
 }
 
 func testNewV3Basic(t *testing.T) {
@@ -316,6 +520,21 @@ func testNewV3EqualNames(t *testing.T) {
 	}
 }
 
+func testNewV3Bytes(t *testing.T) {
+	ns := NamespaceDNS
+	name := []byte("www.example.com")
+	want := Must(FromString("5df41881-3aed-3515-88a7-2f4a814cf09e"))
+	if got := NewV3Bytes(ns, name); got != want {
+		t.Errorf("NewV3Bytes(%v, %q) = %v, want %v", ns, name, got, want)
+	}
+	if got := NewGen().NewV3Bytes(ns, name); got != want {
+		t.Errorf("Gen.NewV3Bytes(%v, %q) = %v, want %v", ns, name, got, want)
+	}
+	if got, want := NewV3Bytes(ns, nil), NewV3(ns, ""); got != want {
+		t.Errorf("NewV3Bytes(%v, nil) = %v, want %v", ns, got, want)
+	}
+}
+
 func testNewV3DifferentNamespaces(t *testing.T) {
 	name := "example.com"
 	ns1 := NamespaceDNS
@@ -392,7 +611,7 @@ func testNewV4FaultyRandWithOptions(t *testing.T) {
 func testNewV4ShortRandomRead(t *testing.T) {
 	g := &Gen{
 		epochFunc: time.Now,
-		hwAddrFunc: func() (net.HardwareAddr, error) {
+		hwAddrFunc: func() (HWAddr, error) {
 			return []byte{}, fmt.Errorf("uuid: no hw address found")
 		},
 		rand: bytes.NewReader([]byte{42}),
@@ -405,7 +624,7 @@ func testNewV4ShortRandomRead(t *testing.T) {
 
 func testNewV4ShortRandomReadWithOptions(t *testing.T) {
 	g := NewGenWithOptions(
-		WithHWAddrFunc(func() (net.HardwareAddr, error) {
+		WithHWAddrFunc(func() (HWAddr, error) {
 			return []byte{}, fmt.Errorf("uuid: no hw address found")
 		}),
 		WithRandomReader(&faultyReader{
@@ -419,9 +638,13 @@ func testNewV4ShortRandomReadWithOptions(t *testing.T) {
 }
 
 func testNewV5(t *testing.T) {
+	skipFIPS(t)
 	t.Run("Basic", testNewV5Basic)
 	t.Run("EqualNames", testNewV5EqualNames)
 	t.Run("DifferentNamespaces", testNewV5DifferentNamespaces)
+	t.Run("Bytes", testNewV5Bytes)
+	t.Run("FromReader", testNewV5FromReader)
+	t.Run("Multi", testNewV5Multi)
 }
 
 func testNewV5Basic(t *testing.T) {
@@ -450,6 +673,83 @@ func testNewV5EqualNames(t *testing.T) {
 	}
 }
 
+func testNewV5Bytes(t *testing.T) {
+	ns := NamespaceDNS
+	name := []byte("www.example.com")
+	want := Must(FromString("2ed6657d-e927-568b-95e1-2665a8aea6a2"))
+	if got := NewV5Bytes(ns, name); got != want {
+		t.Errorf("NewV5Bytes(%v, %q) = %v, want %v", ns, name, got, want)
+	}
+	if got := NewGen().NewV5Bytes(ns, name); got != want {
+		t.Errorf("Gen.NewV5Bytes(%v, %q) = %v, want %v", ns, name, got, want)
+	}
+	if got, want := NewV5Bytes(ns, nil), NewV5(ns, ""); got != want {
+		t.Errorf("NewV5Bytes(%v, nil) = %v, want %v", ns, got, want)
+	}
+}
+
+func testNewV5FromReader(t *testing.T) {
+	ns := NamespaceDNS
+	r := io.MultiReader(strings.NewReader("www."), strings.NewReader("example.com"))
+	u, err := NewV5FromReader(ns, r)
+	if err != nil {
+		t.Fatalf("NewV5FromReader(%v) unexpected error: %v", ns, err)
+	}
+	if want := NewV5(ns, "www.example.com"); u != want {
+		t.Errorf("NewV5FromReader(%v, www.example.com) = %v, want %v", ns, u, want)
+	}
+
+	// A name larger than the buffer of io.Copy.
+	name := bytes.Repeat([]byte("0123456789abcdef"), 1<<12)
+	if got, want := Must(NewV5FromReader(ns, bytes.NewReader(name))), NewV5Bytes(ns, name); got != want {
+		t.Errorf("NewV5FromReader(%v) of %d bytes = %v, want %v", ns, len(name), got, want)
+	}
+
+	r = io.MultiReader(strings.NewReader("www."), &faultyReader{readToFail: 0})
+	if u, err := NewV5FromReader(ns, r); err == nil || u != Nil {
+		t.Errorf("NewV5FromReader(%v) of faulty reader = %v, %v, want Nil, error", ns, u, err)
+	}
+}
+
+func testNewV5Multi(t *testing.T) {
+	ns := NamespaceDNS
+	u := NewV5Multi(ns, "ab", "c")
+	if got := NewV5Multi(ns, "ab", "c"); got != u {
+		t.Errorf("NewV5Multi(%v, ab, c) generated %v and %v across two calls", ns, u, got)
+	}
+	if got, want := u.Version(), V5; got != want {
+		t.Errorf("NewV5Multi(%v, ab, c): got version %d, want %d", ns, got, want)
+	}
+	if got, want := u.Variant(), VariantRFC9562; got != want {
+		t.Errorf("NewV5Multi(%v, ab, c): got variant %d, want %d", ns, got, want)
+	}
+	// The SHA-1 hash of the namespace, then of each part after its length
+	// as an unsigned varint.
+	name := []byte{2, 'a', 'b', 1, 'c'}
+	if want := NewV5Bytes(ns, name); u != want {
+		t.Errorf("NewV5Multi(%v, ab, c) = %v, want %v", ns, u, want)
+	}
+
+	seen := map[UUID][]string{u: {"ab", "c"}}
+	for _, parts := range [][]string{
+		{"a", "bc"},
+		{"abc"},
+		{"ab", "c", ""},
+		{"", "ab", "c"},
+		{"c", "ab"},
+		{},
+	} {
+		got := NewV5Multi(ns, parts...)
+		if prev, ok := seen[got]; ok {
+			t.Errorf("NewV5Multi(%v, %q) = NewV5Multi(%v, %q) = %v", ns, parts, ns, prev, got)
+		}
+		seen[got] = parts
+	}
+	if got := NewV5Multi(NamespaceURL, "ab", "c"); got == u {
+		t.Errorf("NewV5Multi returned %v for different namespaces", got)
+	}
+}
+
 func testNewV5DifferentNamespaces(t *testing.T) {
 	name := "example.com"
 	ns1 := NamespaceDNS
@@ -709,6 +1009,8 @@ func testNewV7(t *testing.T) {
 	t.Run("KSortable", makeTestNewV7KSortable())
 	t.Run("ClockSequence", makeTestNewV7ClockSequence())
 	t.Run("AtSpecificTime", makeTestNewV7AtTime())
+	t.Run("TimestampGranularity", makeTestNewV7TimestampGranularity())
+	t.Run("TimestampJitter", makeTestNewV7TimestampJitter())
 }
 
 func makeTestNewV7Basic() func(t *testing.T) {
@@ -956,6 +1258,113 @@ func makeTestNewV7ClockSequence() func(t *testing.T) {
 	}
 }
 
+// v7Time returns the time of the timestamp of the V7 UUID u.
+func v7Time(t *testing.T, u UUID) time.Time {
+	t.Helper()
+	ts, err := TimestampFromV7(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tm, err := ts.Time()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tm
+}
+
+func makeTestNewV7TimestampGranularity() func(t *testing.T) {
+	return func(t *testing.T) {
+		base := time.Date(2024, 5, 6, 7, 0, 0, 0, time.UTC)
+		now := base
+		g := NewGenWithOptions(
+			WithEpochFunc(func() time.Time { return now }),
+			WithV7TimestampGranularity(time.Minute),
+		)
+		var prev UUID
+		for i, offset := range []time.Duration{0, 59*time.Second + 999*time.Millisecond, time.Minute, 2 * time.Minute} {
+			now = base.Add(offset)
+			u, err := g.NewV7()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := v7Time(t, u), now.Truncate(time.Minute); !got.Equal(want) {
+				t.Errorf("UUID generated at %v has time %v, want %v", now, got, want)
+			}
+			// UUIDs of different minutes are ordered by their timestamps.
+			if i > 1 && u.Compare(prev) <= 0 {
+				t.Errorf("UUID %v generated after %v sorts before it", u, prev)
+			}
+			prev = u
+		}
+
+		g = NewGenWithOptions(WithV7TimestampGranularity(time.Microsecond))
+		at := time.Date(2024, 5, 6, 7, 8, 9, 123456789, time.UTC)
+		u, err := g.NewV7AtTime(at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := v7Time(t, u), at.Truncate(time.Millisecond); !got.Equal(want) {
+			t.Errorf("UUID with sub-millisecond granularity has time %v, want %v", got, want)
+		}
+
+		mg := NewMonotonicGen(
+			WithEpochFunc(func() time.Time { return at }),
+			WithV7TimestampGranularity(time.Hour),
+		)
+		batch, err := mg.GenerateBatchV7(10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, u := range batch {
+			if got, want := v7Time(t, u), at.Truncate(time.Hour); !got.Equal(want) {
+				t.Errorf("batch UUID has time %v, want %v", got, want)
+			}
+		}
+		if !IsSorted(batch) {
+			t.Errorf("batch %v is not sorted", batch)
+		}
+	}
+}
+
+func makeTestNewV7TimestampJitter() func(t *testing.T) {
+	return func(t *testing.T) {
+		at := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+		g := NewGenWithOptions(WithV7TimestampJitter(10 * time.Second))
+		times := make(map[time.Time]bool)
+		for i := 0; i < 100; i++ {
+			u, err := g.NewV7AtTime(at)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tm := v7Time(t, u)
+			if tm.After(at) || !tm.After(at.Add(-10*time.Second)) {
+				t.Fatalf("UUID generated at %v has time %v, want within 10s before", at, tm)
+			}
+			times[tm] = true
+		}
+		if len(times) < 2 {
+			t.Errorf("jitter did not change the timestamps of UUIDs generated at %v", at)
+		}
+
+		g = NewGenWithOptions(
+			WithV7TimestampJitter(time.Second),
+			WithV7TimestampGranularity(time.Minute),
+		)
+		u, err := g.NewV7AtTime(at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := v7Time(t, u); got.Second() != 0 || got.Nanosecond() != 0 {
+			t.Errorf("UUID has time %v, want a whole minute", got)
+		}
+
+		g = NewGenWithOptions(WithV7TimestampJitter(time.Second), WithRandomReader(&faultyReader{readToFail: 0}))
+		if u, err := g.NewV7(); err == nil {
+			t.Errorf("did not error on faulty reader with jitter, got %v", u)
+		}
+	}
+}
+
 func makeTestNewV7AtTime() func(t *testing.T) {
 	return func(t *testing.T) {
 		atTime := time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC)
@@ -1003,6 +1412,100 @@ func makeTestNewV7AtTime() func(t *testing.T) {
 	}
 }
 
+func TestTimestampOutOfRange(t *testing.T) {
+	gregorian := time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC)
+	maxV1, _ := Timestamp(maxEpochTimestamp).Time()
+	type atTime func(*Gen, time.Time) (UUID, error)
+	tests := []struct {
+		name    string
+		gen     atTime
+		at      time.Time
+		err     bool
+		clamped uint64
+	}{
+		{"V1Min", (*Gen).NewV1AtTime, gregorian, false, 0},
+		{"V1BeforeMin", (*Gen).NewV1AtTime, gregorian.Add(-100 * time.Nanosecond), true, 0},
+		{"V1Year3000", (*Gen).NewV1AtTime, time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC), false, 0},
+		{"V1Max", (*Gen).NewV1AtTime, maxV1, false, 0},
+		{"V1AfterMax", (*Gen).NewV1AtTime, maxV1.Add(100 * time.Nanosecond), true, maxEpochTimestamp},
+		{"V6BeforeMin", (*Gen).NewV6AtTime, time.Date(1000, 1, 1, 0, 0, 0, 0, time.UTC), true, 0},
+		{"V6AfterMax", (*Gen).NewV6AtTime, time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC), true, maxEpochTimestamp},
+		{"V7Min", (*Gen).NewV7AtTime, time.UnixMilli(0), false, 0},
+		{"V7BeforeMin", (*Gen).NewV7AtTime, time.UnixMilli(-1), true, 0},
+		{"V7Max", (*Gen).NewV7AtTime, time.UnixMilli(maxV7Millis), false, 0},
+		{"V7AfterMax", (*Gen).NewV7AtTime, time.UnixMilli(maxV7Millis + 1), true, maxV7Millis},
+	}
+	// timestamp returns the timestamp of u, in the unit of its version.
+	timestamp := func(u UUID) uint64 {
+		if u.Version() == V7 {
+			return binary.BigEndian.Uint64(u[:8]) >> 16
+		}
+		ts, _ := timestampOf(u)
+		return uint64(ts)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := tt.gen(NewGen(), tt.at)
+			if !tt.err {
+				if err != nil {
+					t.Fatalf("error = %v, want <nil>", err)
+				}
+				if got, _ := u.Time(); !got.Equal(tt.at) {
+					t.Errorf("%v has time %v, want %v", u, got, tt.at)
+				}
+				return
+			}
+			if !errors.Is(err, ErrTimestampOutOfRange) || u != Nil {
+				t.Errorf("= %v, %v, want %v", u, err, ErrTimestampOutOfRange)
+			}
+			u, err = tt.gen(NewGenWithOptions(WithTimestampClamping()), tt.at)
+			if err != nil {
+				t.Fatalf("error with WithTimestampClamping() = %v, want <nil>", err)
+			}
+			if got := timestamp(u); got != tt.clamped {
+				t.Errorf("%v has timestamp %#x with WithTimestampClamping(), want %#x", u, got, tt.clamped)
+			}
+		})
+	}
+}
+
+func TestGenClockSequence(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	g := NewGenWithOptions(
+		WithEpochFunc(func() time.Time { return now }),
+		WithRandomReader(&faultyReader{readToFail: 0}),
+	)
+	if _, err := g.ClockSequence(); err == nil {
+		t.Error("ClockSequence() with a faulty random reader returned no error")
+	}
+
+	g = NewGenWithOptions(
+		WithEpochFunc(func() time.Time { return now }),
+		WithHWAddrFunc(func() (HWAddr, error) { return HWAddr{0, 1, 2, 3, 4, 5}, nil }),
+	)
+	g.SetClockSequence(0xfffe)
+	if seq, err := g.ClockSequence(); err != nil || seq != 0x3ffe {
+		t.Errorf("ClockSequence() = %#x, %v, want 0x3ffe", seq, err)
+	}
+	// The clock does not advance: the clock sequence is incremented, and
+	// wraps around within 14 bits.
+	for _, want := range []uint16{0x3ffe, 0x3fff, 0, 1} {
+		u, err := g.NewV1()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if seq, _ := u.ClockSequence(); seq != want {
+			t.Errorf("%v.ClockSequence() = %#x, want %#x", u, seq, want)
+		}
+		if u.Variant() != VariantRFC9562 {
+			t.Errorf("%v has variant %d", u, u.Variant())
+		}
+	}
+	if seq, err := g.ClockSequence(); err != nil || seq != 1 {
+		t.Errorf("ClockSequence() = %#x, %v, want 1", seq, err)
+	}
+}
+
 func TestGenerateBatchV7(t *testing.T) {
 	gen := NewMonotonicGen()
 	batchSize := 100
@@ -1029,6 +1532,196 @@ func TestGenerateBatchV7(t *testing.T) {
 			t.Errorf("expected nil UUID slice for zero batch size, got: %v", uuids)
 		}
 	})
+
+	t.Run("Single Random Read", func(t *testing.T) {
+		for _, opts := range [][]GenOption{nil, {WithV7TimestampJitter(time.Millisecond)}} {
+			r := &faultyReader{readToFail: -1}
+			gen := NewMonotonicGen(append(opts, WithRandomReader(r))...)
+			uuids, err := gen.GenerateBatchV7(batchSize)
+			if err != nil {
+				t.Fatalf("Error generating batch: %v", err)
+			}
+			if r.callsNum != 1 {
+				t.Errorf("GenerateBatchV7(%d) read the random source %d times, want 1", batchSize, r.callsNum)
+			}
+			if !IsSorted(uuids) {
+				t.Errorf("batch %v is not sorted", uuids)
+			}
+			for i, u := range uuids {
+				if u.Version() != V7 || u.Variant() != VariantRFC9562 {
+					t.Errorf("UUID %d (%s) has version %d and variant %d", i, u, u.Version(), u.Variant())
+				}
+			}
+		}
+	})
+
+	t.Run("Faulty Rand", func(t *testing.T) {
+		gen := NewMonotonicGen(WithRandomReader(&faultyReader{readToFail: 0}))
+		uuids, err := gen.GenerateBatchV7(batchSize)
+		if err == nil || uuids != nil {
+			t.Errorf("GenerateBatchV7(%d) = %v, %v, want an error", batchSize, uuids, err)
+		}
+	})
+}
+
+func TestGenerateBatchV7Parallel(t *testing.T) {
+	t.Run("Global Order", func(t *testing.T) {
+		for _, tt := range []struct{ n, workers int }{
+			{1, 4},
+			{100, 0},
+			{10000, 3},
+			{3 * parallelBlockSize, 2},
+		} {
+			gen := NewMonotonicGen()
+			before, err := gen.NewV7()
+			if err != nil {
+				t.Fatal(err)
+			}
+			uuids, err := gen.GenerateBatchV7Parallel(tt.n, tt.workers)
+			if err != nil {
+				t.Fatalf("GenerateBatchV7Parallel(%d, %d) error: %v", tt.n, tt.workers, err)
+			}
+			after, err := gen.NewV7()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(uuids) != tt.n {
+				t.Fatalf("GenerateBatchV7Parallel(%d, %d) returned %d UUIDs", tt.n, tt.workers, len(uuids))
+			}
+			all := append(append([]UUID{before}, uuids...), after)
+			for i := 1; i < len(all); i++ {
+				if all[i-1].Compare(all[i]) >= 0 {
+					t.Fatalf("GenerateBatchV7Parallel(%d, %d): UUID %s is not less than %s", tt.n, tt.workers, all[i-1], all[i])
+				}
+			}
+			for i, u := range uuids {
+				if u.Version() != V7 || u.Variant() != VariantRFC9562 {
+					t.Fatalf("UUID %d (%s) has version %d and variant %d", i, u, u.Version(), u.Variant())
+				}
+			}
+		}
+	})
+
+	t.Run("Batch Size Validation", func(t *testing.T) {
+		uuids, err := NewMonotonicGen().GenerateBatchV7Parallel(0, 4)
+		if err == nil || uuids != nil {
+			t.Errorf("GenerateBatchV7Parallel(0, 4) = %v, %v, want an error", uuids, err)
+		}
+	})
+
+	t.Run("Faulty Rand", func(t *testing.T) {
+		gen := NewMonotonicGen(WithRandomReader(&faultyReader{readToFail: 2}))
+		uuids, err := gen.GenerateBatchV7Parallel(4*parallelBlockSize, 2)
+		if err == nil || uuids != nil {
+			t.Errorf("GenerateBatchV7Parallel() = %d UUIDs, %v, want an error", len(uuids), err)
+		}
+	})
+
+	t.Run("Concurrent With Batches", func(t *testing.T) {
+		gen := NewMonotonicGen()
+		var (
+			wg       sync.WaitGroup
+			parallel []UUID
+			serial   [][]UUID
+		)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			var err error
+			if parallel, err = gen.GenerateBatchV7Parallel(50000, 4); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				batch, err := gen.GenerateBatchV7(100)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				serial = append(serial, batch)
+			}
+		}()
+		wg.Wait()
+		seen := make(map[UUID]bool)
+		for _, us := range append(serial, parallel) {
+			for _, u := range us {
+				if seen[u] {
+					t.Fatalf("generated %v twice", u)
+				}
+				seen[u] = true
+			}
+		}
+	})
+}
+
+// testCoordinator is a Coordinator recording its reservations.
+type testCoordinator struct {
+	mu    sync.Mutex
+	last  uint64
+	calls int
+	err   error
+}
+
+func (c *testCoordinator) Reserve(ms, n uint64) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls++
+	if c.err != nil {
+		return 0, c.err
+	}
+	first := ms << 12
+	if first <= c.last {
+		first = c.last + 1
+	}
+	c.last = first + n - 1
+	return first, nil
+}
+
+func TestWithCoordinator(t *testing.T) {
+	c := &testCoordinator{}
+	at := time.UnixMilli(1700000000000)
+	g1 := NewMonotonicGen(WithCoordinator(c), WithEpochFunc(func() time.Time { return at }))
+	g2 := NewMonotonicGen(WithCoordinator(c), WithEpochFunc(func() time.Time { return at.Add(-time.Second) }))
+	var uuids []UUID
+	for i := 0; i < 5; i++ {
+		u1, err := g1.NewV7()
+		if err != nil {
+			t.Fatal(err)
+		}
+		batch, err := g2.GenerateBatchV7(3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parallel, err := g2.GenerateBatchV7Parallel(10, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		uuids = append(append(append(uuids, u1), batch...), parallel...)
+	}
+	for i := 1; i < len(uuids); i++ {
+		if uuids[i-1].Compare(uuids[i]) >= 0 {
+			t.Fatalf("UUID %s is not less than %s", uuids[i-1], uuids[i])
+		}
+	}
+	if want := 5 * (1 + 3 + 1); c.calls != want {
+		t.Errorf("Reserve called %d times, want %d", c.calls, want)
+	}
+	if g1.state.Load() != 0 || g2.state.Load() != 0 {
+		t.Error("generators with a Coordinator recorded their state")
+	}
+
+	c.err = errors.New("coordinator failure")
+	if _, err := g1.NewV7(); !errors.Is(err, c.err) {
+		t.Errorf("NewV7() error = %v, want %v", err, c.err)
+	}
+	if _, err := g1.GenerateBatchV7(3); !errors.Is(err, c.err) {
+		t.Errorf("GenerateBatchV7() error = %v, want %v", err, c.err)
+	}
+	if _, err := g1.GenerateBatchV7Parallel(3, 2); !errors.Is(err, c.err) {
+		t.Errorf("GenerateBatchV7Parallel() error = %v, want %v", err, c.err)
+	}
 }
 
 func TestWithCustomPRNG(t *testing.T) {
@@ -1065,112 +1758,137 @@ func TestMonotonicGenEdgeCases(t *testing.T) {
 	})
 
 	t.Run("Counter Rollover", func(t *testing.T) {
-		gen.monotonicCounter = 0xFFFF
+		at := time.UnixMilli(1700000000000)
+		gen := NewMonotonicGen(WithEpochFunc(func() time.Time { return at }))
+		gen.state.Store(uint64(at.UnixMilli())<<12 | 0xfff)
 		uuid, err := gen.newMonotonicV7()
 		if err != nil {
 			t.Fatalf("error generating UUID during counter rollover: %v", err)
 		}
-		if uuid.IsNil() {
-			t.Errorf("Generated UUID during counter rollover is nil: %v", uuid)
+		if got, want := v7Time(t, uuid), at.Add(time.Millisecond); !got.Equal(want) {
+			t.Errorf("UUID after counter rollover has time %v, want %v", got, want)
+		}
+		if got := binary.BigEndian.Uint16(uuid[6:8]) & 0xfff; got != 0 {
+			t.Errorf("UUID after counter rollover has counter %#x, want 0", got)
 		}
 	})
-}
-
-func TestDefaultHWAddrFunc(t *testing.T) {
-	tests := []struct {
-		n  string
-		fn func() ([]net.Interface, error)
-		hw net.HardwareAddr
-		e  string
-	}{
-		{
-			n: "Error",
-			fn: func() ([]net.Interface, error) {
-				return nil, errors.New("controlled failure")
-			},
-			e: "controlled failure",
-		},
-		{
-			n: "NoValidHWAddrReturned",
-			fn: func() ([]net.Interface, error) {
-				s := []net.Interface{
-					{
-						Index:        1,
-						MTU:          1500,
-						Name:         "test0",
-						HardwareAddr: net.HardwareAddr{1, 2, 3, 4},
-					},
-					{
-						Index:        2,
-						MTU:          1500,
-						Name:         "lo0",
-						HardwareAddr: net.HardwareAddr{5, 6, 7, 8},
-					},
-				}
-
-				return s, nil
-			},
-			e: "uuid: no HW address found",
-		},
-		{
-			n: "ValidHWAddrReturned",
-			fn: func() ([]net.Interface, error) {
-				s := []net.Interface{
-					{
-						Index:        1,
-						MTU:          1500,
-						Name:         "test0",
-						HardwareAddr: net.HardwareAddr{1, 2, 3, 4},
-					},
-					{
-						Index:        2,
-						MTU:          1500,
-						Name:         "lo0",
-						HardwareAddr: net.HardwareAddr{5, 6, 7, 8, 9, 0},
-					},
-				}
 
-				return s, nil
-			},
-			hw: net.HardwareAddr{5, 6, 7, 8, 9, 0},
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.n, func(t *testing.T) {
-			// set the netInterfaces variable (function) for the test
-			// and then set it back to default in the deferred function
-			netInterfaces = tt.fn
-			defer func() {
-				netInterfaces = net.Interfaces
-			}()
-
-			var hw net.HardwareAddr
-			var err error
-
-			hw, err = defaultHWAddrFunc()
-
-			if len(tt.e) > 0 {
-				if err == nil {
-					t.Fatalf("defaultHWAddrFunc() error = <nil>, should contain %q", tt.e)
-				}
+	t.Run("Clock Rollback", func(t *testing.T) {
+		at := time.UnixMilli(1700000000000)
+		gen := NewMonotonicGen(WithEpochFunc(func() time.Time { return at }))
+		u1, err := gen.NewV7()
+		if err != nil {
+			t.Fatal(err)
+		}
+		at = at.Add(-time.Second)
+		u2, err := gen.NewV7()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u1.String() >= u2.String() {
+			t.Errorf("UUID %s generated after a clock rollback is not greater than %s", u2, u1)
+		}
+	})
 
-				if !strings.Contains(err.Error(), tt.e) {
-					t.Fatalf("defaultHWAddrFunc() error = %q, should contain %q", err.Error(), tt.e)
-				}
+	t.Run("Timestamp Out Of Range", func(t *testing.T) {
+		for _, tt := range []struct {
+			at      time.Time
+			clamped uint64
+		}{
+			{time.UnixMilli(-1), 0},
+			{time.UnixMilli(maxV7Millis + 1), maxV7Millis},
+		} {
+			epochFunc := WithEpochFunc(func() time.Time { return tt.at })
+			gen := NewMonotonicGen(epochFunc)
+			if u, err := gen.NewV7(); !errors.Is(err, ErrTimestampOutOfRange) {
+				t.Errorf("NewV7() at %v = %v, %v, want %v", tt.at, u, err, ErrTimestampOutOfRange)
+			}
+			if _, err := gen.GenerateBatchV7Parallel(10, 2); !errors.Is(err, ErrTimestampOutOfRange) {
+				t.Errorf("GenerateBatchV7Parallel() at %v error = %v, want %v", tt.at, err, ErrTimestampOutOfRange)
+			}
 
-				return
+			gen = NewMonotonicGen(epochFunc, WithTimestampClamping())
+			u, err := gen.NewV7()
+			if err != nil {
+				t.Fatalf("NewV7() at %v with WithTimestampClamping() unexpected error: %v", tt.at, err)
+			}
+			if got := binary.BigEndian.Uint64(u[:8]) >> 16; got != tt.clamped {
+				t.Errorf("NewV7() at %v with WithTimestampClamping() has timestamp %#x, want %#x", tt.at, got, tt.clamped)
 			}
+		}
+	})
+}
 
-			if err != nil && tt.e == "" {
-				t.Fatalf("defaultHWAddrFunc() error = %q, want <nil>", err.Error())
+func TestMonotonicGenMixed(t *testing.T) {
+	t.Run("Single And Batch", func(t *testing.T) {
+		at := time.UnixMilli(1700000000000)
+		gen := NewMonotonicGen(WithEpochFunc(func() time.Time { return at }))
+		var uuids []UUID
+		for i := 0; i < 10; i++ {
+			u, err := gen.NewV7()
+			if err != nil {
+				t.Fatal(err)
+			}
+			batch, err := gen.GenerateBatchV7(i + 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			uuids = append(append(uuids, u), batch...)
+			if i%3 == 0 {
+				at = at.Add(time.Millisecond)
 			}
+		}
+		if !IsSorted(uuids) {
+			t.Errorf("UUIDs %v are not sorted", uuids)
+		}
+		for i := 1; i < len(uuids); i++ {
+			if uuids[i-1] == uuids[i] {
+				t.Errorf("UUID %d and %d are both %s", i-1, i, uuids[i])
+			}
+		}
+	})
 
-			if !bytes.Equal(hw, tt.hw) {
-				t.Fatalf("hw = %#v, want %#v", hw, tt.hw)
+	t.Run("Concurrent", func(t *testing.T) {
+		const goroutines, batches = 8, 100
+		gen := NewMonotonicGen()
+		results := make([][]UUID, goroutines)
+		var wg sync.WaitGroup
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < batches; j++ {
+					u, err := gen.NewV7()
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					batch, err := gen.GenerateBatchV7(10)
+					if err != nil {
+						t.Error(err)
+						return
+					}
+					if !IsSorted(batch) || batch[0].String() <= u.String() {
+						t.Errorf("batch %v does not follow %s", batch, u)
+					}
+					results[i] = append(append(results[i], u), batch...)
+				}
+			}(i)
+		}
+		wg.Wait()
+		seen := make(map[UUID]bool)
+		for i, us := range results {
+			if !IsSorted(us) {
+				t.Errorf("UUIDs of goroutine %d are not sorted", i)
 			}
-		})
-	}
+			for _, u := range us {
+				if seen[u] {
+					t.Fatalf("generated %v twice", u)
+				}
+				seen[u] = true
+			}
+		}
+	})
 }
 
 func BenchmarkGenerator(b *testing.B) {
@@ -1194,6 +1912,38 @@ func BenchmarkGenerator(b *testing.B) {
 			NewV5(NamespaceDNS, "www.example.com")
 		}
 	})
+	b.Run("NewV5Bytes", func(b *testing.B) {
+		name := []byte("www.example.com")
+		for i := 0; i < b.N; i++ {
+			NewV5Bytes(NamespaceDNS, name)
+		}
+	})
+}
+
+func BenchmarkGenerateBatchV7(b *testing.B) {
+	gen := NewMonotonicGen()
+	for _, size := range []int{1, 100, 10000} {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := gen.GenerateBatchV7(size); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGenerateBatchV7Parallel(b *testing.B) {
+	gen := NewMonotonicGen()
+	for _, workers := range []int{1, 4, 0} {
+		b.Run(fmt.Sprint(workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := gen.GenerateBatchV7Parallel(1000000, workers); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
 }
 
 type faultyReader struct {
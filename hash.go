@@ -0,0 +1,22 @@
+package uuid
+
+// hashMix is the 64-bit finalizer of MurmurHash3, a bijection with good
+// avalanche behavior.
+func hashMix(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+// Hash64 returns a 64-bit hash of the UUID, mixed with seed. It is fast,
+// allocation-free and well distributed even for UUIDs that differ only in a
+// few bits, such as time-ordered versions, making it suitable for hash tables,
+// consistent-hash rings and partitioners. It is not a cryptographic hash.
+func (u UUID) Hash64(seed uint64) uint64 {
+	hi, lo := u.Uint64Pair()
+	h := hashMix(hi ^ seed ^ 0x9e3779b97f4a7c15)
+	return hashMix(h ^ lo)
+}
@@ -0,0 +1,67 @@
+package uuid
+
+import (
+	"math/bits"
+	"testing"
+	"time"
+)
+
+func TestHash64(t *testing.T) {
+	t.Run("Deterministic", func(t *testing.T) {
+		if a, b := NamespaceDNS.Hash64(1), NamespaceDNS.Hash64(1); a != b {
+			t.Errorf("Hash64(1) = %#x then %#x", a, b)
+		}
+	})
+	t.Run("Seed", func(t *testing.T) {
+		if a, b := NamespaceDNS.Hash64(1), NamespaceDNS.Hash64(2); a == b {
+			t.Errorf("Hash64(1) == Hash64(2) == %#x", a)
+		}
+	})
+	t.Run("Avalanche", func(t *testing.T) {
+		// Flipping any single input bit should flip about half of the
+		// output bits on average.
+		base := NamespaceDNS.Hash64(0)
+		total := 0
+		for i := 0; i < 8*Size; i++ {
+			u := NamespaceDNS
+			u[i/8] ^= 1 << (i % 8)
+			total += bits.OnesCount64(base ^ u.Hash64(0))
+		}
+		if avg := float64(total) / (8 * Size); avg < 24 || avg > 40 {
+			t.Errorf("flipping one input bit flips %.1f output bits on average, want about 32", avg)
+		}
+	})
+	t.Run("Distribution", func(t *testing.T) {
+		// Sequential time-ordered UUIDs should spread evenly across buckets.
+		const buckets = 64
+		const n = 64000
+		var counts [buckets]int
+		g := NewGenWithOptions(WithCustomPRNG(1))
+		start := time.UnixMilli(1700000000000)
+		for i := 0; i < n; i++ {
+			u, err := g.NewV7AtTime(start.Add(time.Duration(i/8) * time.Millisecond))
+			if err != nil {
+				t.Fatal(err)
+			}
+			counts[u.Hash64(0)%buckets]++
+		}
+		for i, c := range counts {
+			if c < n/buckets*8/10 || c > n/buckets*12/10 {
+				t.Errorf("bucket %d has %d entries, want about %d", i, c, n/buckets)
+			}
+		}
+	})
+	t.Run("Allocs", func(t *testing.T) {
+		if allocs := testing.AllocsPerRun(100, func() { codecTestUUID.Hash64(0) }); allocs != 0 {
+			t.Errorf("Hash64 allocated %v times, want 0", allocs)
+		}
+	})
+}
+
+var hash64BenchmarkSink uint64
+
+func BenchmarkHash64(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		hash64BenchmarkSink = codecTestUUID.Hash64(uint64(i))
+	}
+}
@@ -0,0 +1,39 @@
+//go:build !uuid_nonet
+
+package uuid
+
+import "net"
+
+// NoNet reports whether the package was built with the uuid_nonet build tag,
+// which removes the dependency on the net package and the lookup of the
+// hardware (MAC) address used as the node of V1 UUIDs.
+const NoNet = false
+
+// HWAddr is the type of the hardware (MAC) addresses returned by an
+// HWAddrFunc: a net.HardwareAddr, or a byte slice with the uuid_nonet build
+// tag.
+type HWAddr = net.HardwareAddr
+
+// HWAddrFunc is the function type used to provide hardware (MAC) addresses.
+//
+// Its result type is a net.HardwareAddr, but a byte slice with the
+// uuid_nonet build tag, so that a function declared as returning a
+// net.HardwareAddr does not compile with the tag. Declare it as returning an
+// HWAddr to compile with and without the tag.
+type HWAddrFunc func() (HWAddr, error)
+
+var netInterfaces = net.Interfaces
+
+// Returns the hardware address.
+func defaultHWAddrFunc() (net.HardwareAddr, error) {
+	ifaces, err := netInterfaces()
+	if err != nil {
+		return []byte{}, err
+	}
+	for _, iface := range ifaces {
+		if len(iface.HardwareAddr) >= 6 {
+			return iface.HardwareAddr, nil
+		}
+	}
+	return []byte{}, ErrNoHwAddressFound
+}
@@ -0,0 +1,27 @@
+//go:build uuid_nonet
+
+package uuid
+
+// NoNet reports whether the package was built with the uuid_nonet build tag,
+// which removes the dependency on the net package and the lookup of the
+// hardware (MAC) address used as the node of V1 UUIDs.
+const NoNet = true
+
+// HWAddr is the type of the hardware (MAC) addresses returned by an
+// HWAddrFunc: a net.HardwareAddr, or a byte slice with the uuid_nonet build
+// tag.
+type HWAddr = []byte
+
+// HWAddrFunc is the function type used to provide hardware (MAC) addresses.
+//
+// Its result type is a net.HardwareAddr, but a byte slice with the
+// uuid_nonet build tag, so that a function declared as returning a
+// net.HardwareAddr does not compile with the tag. Declare it as returning an
+// HWAddr to compile with and without the tag.
+type HWAddrFunc func() (HWAddr, error)
+
+// Returns ErrNoHwAddressFound, so that generators use a random node unless
+// given an HWAddrFunc.
+func defaultHWAddrFunc() ([]byte, error) {
+	return nil, ErrNoHwAddressFound
+}
@@ -0,0 +1,33 @@
+//go:build uuid_nonet
+
+package uuid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNoNet(t *testing.T) {
+	if !NoNet {
+		t.Error("NoNet = false with the uuid_nonet build tag")
+	}
+	if _, err := defaultHWAddrFunc(); !errors.Is(err, ErrNoHwAddressFound) {
+		t.Errorf("defaultHWAddrFunc() error = %v, want %v", err, ErrNoHwAddressFound)
+	}
+
+	g := NewGen()
+	u1, err := g.NewV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	u2, err := g.NewV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u1[10]&0x01 == 0 {
+		t.Errorf("node %x does not have the multicast bit set", u1[10:])
+	}
+	if string(u1[10:]) != string(u2[10:]) {
+		t.Errorf("random node changed from %x to %x", u1[10:], u2[10:])
+	}
+}
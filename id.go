@@ -0,0 +1,26 @@
+package uuid
+
+// ID is a UUID identifying a value of type T. Identifiers of different entity
+// types, such as ID[User] and ID[Order], are distinct types, so passing one
+// where the other is expected is a compile-time error.
+//
+// ID embeds UUID and so inherits its methods, including the text, binary,
+// JSON and SQL encodings. The underlying UUID is available as the UUID field.
+type ID[T any] struct {
+	// Prevents conversion between IDs of different entity types.
+	_ [0]*T
+
+	UUID
+}
+
+// IDFrom returns u as the identifier of a value of type T.
+func IDFrom[T any](u UUID) ID[T] {
+	return ID[T]{UUID: u}
+}
+
+// ParseID returns the identifier of a value of type T parsed from the input
+// string. Input is expected in a form accepted by UnmarshalText.
+func ParseID[T any](s string) (ID[T], error) {
+	u, err := FromString(s)
+	return ID[T]{UUID: u}, err
+}
@@ -0,0 +1,100 @@
+package uuid
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type idTestUser struct{}
+
+type idTestOrder struct{}
+
+func TestID(t *testing.T) {
+	t.Run("IDFrom", testIDFrom)
+	t.Run("ParseID", testParseID)
+	t.Run("DistinctTypes", testIDDistinctTypes)
+	t.Run("JSON", testIDJSON)
+	t.Run("SQL", testIDSQL)
+}
+
+func testIDFrom(t *testing.T) {
+	id := IDFrom[idTestUser](codecTestUUID)
+	if id.UUID != codecTestUUID {
+		t.Errorf("IDFrom(%v).UUID = %v", codecTestUUID, id.UUID)
+	}
+	if got, want := id.String(), codecTestUUID.String(); got != want {
+		t.Errorf("IDFrom(%v).String() = %q, want %q", codecTestUUID, got, want)
+	}
+	if id != IDFrom[idTestUser](codecTestUUID) {
+		t.Errorf("IDFrom(%v) is not comparable with itself", codecTestUUID)
+	}
+}
+
+func testParseID(t *testing.T) {
+	s := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	id, err := ParseID[idTestUser](s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id.UUID != codecTestUUID {
+		t.Errorf("ParseID(%q) = %v, want %v", s, id, codecTestUUID)
+	}
+	if _, err := ParseID[idTestUser]("bad"); err == nil {
+		t.Error("ParseID(\"bad\") succeeded, want error")
+	}
+}
+
+func testIDDistinctTypes(t *testing.T) {
+	user := reflect.TypeOf(ID[idTestUser]{})
+	order := reflect.TypeOf(ID[idTestOrder]{})
+	if user.AssignableTo(order) || user.ConvertibleTo(order) {
+		t.Errorf("%v is assignable or convertible to %v", user, order)
+	}
+	if size := user.Size(); size != Size {
+		t.Errorf("%v has size %d, want %d", user, size, Size)
+	}
+}
+
+func testIDJSON(t *testing.T) {
+	type order struct {
+		ID   ID[idTestOrder]
+		User ID[idTestUser]
+	}
+	in := order{
+		ID:   IDFrom[idTestOrder](NamespaceURL),
+		User: IDFrom[idTestUser](NamespaceDNS),
+	}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"ID":"6ba7b811-9dad-11d1-80b4-00c04fd430c8","User":"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}`
+	if string(b) != want {
+		t.Errorf("json.Marshal(%v) = %s, want %s", in, b, want)
+	}
+	var out order
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("json.Unmarshal(%s) = %v, want %v", b, out, in)
+	}
+}
+
+func testIDSQL(t *testing.T) {
+	var id ID[idTestUser]
+	if err := id.Scan(codecTestData); err != nil {
+		t.Fatal(err)
+	}
+	if id.UUID != codecTestUUID {
+		t.Errorf("Scan(%x) = %v, want %v", codecTestData, id, codecTestUUID)
+	}
+	v, err := id.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != codecTestUUID.String() {
+		t.Errorf("Value() = %v, want %v", v, codecTestUUID.String())
+	}
+}
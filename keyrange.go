@@ -0,0 +1,70 @@
+package uuid
+
+import "math/bits"
+
+// Range is a contiguous, inclusive range of UUIDs in byte order.
+type Range struct {
+	Min UUID // first UUID in the range
+	Max UUID // last UUID in the range
+}
+
+// Contains reports whether u falls within r.
+func (r Range) Contains(u UUID) bool {
+	return u.Compare(r.Min) >= 0 && u.Compare(r.Max) <= 0
+}
+
+// SplitRange divides the 128-bit UUID space into n contiguous, disjoint
+// ranges of equal size (differing by at most one), in ascending order. The
+// first range starts at Nil and the last ends at Max, so every UUID falls in
+// exactly one range. The result only depends on n, which makes it suitable
+// for assigning key ranges to parallel workers deterministically. SplitRange
+// returns nil if n is not positive.
+func SplitRange(n int) []Range {
+	if n <= 0 {
+		return nil
+	}
+	// 2^128 = q*n + r, computed as (2^128 - 1) / n with the remainder
+	// adjusted for the missing one. For n == 1, q wraps around to zero, and
+	// the wrapping arithmetic below still yields the single range Nil to Max.
+	d := uint64(n)
+	qHi, rem := bits.Div64(0, ^uint64(0), d)
+	qLo, rem := bits.Div64(rem, ^uint64(0), d)
+	q := Uint128{Hi: qHi, Lo: qLo}
+	r := rem + 1
+	if r == d {
+		q = q.add64(1)
+		r = 0
+	}
+
+	ranges := make([]Range, n)
+	start := Uint128{}
+	for i := range ranges {
+		size := q
+		if uint64(i) < r {
+			size = size.add64(1)
+		}
+		end := start.add(size).sub64(1)
+		ranges[i] = Range{Min: start.UUID(), Max: end.UUID()}
+		start = end.add64(1)
+	}
+	return ranges
+}
+
+// add returns x + y, wrapping around on overflow.
+func (x Uint128) add(y Uint128) Uint128 {
+	lo, carry := bits.Add64(x.Lo, y.Lo, 0)
+	hi, _ := bits.Add64(x.Hi, y.Hi, carry)
+	return Uint128{Hi: hi, Lo: lo}
+}
+
+// add64 returns x + y, wrapping around on overflow.
+func (x Uint128) add64(y uint64) Uint128 {
+	return x.add(Uint128{Lo: y})
+}
+
+// sub64 returns x - y, wrapping around on underflow.
+func (x Uint128) sub64(y uint64) Uint128 {
+	lo, borrow := bits.Sub64(x.Lo, y, 0)
+	hi, _ := bits.Sub64(x.Hi, 0, borrow)
+	return Uint128{Hi: hi, Lo: lo}
+}
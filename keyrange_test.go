@@ -0,0 +1,70 @@
+package uuid
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestSplitRange(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		if got := SplitRange(n); got != nil {
+			t.Errorf("SplitRange(%d) = %v, want nil", n, got)
+		}
+	}
+
+	one := SplitRange(1)
+	if len(one) != 1 || one[0].Min != Nil || one[0].Max != Max {
+		t.Errorf("SplitRange(1) = %v, want [{%v %v}]", one, Nil, Max)
+	}
+
+	two := SplitRange(2)
+	half := UUID{0: 0x80}
+	if two[0].Max != FromUint64Pair(0x7fffffffffffffff, 0xffffffffffffffff) || two[1].Min != half {
+		t.Errorf("SplitRange(2) = %v, want a split at %v", two, half)
+	}
+
+	space := new(big.Int).Lsh(big.NewInt(1), 128)
+	for _, n := range []int{1, 2, 3, 7, 16, 100, 1000} {
+		ranges := SplitRange(n)
+		if len(ranges) != n {
+			t.Fatalf("SplitRange(%d) returned %d ranges", n, len(ranges))
+		}
+		if ranges[0].Min != Nil || ranges[n-1].Max != Max {
+			t.Errorf("SplitRange(%d) covers %v to %v, want %v to %v", n, ranges[0].Min, ranges[n-1].Max, Nil, Max)
+		}
+		want := new(big.Int).Div(space, big.NewInt(int64(n)))
+		for i, r := range ranges {
+			if i > 0 {
+				next := new(big.Int).Add(ranges[i-1].Max.BigInt(), big.NewInt(1))
+				if next.Cmp(r.Min.BigInt()) != 0 {
+					t.Fatalf("SplitRange(%d)[%d] starts at %v, want %s", n, i, r.Min, next)
+				}
+			}
+			size := new(big.Int).Sub(r.Max.BigInt(), r.Min.BigInt())
+			size.Add(size, big.NewInt(1))
+			if diff := new(big.Int).Sub(size, want); diff.Sign() < 0 || diff.Cmp(big.NewInt(1)) > 0 {
+				t.Errorf("SplitRange(%d)[%d] has size %s, want %s or one more", n, i, size, want)
+			}
+		}
+	}
+}
+
+func TestRangeContains(t *testing.T) {
+	ranges := SplitRange(5)
+	for _, u := range []UUID{Nil, Max, NamespaceDNS, {0: 0x33}, {0: 0x33, 1: 0x33}, {0: 0xcc, 15: 0xcd}} {
+		owners := 0
+		for _, r := range ranges {
+			if r.Contains(u) {
+				owners++
+			}
+		}
+		if owners != 1 {
+			t.Errorf("%v is contained in %d ranges, want 1", u, owners)
+		}
+	}
+	for _, r := range ranges {
+		if !r.Contains(r.Min) || !r.Contains(r.Max) {
+			t.Errorf("%v does not contain its bounds", r)
+		}
+	}
+}
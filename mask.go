@@ -0,0 +1,109 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+)
+
+// MaskKey is the secret key of Mask and Unmask, the 128-bit key of
+// SipHash-2-4. It should be generated from a cryptographically secure
+// source and kept private: anyone holding it can recover the creation time
+// of masked UUIDs.
+type MaskKey [16]byte
+
+// Mask returns the version 4 UUID masking the version 7 UUID u: its 48-bit
+// timestamp is encrypted with key, and its version set to 4, so that the
+// UUID can be exposed externally without revealing when it was created. The
+// random bits of u are left as they are.
+//
+// The timestamp is XORed with the low 48 bits of the SipHash-2-4, keyed by
+// key, of the random bits of u, as specified by UUIDv47: the masked UUIDs
+// are those of other UUIDv47 implementations given the same key, with the
+// first 8 bytes of key as k0 and the last 8 as k1, both little-endian.
+//
+// Mask returns an error wrapping ErrInvalidVersion if u is not a version 7
+// UUID of the RFC 9562 variant. Unmask recovers u.
+func (u UUID) Mask(key MaskKey) (UUID, error) {
+	if u.Version() != V7 || u.Variant() != VariantRFC9562 {
+		return Nil, fmt.Errorf("%w %s is version %d, not version 7", ErrInvalidVersion, u, u.Version())
+	}
+	u.xorTimestamp(key)
+	u.SetVersion(V4)
+	return u, nil
+}
+
+// Unmask returns the version 7 UUID masked by u with Mask and the same key.
+// It returns an error wrapping ErrInvalidVersion if u is not a version 4
+// UUID of the RFC 9562 variant. Any such UUID can be unmasked, and unmasking
+// a UUID not returned by Mask, or with another key, returns a version 7 UUID
+// with a meaningless timestamp.
+func (u UUID) Unmask(key MaskKey) (UUID, error) {
+	if u.Version() != V4 || u.Variant() != VariantRFC9562 {
+		return Nil, fmt.Errorf("%w %s is version %d, not version 4", ErrInvalidVersion, u, u.Version())
+	}
+	u.xorTimestamp(key)
+	u.SetVersion(V7)
+	return u, nil
+}
+
+// xorTimestamp XORs the 48-bit timestamp of u with the low 48 bits of the
+// SipHash-2-4 of its random bits, which are not changed by Mask and Unmask.
+func (u *UUID) xorTimestamp(key MaskKey) {
+	msg := [10]byte{
+		u[6] & 0x0f, u[7],
+		u[8] & 0x3f, u[9], u[10], u[11], u[12], u[13], u[14], u[15],
+	}
+	h := sipHash24(binary.LittleEndian.Uint64(key[:8]), binary.LittleEndian.Uint64(key[8:]), msg[:])
+	for i := 0; i < 6; i++ {
+		u[i] ^= byte(h >> (40 - 8*i))
+	}
+}
+
+// sipHash24 returns the SipHash-2-4 of msg with the key k0, k1.
+func sipHash24(k0, k1 uint64, msg []byte) uint64 {
+	v0 := k0 ^ 0x736f6d6570736575
+	v1 := k1 ^ 0x646f72616e646f6d
+	v2 := k0 ^ 0x6c7967656e657261
+	v3 := k1 ^ 0x7465646279746573
+
+	round := func() {
+		v0 += v1
+		v1 = bits.RotateLeft64(v1, 13)
+		v1 ^= v0
+		v0 = bits.RotateLeft64(v0, 32)
+		v2 += v3
+		v3 = bits.RotateLeft64(v3, 16)
+		v3 ^= v2
+		v0 += v3
+		v3 = bits.RotateLeft64(v3, 21)
+		v3 ^= v0
+		v2 += v1
+		v1 = bits.RotateLeft64(v1, 17)
+		v1 ^= v2
+		v2 = bits.RotateLeft64(v2, 32)
+	}
+
+	b := uint64(len(msg)) << 56
+	for ; len(msg) >= 8; msg = msg[8:] {
+		m := binary.LittleEndian.Uint64(msg)
+		v3 ^= m
+		round()
+		round()
+		v0 ^= m
+	}
+	for i, c := range msg {
+		b |= uint64(c) << (8 * i)
+	}
+	v3 ^= b
+	round()
+	round()
+	v0 ^= b
+
+	v2 ^= 0xff
+	round()
+	round()
+	round()
+	round()
+	return v0 ^ v1 ^ v2 ^ v3
+}
@@ -0,0 +1,102 @@
+package uuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var maskTestKey = MaskKey{0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f}
+
+func TestSipHash24(t *testing.T) {
+	// Test vectors of the SipHash reference implementation, with the key
+	// 00 01 ... 0f and the message 00 01 ... of the given length.
+	tests := []struct {
+		n    int
+		want uint64
+	}{
+		{0, 0x726fdb47dd0e0e31},
+		{15, 0xa129ca6149be45e5},
+	}
+	const k0, k1 = 0x0706050403020100, 0x0f0e0d0c0b0a0908
+	for _, tt := range tests {
+		msg := make([]byte, tt.n)
+		for i := range msg {
+			msg[i] = byte(i)
+		}
+		if got := sipHash24(k0, k1, msg); got != tt.want {
+			t.Errorf("sipHash24(%d bytes) = %#x, want %#x", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestMask(t *testing.T) {
+	g := NewGen()
+	for i := 0; i < 100; i++ {
+		u, err := g.NewV7()
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := u.Mask(maskTestKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.Version() != V4 || m.Variant() != VariantRFC9562 {
+			t.Fatalf("%v.Mask() = %v, want a version 4 UUID", u, m)
+		}
+		if m == u || [6]byte{m[0], m[1], m[2], m[3], m[4], m[5]} == [6]byte{u[0], u[1], u[2], u[3], u[4], u[5]} {
+			t.Fatalf("%v.Mask() = %v, timestamp not masked", u, m)
+		}
+		for j := 7; j < Size; j++ {
+			if j != 8 && m[j] != u[j] {
+				t.Fatalf("%v.Mask() = %v, random bits changed", u, m)
+			}
+		}
+		got, err := m.Unmask(maskTestKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != u {
+			t.Fatalf("%v.Unmask() = %v, want %v", m, got, u)
+		}
+
+		other := maskTestKey
+		other[0] ^= 1
+		if got, _ := m.Unmask(other); got == u {
+			t.Fatalf("%v.Unmask() with another key = %v", m, got)
+		}
+	}
+}
+
+func TestMaskTimestamp(t *testing.T) {
+	at := time.Date(2025, 1, 2, 3, 4, 5, 6000000, time.UTC)
+	g := NewGenWithOptions(WithEpochFunc(func() time.Time { return at }))
+	u, err := g.NewV7()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, _ := u.Mask(maskTestKey)
+	got, _ := m.Unmask(maskTestKey)
+	ts, err := TimestampFromV7(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tm, _ := ts.Time(); !tm.Equal(at) {
+		t.Errorf("unmasked timestamp = %v, want %v", tm, at)
+	}
+}
+
+func TestMaskErrors(t *testing.T) {
+	v4 := Must(NewV4())
+	v7 := Must(NewV7())
+	for _, u := range []UUID{v4, Nil, Max, Must(NewV1())} {
+		if _, err := u.Mask(maskTestKey); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("%v.Mask() error = %v, want %v", u, err, ErrInvalidVersion)
+		}
+	}
+	for _, u := range []UUID{v7, Nil, Max, Must(NewV1())} {
+		if _, err := u.Unmask(maskTestKey); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("%v.Unmask() error = %v, want %v", u, err, ErrInvalidVersion)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package uuid
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// MigrateFormat is the encoding of the UUID streams read and written by a
+// Migrator.
+type MigrateFormat byte
+
+const (
+	// MigrateText reads one UUID per line, in any form accepted by
+	// FromString; blank lines are skipped. Each mapping is written as a line
+	// holding the old and new UUIDs in canonical form, separated by a comma.
+	MigrateText MigrateFormat = iota
+
+	// MigrateBinary reads consecutive 16-byte UUIDs. Each mapping is written
+	// as the 16 bytes of the old UUID followed by the 16 bytes of the new one.
+	MigrateBinary
+)
+
+// Migrator derives version 7 UUIDs from version 1 and 6 UUIDs, keeping their
+// embedded timestamps (truncated to the millisecond precision of version 7),
+// so that existing identifiers can be re-keyed into a k-sortable form. The
+// random bits of each new UUID come from the Migrator's Generator, so the
+// mapping is not reproducible and must be recorded; Run writes it out as it
+// goes.
+type Migrator struct {
+	gen    Generator
+	format MigrateFormat
+}
+
+// NewMigrator returns a Migrator reading and writing streams in format and
+// generating UUIDs with gen. If gen is nil, the generator of V7 UUIDs of
+// the package-level constructors, VersionGenerator(V7), is used.
+func NewMigrator(gen Generator, format MigrateFormat) *Migrator {
+	if gen == nil {
+		gen = VersionGenerator(V7)
+	}
+	return &Migrator{gen: gen, format: format}
+}
+
+// Migrate returns a version 7 UUID with the timestamp of u. It will return an
+// error if u is not a version 1 or 6 UUID.
+func (m *Migrator) Migrate(u UUID) (UUID, error) {
+	if v := u.Version(); v != V1 && v != V6 {
+		return Nil, fmt.Errorf("%w %s is version %d, not version 1 or 6", ErrInvalidVersion, u, v)
+	}
+	t, err := u.Time()
+	if err != nil {
+		return Nil, err
+	}
+	return m.gen.NewV7AtTime(t)
+}
+
+// Run reads UUIDs from src, migrates each of them and writes the old→new
+// mappings to dst, in input order. It returns the number of UUIDs migrated.
+// It stops at the first invalid or unsupported UUID, returning an error that
+// includes its position in the stream; mappings written before it are
+// flushed to dst.
+func (m *Migrator) Run(dst io.Writer, src io.Reader) (n int, err error) {
+	w := bufio.NewWriter(dst)
+	defer func() {
+		if ferr := w.Flush(); err == nil {
+			err = ferr
+		}
+	}()
+
+	switch m.format {
+	case MigrateText:
+		return m.runText(w, src)
+	case MigrateBinary:
+		return m.runBinary(w, src)
+	}
+	return 0, fmt.Errorf("%w: unknown migrate format %d", ErrInvalidArgument, m.format)
+}
+
+func (m *Migrator) runText(w *bufio.Writer, src io.Reader) (int, error) {
+	s := bufio.NewScanner(src)
+	buf := make([]byte, 0, 2*36+2)
+	n := 0
+	for line := 1; s.Scan(); line++ {
+		text := bytes.TrimSpace(s.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		old, err := ParseBytes(text)
+		if err != nil {
+			return n, fmt.Errorf("uuid: migrate line %d: %w", line, err)
+		}
+		u, err := m.Migrate(old)
+		if err != nil {
+			return n, fmt.Errorf("uuid: migrate line %d: %w", line, err)
+		}
+		buf = old.AppendFormat(buf[:0], FormatCanonical)
+		buf = append(buf, ',')
+		buf = u.AppendFormat(buf, FormatCanonical)
+		buf = append(buf, '\n')
+		if _, err := w.Write(buf); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, s.Err()
+}
+
+func (m *Migrator) runBinary(w *bufio.Writer, src io.Reader) (int, error) {
+	r := bufio.NewReader(src)
+	var pair [2 * Size]byte
+	n := 0
+	for {
+		if _, err := io.ReadFull(r, pair[:Size]); err != nil {
+			if err == io.EOF {
+				return n, nil
+			}
+			return n, err
+		}
+		var old UUID
+		copy(old[:], pair[:Size])
+		u, err := m.Migrate(old)
+		if err != nil {
+			return n, fmt.Errorf("uuid: migrate UUID %d: %w", n+1, err)
+		}
+		copy(pair[Size:], u[:])
+		if _, err := w.Write(pair[:]); err != nil {
+			return n, err
+		}
+		n++
+	}
+}
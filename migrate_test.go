@@ -0,0 +1,157 @@
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMigratorMigrate(t *testing.T) {
+	at := time.Date(2022, 2, 22, 19, 22, 22, 123456700, time.UTC)
+	g := NewGen()
+	m := NewMigrator(NewGenWithOptions(WithCustomPRNG(1)), MigrateText)
+	for _, old := range []UUID{Must(g.NewV1AtTime(at)), Must(g.NewV6AtTime(at))} {
+		u, err := m.Migrate(old)
+		if err != nil {
+			t.Fatalf("Migrate(%v) unexpected error: %v", old, err)
+		}
+		if got := u.Version(); got != V7 {
+			t.Errorf("Migrate(%v) = %v, version %d, want %d", old, u, got, V7)
+		}
+		got, err := u.Time()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := at.Truncate(time.Millisecond); !got.Equal(want) {
+			t.Errorf("Migrate(%v) = %v, time %v, want %v", old, u, got, want)
+		}
+	}
+
+	for _, old := range []UUID{Nil, Must(NewV4()), Must(NewV7())} {
+		if _, err := m.Migrate(old); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("Migrate(%v) error = %v, want %v", old, err, ErrInvalidVersion)
+		}
+	}
+}
+
+func TestMigratorRun(t *testing.T) {
+	g := NewGen()
+	base := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	olds := make([]UUID, 50)
+	for i := range olds {
+		at := base.Add(time.Duration(i) * time.Hour)
+		if i%2 == 0 {
+			olds[i] = Must(g.NewV1AtTime(at))
+		} else {
+			olds[i] = Must(g.NewV6AtTime(at))
+		}
+	}
+
+	check := func(t *testing.T, got [][2]UUID) {
+		t.Helper()
+		if len(got) != len(olds) {
+			t.Fatalf("got %d mappings, want %d", len(got), len(olds))
+		}
+		for i, pair := range got {
+			if pair[0] != olds[i] {
+				t.Errorf("mapping %d old = %v, want %v", i, pair[0], olds[i])
+			}
+			if pair[1].Version() != V7 || !sameMillisecond(pair[0], pair[1]) {
+				t.Errorf("mapping %d new = %v, does not match the time of %v", i, pair[1], pair[0])
+			}
+		}
+	}
+
+	t.Run("Text", func(t *testing.T) {
+		var in strings.Builder
+		for i, u := range olds {
+			if i%10 == 0 {
+				in.WriteString("\n")
+			}
+			fmt.Fprintf(&in, "%s\n", u.AppendFormat(nil, Format(i%4)))
+		}
+		var out bytes.Buffer
+		n, err := NewMigrator(nil, MigrateText).Run(&out, strings.NewReader(in.String()))
+		if err != nil {
+			t.Fatalf("Run() unexpected error: %v", err)
+		}
+		if n != len(olds) {
+			t.Errorf("Run() = %d, want %d", n, len(olds))
+		}
+		var got [][2]UUID
+		for _, line := range strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n") {
+			fields := strings.Split(line, ",")
+			if len(fields) != 2 {
+				t.Fatalf("malformed mapping line %q", line)
+			}
+			got = append(got, [2]UUID{Must(FromString(fields[0])), Must(FromString(fields[1]))})
+		}
+		check(t, got)
+	})
+
+	t.Run("Binary", func(t *testing.T) {
+		var in bytes.Buffer
+		for _, u := range olds {
+			in.Write(u[:])
+		}
+		var out bytes.Buffer
+		n, err := NewMigrator(nil, MigrateBinary).Run(&out, &in)
+		if err != nil {
+			t.Fatalf("Run() unexpected error: %v", err)
+		}
+		if n != len(olds) {
+			t.Errorf("Run() = %d, want %d", n, len(olds))
+		}
+		if out.Len() != len(olds)*2*Size {
+			t.Fatalf("Run() wrote %d bytes, want %d", out.Len(), len(olds)*2*Size)
+		}
+		var got [][2]UUID
+		for b := out.Bytes(); len(b) > 0; b = b[2*Size:] {
+			got = append(got, [2]UUID{Must(FromBytes(b[:Size])), Must(FromBytes(b[Size : 2*Size]))})
+		}
+		check(t, got)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		v1 := Must(g.NewV1())
+		v4 := Must(NewV4())
+		tests := []struct {
+			name   string
+			format MigrateFormat
+			in     []byte
+			n      int
+			err    error
+		}{
+			{"TextInvalid", MigrateText, []byte(v1.String() + "\n6ba7b810-9dad-11d1-80b4-00c04fd430zz\n"), 1, ErrInvalidFormat},
+			{"TextVersion", MigrateText, []byte(v1.String() + "\n" + v4.String() + "\n"), 1, ErrInvalidVersion},
+			{"BinaryVersion", MigrateBinary, append(v1.Bytes(), v4.Bytes()...), 1, ErrInvalidVersion},
+			{"BinaryTruncated", MigrateBinary, append(v1.Bytes(), v1[:5]...), 1, io.ErrUnexpectedEOF},
+			{"Format", MigrateFormat(42), v1.Bytes(), 0, ErrInvalidArgument},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var out bytes.Buffer
+				n, err := NewMigrator(nil, tt.format).Run(&out, bytes.NewReader(tt.in))
+				if !errors.Is(err, tt.err) {
+					t.Errorf("Run() error = %v, want %v", err, tt.err)
+				}
+				if n != tt.n {
+					t.Errorf("Run() = %d, want %d", n, tt.n)
+				}
+				if tt.n > 0 && out.Len() == 0 {
+					t.Error("Run() did not flush the mappings written before the error")
+				}
+			})
+		}
+	})
+}
+
+func sameMillisecond(a, b UUID) bool {
+	ta, _ := a.Time()
+	tb, _ := b.Time()
+	return ta.Truncate(time.Millisecond).Equal(tb)
+}
@@ -0,0 +1,83 @@
+package uuid
+
+import (
+	"fmt"
+	"time"
+)
+
+// MonotonicChecker verifies that a stream of version 6 and 7 UUIDs, such as
+// a feed claiming ordered IDs or the output of a generator, is k-sortable.
+//
+// By default the order is strict: each UUID must sort after the previous one
+// by CompareByTime, which is the byte order of UUIDs of the same version. If
+// Loose is true, the timestamp of each UUID must only be at least the
+// timestamp of the previous one, so that UUIDs generated in the same
+// millisecond, such as version 7 UUIDs without a counter, may be in any
+// order, and repeated.
+//
+// The zero value is a strict checker of an empty stream. A MonotonicChecker
+// is not safe for concurrent use.
+type MonotonicChecker struct {
+	Loose bool
+
+	prev    UUID
+	prevTS  Timestamp
+	hasPrev bool
+	n       int // index of the next UUID
+}
+
+// Check checks u against the previous UUID of the stream. It returns an
+// error wrapping ErrNotMonotonic for the first UUID out of order, with the
+// timestamps of both UUIDs, ErrInvalidVariant for a UUID that is not of the
+// RFC 9562 variant, and ErrInvalidVersion for a UUID that is not of version
+// 6 or 7. A UUID in error is not recorded, so that Check can go on
+// with the next UUIDs of the stream.
+func (c *MonotonicChecker) Check(u UUID) error {
+	i := c.n
+	c.n++
+	ts, ok := timestampOf(u)
+	if !ok || u.Version() == V1 {
+		if u.Variant() != VariantRFC9562 {
+			return fmt.Errorf("%w: %s at index %d has variant %d, not the RFC 9562 variant", ErrInvalidVariant, u, i, u.Variant())
+		}
+		return fmt.Errorf("%w %s at index %d is version %d, not version 6 or 7", ErrInvalidVersion, u, i, u.Version())
+	}
+	if c.hasPrev {
+		var inOrder bool
+		if c.Loose {
+			inOrder = ts >= c.prevTS
+		} else {
+			inOrder = CompareByTime(c.prev, u) < 0
+		}
+		if !inOrder {
+			prevTime, _ := c.prevTS.Time()
+			t, _ := ts.Time()
+			return fmt.Errorf("%w: %s (%s) at index %d after %s (%s)", ErrNotMonotonic,
+				u, t.UTC().Format(time.RFC3339Nano), i, c.prev, prevTime.UTC().Format(time.RFC3339Nano))
+		}
+	}
+	c.prev, c.prevTS, c.hasPrev = u, ts, true
+	return nil
+}
+
+// CheckMonotonic verifies that seq is strictly k-sortable, as checked by a
+// MonotonicChecker, and returns the error of its first UUID out of order.
+func CheckMonotonic(seq []UUID) error {
+	return checkMonotonic(&MonotonicChecker{}, seq)
+}
+
+// CheckMonotonicLoose verifies that the timestamps of seq never decrease,
+// as checked by a loose MonotonicChecker, and returns the error of its first
+// UUID out of order.
+func CheckMonotonicLoose(seq []UUID) error {
+	return checkMonotonic(&MonotonicChecker{Loose: true}, seq)
+}
+
+func checkMonotonic(c *MonotonicChecker, seq []UUID) error {
+	for _, u := range seq {
+		if err := c.Check(u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package uuid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckMonotonic(t *testing.T) {
+	// Version 7 UUIDs of 2023-11-14T22:13:20.123Z and 1 ms later.
+	a := Must(FromString("018bcfe5-687b-7000-8000-000000000001"))
+	b := Must(FromString("018bcfe5-687b-7000-8000-000000000002"))
+	c := Must(FromString("018bcfe5-687c-7000-8000-000000000000"))
+	v6 := Must(FromString("1ec9414c-232a-6b00-b3c8-9f6bdeced846"))
+
+	if err := CheckMonotonic(nil); err != nil {
+		t.Errorf("CheckMonotonic(nil) = %v", err)
+	}
+	tests := []struct {
+		seq        []UUID
+		strict     error
+		loose      error
+		strictText string
+	}{
+		{[]UUID{a, b, c}, nil, nil, ""},
+		{[]UUID{v6, a, c}, nil, nil, ""},
+		{[]UUID{b, a, c}, ErrNotMonotonic, nil, "at index 1 after"},
+		{[]UUID{a, a}, ErrNotMonotonic, nil, "at index 1 after"},
+		{[]UUID{a, c, b}, ErrNotMonotonic, ErrNotMonotonic, "(2023-11-14T22:13:20.123Z) at index 2 after 018bcfe5-687c-7000-8000-000000000000 (2023-11-14T22:13:20.124Z)"},
+		{[]UUID{a, v6}, ErrNotMonotonic, ErrNotMonotonic, "(2022-02-22T19:22:22Z) at index 1"},
+		{[]UUID{a, NamespaceDNS}, ErrInvalidVersion, ErrInvalidVersion, "at index 1 is version 1"},
+		{[]UUID{Max}, ErrInvalidVariant, ErrInvalidVariant, "has variant 3"},
+	}
+	for _, tt := range tests {
+		err := CheckMonotonic(tt.seq)
+		if !errors.Is(err, tt.strict) || (tt.strict == nil && err != nil) {
+			t.Errorf("CheckMonotonic(%v) = %v, want %v", tt.seq, err, tt.strict)
+		} else if err != nil && !strings.Contains(err.Error(), tt.strictText) {
+			t.Errorf("CheckMonotonic(%v) = %v, want an error containing %q", tt.seq, err, tt.strictText)
+		}
+		if err := CheckMonotonicLoose(tt.seq); !errors.Is(err, tt.loose) || (tt.loose == nil && err != nil) {
+			t.Errorf("CheckMonotonicLoose(%v) = %v, want %v", tt.seq, err, tt.loose)
+		}
+	}
+}
+
+func TestMonotonicChecker(t *testing.T) {
+	// The 12-bit counter of the V7 UUIDs of a Gen may wrap within a
+	// millisecond, unlike that of a MonotonicGen.
+	g := NewMonotonicGen()
+	var c MonotonicChecker
+	for i := 0; i < 1000; i++ {
+		if err := c.Check(Must(g.NewV7())); err != nil {
+			t.Fatalf("Check(NewV7()) = %v", err)
+		}
+	}
+
+	// A UUID out of order is not recorded.
+	c = MonotonicChecker{}
+	u1, u2 := Must(g.NewV7()), Must(g.NewV7())
+	if err := c.Check(u2); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Check(u1); !errors.Is(err, ErrNotMonotonic) {
+		t.Errorf("Check(%v) after %v = %v, want %v", u1, u2, err, ErrNotMonotonic)
+	}
+	u3 := Must(g.NewV7())
+	if err := c.Check(u3); err != nil {
+		t.Errorf("Check(%v) after %v = %v", u3, u2, err)
+	}
+	if err := c.Check(u2); err == nil || !strings.Contains(err.Error(), "at index 3 ") {
+		t.Errorf("Check(%v) after %v = %v, want an error at index 3", u2, u3, err)
+	}
+}
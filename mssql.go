@@ -0,0 +1,105 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sort"
+)
+
+var (
+	_ driver.Valuer = MSSQLUUID{}
+	_ sql.Scanner   = (*MSSQLUUID)(nil)
+)
+
+// mssqlOrder lists the bytes of a UUID in the order of their significance in
+// SQL Server comparisons of uniqueidentifier values: the last six bytes come
+// first, and the first four last.
+var mssqlOrder = [Size]int{10, 11, 12, 13, 14, 15, 8, 9, 7, 6, 5, 4, 3, 2, 1, 0}
+
+// MSSQLUUID is a UUID stored in a SQL Server uniqueidentifier column.
+//
+// SQL Server stores the first three groups of a uniqueidentifier in
+// little-endian byte order, and its drivers send and return the stored
+// bytes: the Value and Scan methods convert the UUID to and from this
+// layout. SQL Server also sorts uniqueidentifier values by their last group
+// first, and the Compare method follows this order, so that pagination keyed
+// on a uniqueidentifier column can be computed on the client.
+type MSSQLUUID UUID
+
+// MSSQLUUIDFromBytes returns the UUID of 16 bytes in the layout stored by
+// SQL Server. It returns an error if b is not 16 bytes long.
+func MSSQLUUIDFromBytes(b []byte) (MSSQLUUID, error) {
+	if len(b) != Size {
+		return MSSQLUUID{}, fmt.Errorf("%w, got %d bytes", ErrIncorrectByteLength, len(b))
+	}
+	return MSSQLUUID{
+		b[3], b[2], b[1], b[0],
+		b[5], b[4],
+		b[7], b[6],
+		b[8], b[9], b[10], b[11], b[12], b[13], b[14], b[15],
+	}, nil
+}
+
+// MSSQLBytes returns the 16 bytes of the UUID in the layout stored by SQL
+// Server.
+func (u MSSQLUUID) MSSQLBytes() []byte {
+	return []byte{
+		u[3], u[2], u[1], u[0],
+		u[5], u[4],
+		u[7], u[6],
+		u[8], u[9], u[10], u[11], u[12], u[13], u[14], u[15],
+	}
+}
+
+// Compare returns an integer comparing u and v in the order of SQL Server
+// uniqueidentifier values. The result is 0 if u == v, -1 if u sorts before v
+// and +1 if u sorts after v.
+func (u MSSQLUUID) Compare(v MSSQLUUID) int {
+	for _, i := range mssqlOrder {
+		switch {
+		case u[i] < v[i]:
+			return -1
+		case u[i] > v[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// SortMSSQL sorts s in ascending order of SQL Server uniqueidentifier values.
+func SortMSSQL(s []MSSQLUUID) {
+	sort.Slice(s, func(i, j int) bool { return s[i].Compare(s[j]) < 0 })
+}
+
+// Value implements the driver.Valuer interface, returning the bytes stored
+// by SQL Server.
+func (u MSSQLUUID) Value() (driver.Value, error) {
+	return u.MSSQLBytes(), nil
+}
+
+// Scan implements the sql.Scanner interface. A 16-byte slice holds the
+// bytes stored by SQL Server, and other slices and strings any text form
+// accepted by UnmarshalText.
+func (u *MSSQLUUID) Scan(src interface{}) error {
+	if b, ok := src.([]byte); ok && len(b) == Size {
+		*u, _ = MSSQLUUIDFromBytes(b)
+		return nil
+	}
+	return (*UUID)(u).Scan(src)
+}
+
+// String returns the canonical string representation of the UUID.
+func (u MSSQLUUID) String() string {
+	return UUID(u).String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u MSSQLUUID) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (u *MSSQLUUID) UnmarshalText(b []byte) error {
+	return (*UUID)(u).UnmarshalText(b)
+}
@@ -0,0 +1,99 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+// mssqlTestData holds the bytes of codecTestUUID as stored by SQL Server.
+var mssqlTestData = []byte{0x10, 0xb8, 0xa7, 0x6b, 0xad, 0x9d, 0xd1, 0x11, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+func TestMSSQLBytes(t *testing.T) {
+	if got := MSSQLUUID(codecTestUUID).MSSQLBytes(); !bytes.Equal(got, mssqlTestData) {
+		t.Errorf("MSSQLUUID(%v).MSSQLBytes() = %x, want %x", codecTestUUID, got, mssqlTestData)
+	}
+	got, err := MSSQLUUIDFromBytes(mssqlTestData)
+	if err != nil || UUID(got) != codecTestUUID {
+		t.Errorf("MSSQLUUIDFromBytes(%x) = %v, %v, want %v", mssqlTestData, got, err, codecTestUUID)
+	}
+	if _, err := MSSQLUUIDFromBytes(mssqlTestData[:8]); !errors.Is(err, ErrIncorrectByteLength) {
+		t.Errorf("MSSQLUUIDFromBytes(8 bytes) error = %v, want %v", err, ErrIncorrectByteLength)
+	}
+}
+
+func TestMSSQLUUIDCompare(t *testing.T) {
+	// The order in which SQL Server returns these uniqueidentifier values
+	// from an ORDER BY clause.
+	want := []string{
+		"00000000-0000-0000-0000-000000000000",
+		"01000000-0000-0000-0000-000000000000",
+		"00010000-0000-0000-0000-000000000000",
+		"00000100-0000-0000-0000-000000000000",
+		"00000001-0000-0000-0000-000000000000",
+		"00000000-0100-0000-0000-000000000000",
+		"00000000-0001-0000-0000-000000000000",
+		"00000000-0000-0100-0000-000000000000",
+		"00000000-0000-0001-0000-000000000000",
+		"00000000-0000-0000-0001-000000000000",
+		"00000000-0000-0000-0100-000000000000",
+		"00000000-0000-0000-0000-000000000001",
+		"00000000-0000-0000-0000-000000000100",
+		"00000000-0000-0000-0000-000000010000",
+		"00000000-0000-0000-0000-000001000000",
+		"00000000-0000-0000-0000-000100000000",
+		"00000000-0000-0000-0000-010000000000",
+	}
+	s := make([]MSSQLUUID, len(want))
+	for i := range want {
+		s[len(s)-1-i] = MSSQLUUID(Must(FromString(want[i])))
+	}
+	SortMSSQL(s)
+	for i := range s {
+		if got := s[i].String(); got != want[i] {
+			t.Errorf("SortMSSQL()[%d] = %s, want %s", i, got, want[i])
+		}
+	}
+
+	u := MSSQLUUID(codecTestUUID)
+	if u.Compare(u) != 0 {
+		t.Errorf("%v.Compare(%v) != 0", u, u)
+	}
+	a, b := MSSQLUUID(Must(FromString(want[4]))), MSSQLUUID(Must(FromString(want[5])))
+	if a.Compare(b) != -1 || b.Compare(a) != 1 {
+		t.Errorf("%v.Compare(%v) = %d, want -1", a, b, a.Compare(b))
+	}
+}
+
+func TestMSSQLUUID(t *testing.T) {
+	v, err := MSSQLUUID(codecTestUUID).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, ok := v.([]byte); !ok || !bytes.Equal(b, mssqlTestData) {
+		t.Errorf("MSSQLUUID(%v).Value() = %#v, want %x", codecTestUUID, v, mssqlTestData)
+	}
+
+	for _, src := range []interface{}{mssqlTestData, codecTestUUID.String(), []byte(codecTestUUID.String()), codecTestUUID} {
+		var got MSSQLUUID
+		if err := got.Scan(src); err != nil {
+			t.Errorf("MSSQLUUID.Scan(%#v) unexpected error: %v", src, err)
+		}
+		if UUID(got) != codecTestUUID {
+			t.Errorf("MSSQLUUID.Scan(%#v) = %v, want %v", src, got, codecTestUUID)
+		}
+	}
+	var u MSSQLUUID
+	if err := u.Scan(42); err == nil {
+		t.Error("MSSQLUUID.Scan(42) succeeded, want error")
+	}
+
+	data, err := json.Marshal(MSSQLUUID(codecTestUUID))
+	if err != nil || string(data) != `"`+codecTestUUID.String()+`"` {
+		t.Errorf("json.Marshal(MSSQLUUID(%v)) = %s, %v", codecTestUUID, data, err)
+	}
+	if err := json.Unmarshal(data, &u); err != nil || UUID(u) != codecTestUUID {
+		t.Errorf("json.Unmarshal(%s) = %v, %v, want %v", data, u, err, codecTestUUID)
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !uuid_fips
+
+package uuid
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"hash"
+)
+
+// FIPS reports whether the package was built with the uuid_fips build tag,
+// which removes the MD5 and SHA-1 name-based UUIDs of versions 3 and 5.
+const FIPS = false
+
+func newMD5() hash.Hash {
+	return md5.New()
+}
+
+func newSHA1() hash.Hash {
+	return sha1.New()
+}
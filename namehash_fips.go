@@ -0,0 +1,17 @@
+//go:build uuid_fips
+
+package uuid
+
+import "hash"
+
+// FIPS reports whether the package was built with the uuid_fips build tag,
+// which removes the MD5 and SHA-1 name-based UUIDs of versions 3 and 5.
+const FIPS = true
+
+func newMD5() hash.Hash {
+	panic(ErrHashDisabled)
+}
+
+func newSHA1() hash.Hash {
+	panic(ErrHashDisabled)
+}
@@ -0,0 +1,37 @@
+//go:build uuid_fips
+
+package uuid
+
+import (
+	"strings"
+	"testing"
+)
+
+// The other tests of the MD5 and SHA-1 name-based UUIDs are skipped with the
+// uuid_fips build tag.
+func TestFIPS(t *testing.T) {
+	if !FIPS {
+		t.Error("FIPS = false with the uuid_fips build tag")
+	}
+	for name, f := range map[string]func(){
+		"NewV3":           func() { NewV3(NamespaceDNS, "www.example.com") },
+		"NewV5":           func() { NewV5(NamespaceDNS, "www.example.com") },
+		"Gen.NewV3":       func() { NewGen().NewV3(NamespaceDNS, "www.example.com") },
+		"Gen.NewV5":       func() { NewGen().NewV5(NamespaceDNS, "www.example.com") },
+		"NewV3Bytes":      func() { NewV3Bytes(NamespaceDNS, []byte("www.example.com")) },
+		"NewV5Bytes":      func() { NewV5Bytes(NamespaceDNS, []byte("www.example.com")) },
+		"Gen.NewV3Bytes":  func() { NewGen().NewV3Bytes(NamespaceDNS, []byte("www.example.com")) },
+		"Gen.NewV5Bytes":  func() { NewGen().NewV5Bytes(NamespaceDNS, []byte("www.example.com")) },
+		"NewV5Multi":      func() { NewV5Multi(NamespaceDNS, "www", "example", "com") },
+		"NewV5FromReader": func() { NewV5FromReader(NamespaceDNS, strings.NewReader("www.example.com")) },
+	} {
+		func() {
+			defer func() {
+				if r := recover(); r != ErrHashDisabled {
+					t.Errorf("%s panicked with %v, want %v", name, r, ErrHashDisabled)
+				}
+			}()
+			f()
+		}()
+	}
+}
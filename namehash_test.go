@@ -0,0 +1,17 @@
+//go:build !uuid_fips
+
+package uuid
+
+import "testing"
+
+func TestFIPS(t *testing.T) {
+	if FIPS {
+		t.Error("FIPS = true without the uuid_fips build tag")
+	}
+	if got := NewV3(NamespaceDNS, "www.example.com"); got.Version() != V3 {
+		t.Errorf("NewV3() = %v, want a version 3 UUID", got)
+	}
+	if got := NewV5(NamespaceDNS, "www.example.com"); got.Version() != V5 {
+		t.Errorf("NewV5() = %v, want a version 5 UUID", got)
+	}
+}
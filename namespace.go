@@ -0,0 +1,94 @@
+package uuid
+
+import (
+	"fmt"
+	"sync"
+)
+
+// NewNamespace returns the UUID of the custom namespace identified by name,
+// a domain name owned by the application, such as "orders.example.com". It
+// is the version 8 UUID of the SHA-256 hash of name in NamespaceDNS, as
+// RFC-9562 Section 6.6 recommends deriving new namespaces from a name under
+// the control of their owner, so that it is the same wherever it is
+// computed and does not collide with the namespaces of others.
+func NewNamespace(name string) UUID {
+	return NewV8SHA256(NamespaceDNS, name)
+}
+
+// NamespaceRegistry maps names to namespace UUIDs, registered once, usually
+// in package variables, so that the UUIDs of namespaces are declared in one
+// place rather than copied as literals. Registering a name or a UUID twice
+// for different namespaces is an error, catching typos and copy-paste
+// mistakes when the program starts.
+//
+// A NamespaceRegistry is safe for concurrent use. The zero value is not
+// usable; create registries with NewNamespaceRegistry.
+type NamespaceRegistry struct {
+	mu     sync.RWMutex
+	byName map[string]UUID
+	byUUID map[UUID]string
+}
+
+// NewNamespaceRegistry returns a registry holding the namespaces predefined
+// by RFC-9562 under the names "dns", "url", "oid" and "x500".
+func NewNamespaceRegistry() *NamespaceRegistry {
+	r := &NamespaceRegistry{
+		byName: make(map[string]UUID),
+		byUUID: make(map[UUID]string),
+	}
+	r.MustRegister("dns", NamespaceDNS)
+	r.MustRegister("url", NamespaceURL)
+	r.MustRegister("oid", NamespaceOID)
+	r.MustRegister("x500", NamespaceX500)
+	return r
+}
+
+// Register registers the namespace ns under name. Registering the same name
+// and UUID again has no effect. It returns ErrNamespaceConflict if name is
+// registered with another UUID, or ns under another name, and
+// ErrInvalidArgument if name is empty or ns is Nil.
+func (r *NamespaceRegistry) Register(name string, ns UUID) error {
+	if name == "" || ns.IsNil() {
+		return fmt.Errorf("%w: empty namespace name or Nil namespace", ErrInvalidArgument)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if u, ok := r.byName[name]; ok && u != ns {
+		return fmt.Errorf("%w: name %q is registered for %v", ErrNamespaceConflict, name, u)
+	}
+	if n, ok := r.byUUID[ns]; ok && n != name {
+		return fmt.Errorf("%w: %v is registered as %q", ErrNamespaceConflict, ns, n)
+	}
+	r.byName[name] = ns
+	r.byUUID[ns] = name
+	return nil
+}
+
+// MustRegister is like Register but panics on error, and returns ns. It
+// simplifies the declaration of namespaces in package variables:
+//
+//	var NamespaceOrders = registry.MustRegister("orders", uuid.NewNamespace("orders.example.com"))
+func (r *NamespaceRegistry) MustRegister(name string, ns UUID) UUID {
+	if err := r.Register(name, ns); err != nil {
+		panic(err)
+	}
+	return ns
+}
+
+// Lookup returns the namespace registered under name, and whether it is
+// registered.
+func (r *NamespaceRegistry) Lookup(name string) (UUID, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ns, ok := r.byName[name]
+	return ns, ok
+}
+
+// Name returns the name under which ns is registered, and whether it is
+// registered.
+func (r *NamespaceRegistry) Name(ns UUID) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	name, ok := r.byUUID[ns]
+	return name, ok
+}
@@ -0,0 +1,80 @@
+package uuid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewNamespace(t *testing.T) {
+	ns := NewNamespace("orders.example.com")
+	if got := NewNamespace("orders.example.com"); got != ns {
+		t.Errorf("NewNamespace(orders.example.com) = %v then %v", ns, got)
+	}
+	if got := NewNamespace("users.example.com"); got == ns {
+		t.Errorf("NewNamespace returned %v for different names", got)
+	}
+	if want := NewV8SHA256(NamespaceDNS, "orders.example.com"); ns != want {
+		t.Errorf("NewNamespace(orders.example.com) = %v, want %v", ns, want)
+	}
+	if err := ns.Validate(); err != nil {
+		t.Errorf("NewNamespace(orders.example.com).Validate() = %v", err)
+	}
+}
+
+func TestNamespaceRegistry(t *testing.T) {
+	r := NewNamespaceRegistry()
+	for name, want := range map[string]UUID{
+		"dns":  NamespaceDNS,
+		"url":  NamespaceURL,
+		"oid":  NamespaceOID,
+		"x500": NamespaceX500,
+	} {
+		if ns, ok := r.Lookup(name); !ok || ns != want {
+			t.Errorf("Lookup(%q) = %v, %t, want %v, true", name, ns, ok, want)
+		}
+		if got, ok := r.Name(want); !ok || got != name {
+			t.Errorf("Name(%v) = %q, %t, want %q, true", want, got, ok, name)
+		}
+	}
+
+	orders := r.MustRegister("orders", NewNamespace("orders.example.com"))
+	if ns, ok := r.Lookup("orders"); !ok || ns != orders {
+		t.Errorf("Lookup(orders) = %v, %t, want %v, true", ns, ok, orders)
+	}
+	if err := r.Register("orders", orders); err != nil {
+		t.Errorf("Register(orders) again: unexpected error: %v", err)
+	}
+	if ns, ok := r.Lookup("users"); ok || !ns.IsNil() {
+		t.Errorf("Lookup(users) = %v, %t, want Nil, false", ns, ok)
+	}
+	if name, ok := r.Name(NewNamespace("users.example.com")); ok || name != "" {
+		t.Errorf("Name() of unregistered namespace = %q, %t, want \"\", false", name, ok)
+	}
+
+	tests := []struct {
+		name string
+		ns   UUID
+		want error
+	}{
+		{"orders", NewNamespace("order.example.com"), ErrNamespaceConflict},
+		{"order", orders, ErrNamespaceConflict},
+		{"dns", NamespaceURL, ErrNamespaceConflict},
+		{"", NewNamespace("users.example.com"), ErrInvalidArgument},
+		{"users", Nil, ErrInvalidArgument},
+	}
+	for _, tt := range tests {
+		if err := r.Register(tt.name, tt.ns); !errors.Is(err, tt.want) {
+			t.Errorf("Register(%q, %v) error = %v, want %v", tt.name, tt.ns, err, tt.want)
+		}
+	}
+	if ns, _ := r.Lookup("orders"); ns != orders {
+		t.Errorf("Lookup(orders) = %v after conflicting Register, want %v", ns, orders)
+	}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, ErrNamespaceConflict) {
+			t.Errorf("MustRegister panicked with %v, want %v", err, ErrNamespaceConflict)
+		}
+	}()
+	r.MustRegister("order", orders)
+}
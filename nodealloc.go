@@ -0,0 +1,122 @@
+package uuid
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// NodeLease is a node ID leased by a NodeAllocator until its expiry.
+type NodeLease struct {
+	// ID is the node ID, unique among the unexpired leases of the
+	// allocator.
+	ID uint64
+
+	// Token identifies the lease for the allocator, such as the ID of an
+	// etcd lease or of a Consul session.
+	Token uint64
+
+	// Expiry is the time the lease expires, unless renewed.
+	Expiry time.Time
+}
+
+// NodeAllocator leases node IDs, so that the generators of a fleet can use
+// distinct nodes without configuring each of them, with an allocator backed
+// by a store shared by the fleet, such as etcd or Consul. See
+// WithNodeAllocator.
+type NodeAllocator interface {
+	// Acquire leases a node ID, not leased by another unexpired lease, for
+	// ttl.
+	Acquire(ttl time.Duration) (NodeLease, error)
+
+	// Renew extends lease for ttl, and returns it with its new expiry. It
+	// returns an error wrapping ErrNodeLeaseLost if lease expired or was
+	// released.
+	Renew(lease NodeLease, ttl time.Duration) (NodeLease, error)
+
+	// Release releases lease, so that its node ID can be leased again.
+	Release(lease NodeLease) error
+}
+
+// MemoryNodeAllocator is a NodeAllocator of the node IDs from 0 to n-1,
+// holding its leases in memory. It is the reference implementation of
+// NodeAllocator, and coordinates the generators of a single process only.
+//
+// A MemoryNodeAllocator is safe for concurrent use.
+type MemoryNodeAllocator struct {
+	mu        sync.Mutex
+	n         uint64
+	leases    map[uint64]NodeLease
+	lastToken uint64
+
+	now func() time.Time
+}
+
+var _ NodeAllocator = (*MemoryNodeAllocator)(nil)
+
+// NewMemoryNodeAllocator returns a MemoryNodeAllocator of the node IDs from
+// 0 to n-1. It will return an error if n is zero.
+func NewMemoryNodeAllocator(n uint64) (*MemoryNodeAllocator, error) {
+	if n == 0 {
+		return nil, fmt.Errorf("%w: node ID count must be positive", ErrInvalidArgument)
+	}
+	return &MemoryNodeAllocator{
+		n:      n,
+		leases: make(map[uint64]NodeLease),
+		now:    time.Now,
+	}, nil
+}
+
+// Acquire leases the lowest node ID without an unexpired lease for ttl. It
+// returns ErrNoNodeAvailable if all the node IDs are leased, and an error
+// wrapping ErrInvalidArgument if ttl is not positive.
+func (a *MemoryNodeAllocator) Acquire(ttl time.Duration) (NodeLease, error) {
+	if ttl <= 0 {
+		return NodeLease{}, fmt.Errorf("%w: lease TTL %v must be positive", ErrInvalidArgument, ttl)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	for id := uint64(0); id < a.n; id++ {
+		if l, ok := a.leases[id]; ok && now.Before(l.Expiry) {
+			continue
+		}
+		a.lastToken++
+		l := NodeLease{ID: id, Token: a.lastToken, Expiry: now.Add(ttl)}
+		a.leases[id] = l
+		return l, nil
+	}
+	return NodeLease{}, ErrNoNodeAvailable
+}
+
+// Renew extends lease for ttl. It returns an error wrapping
+// ErrNodeLeaseLost if lease expired or was released, and ErrInvalidArgument
+// if ttl is not positive.
+func (a *MemoryNodeAllocator) Renew(lease NodeLease, ttl time.Duration) (NodeLease, error) {
+	if ttl <= 0 {
+		return NodeLease{}, fmt.Errorf("%w: lease TTL %v must be positive", ErrInvalidArgument, ttl)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	if l, ok := a.leases[lease.ID]; !ok || l.Token != lease.Token || !now.Before(l.Expiry) {
+		return NodeLease{}, fmt.Errorf("%w: node ID %d", ErrNodeLeaseLost, lease.ID)
+	}
+	lease.Expiry = now.Add(ttl)
+	a.leases[lease.ID] = lease
+	return lease, nil
+}
+
+// Release releases lease. Releasing an expired or released lease has no
+// effect.
+func (a *MemoryNodeAllocator) Release(lease NodeLease) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if l, ok := a.leases[lease.ID]; ok && l.Token == lease.Token {
+		delete(a.leases, lease.ID)
+	}
+	return nil
+}
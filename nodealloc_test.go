@@ -0,0 +1,287 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestNodeAllocator(t *testing.T, n uint64, now *time.Time) *MemoryNodeAllocator {
+	t.Helper()
+	a, err := NewMemoryNodeAllocator(n)
+	if err != nil {
+		t.Fatalf("NewMemoryNodeAllocator(%d) unexpected error: %v", n, err)
+	}
+	a.now = func() time.Time { return *now }
+	return a
+}
+
+func TestMemoryNodeAllocator(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	ttl := 10 * time.Second
+
+	t.Run("Acquire", func(t *testing.T) {
+		a := newTestNodeAllocator(t, 2, &now)
+		l0, err := a.Acquire(ttl)
+		if err != nil || l0.ID != 0 || !l0.Expiry.Equal(now.Add(ttl)) {
+			t.Fatalf("Acquire() = %+v, %v, want node 0 until %v", l0, err, now.Add(ttl))
+		}
+		l1, err := a.Acquire(ttl)
+		if err != nil || l1.ID != 1 || l1.Token == l0.Token {
+			t.Fatalf("Acquire() = %+v, %v, want node 1 with a new token", l1, err)
+		}
+		if l, err := a.Acquire(ttl); !errors.Is(err, ErrNoNodeAvailable) {
+			t.Errorf("Acquire() with all nodes leased = %+v, %v, want %v", l, err, ErrNoNodeAvailable)
+		}
+		if err := a.Release(l0); err != nil {
+			t.Fatalf("Release(%+v) unexpected error: %v", l0, err)
+		}
+		if l, err := a.Acquire(ttl); err != nil || l.ID != 0 {
+			t.Errorf("Acquire() after Release = %+v, %v, want node 0", l, err)
+		}
+	})
+
+	t.Run("Renew", func(t *testing.T) {
+		now := now
+		a := newTestNodeAllocator(t, 1, &now)
+		l, err := a.Acquire(ttl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		now = now.Add(ttl / 2)
+		renewed, err := a.Renew(l, ttl)
+		if err != nil || renewed.ID != l.ID || !renewed.Expiry.Equal(now.Add(ttl)) {
+			t.Fatalf("Renew(%+v) = %+v, %v, want expiry %v", l, renewed, err, now.Add(ttl))
+		}
+
+		// The lease expires, and its node is leased again.
+		now = renewed.Expiry
+		if _, err := a.Renew(renewed, ttl); !errors.Is(err, ErrNodeLeaseLost) {
+			t.Errorf("Renew() of an expired lease error = %v, want %v", err, ErrNodeLeaseLost)
+		}
+		l2, err := a.Acquire(ttl)
+		if err != nil || l2.ID != l.ID {
+			t.Fatalf("Acquire() after expiry = %+v, %v, want node %d", l2, err, l.ID)
+		}
+		if _, err := a.Renew(renewed, ttl); !errors.Is(err, ErrNodeLeaseLost) {
+			t.Errorf("Renew() of a lease leased again error = %v, want %v", err, ErrNodeLeaseLost)
+		}
+		if err := a.Release(renewed); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := a.Renew(l2, ttl); err != nil {
+			t.Errorf("Renew() after the release of a stale lease unexpected error: %v", err)
+		}
+		if err := a.Release(l2); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := a.Renew(l2, ttl); !errors.Is(err, ErrNodeLeaseLost) {
+			t.Errorf("Renew() of a released lease error = %v, want %v", err, ErrNodeLeaseLost)
+		}
+	})
+
+	t.Run("InvalidArgument", func(t *testing.T) {
+		if _, err := NewMemoryNodeAllocator(0); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("NewMemoryNodeAllocator(0) error = %v, want %v", err, ErrInvalidArgument)
+		}
+		a := newTestNodeAllocator(t, 1, &now)
+		if _, err := a.Acquire(0); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("Acquire(0) error = %v, want %v", err, ErrInvalidArgument)
+		}
+		if _, err := a.Renew(NodeLease{}, -time.Second); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("Renew(-1s) error = %v, want %v", err, ErrInvalidArgument)
+		}
+	})
+}
+
+// failingNodeAllocator fails all its calls with err.
+type failingNodeAllocator struct {
+	err error
+}
+
+func (a failingNodeAllocator) Acquire(time.Duration) (NodeLease, error) {
+	return NodeLease{}, a.err
+}
+
+func (a failingNodeAllocator) Renew(NodeLease, time.Duration) (NodeLease, error) {
+	return NodeLease{}, a.err
+}
+
+func (a failingNodeAllocator) Release(NodeLease) error {
+	return a.err
+}
+
+func TestWithNodeAllocator(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	ttl := 10 * time.Second
+	clock := WithEpochFunc(func() time.Time { return now })
+
+	t.Run("Node", func(t *testing.T) {
+		a := newTestNodeAllocator(t, 4, &now)
+		if _, err := a.Acquire(ttl); err != nil {
+			t.Fatal(err)
+		}
+		g := NewGenWithOptions(clock, WithRandomNode(), WithNodeAllocator(a, ttl))
+		want := []byte{0, 0, 0, 0, 0, 1}
+		want[0] |= 0x01
+		u1 := Must(g.NewV1())
+		if !bytes.Equal(u1[10:], want) {
+			t.Errorf("NewV1() node = %x, want %x", u1[10:], want)
+		}
+		u6 := Must(g.NewV6())
+		if !bytes.Equal(u6[10:], want) {
+			t.Errorf("NewV6() node = %x, want %x", u6[10:], want)
+		}
+		if u6.Version() != V6 || u6.Variant() != VariantRFC9562 {
+			t.Errorf("NewV6() = %v, version %d, variant %d", u6, u6.Version(), u6.Variant())
+		}
+
+		// A second generator leases another node.
+		g2 := NewGenWithOptions(clock, WithNodeAllocator(a, ttl))
+		if u := Must(g2.NewV1()); bytes.Equal(u[10:], want) {
+			t.Errorf("NewV1() of a second generator node = %x, want another node", u[10:])
+		}
+	})
+
+	t.Run("V7NodeBits", func(t *testing.T) {
+		a := newTestNodeAllocator(t, 8, &now)
+		for i := 0; i < 5; i++ {
+			if _, err := a.Acquire(ttl); err != nil {
+				t.Fatal(err)
+			}
+		}
+		const bits = 4
+		node := func(u UUID) uint64 {
+			return binary.BigEndian.Uint64(u[8:]) >> (62 - bits) & (1<<bits - 1)
+		}
+		g := NewMonotonicGen(clock, WithNodeAllocator(a, ttl), WithV7NodeBits(bits))
+		u := Must(g.NewV7())
+		if node(u) != 5 || u.Version() != V7 || u.Variant() != VariantRFC9562 {
+			t.Errorf("NewV7() = %v, node bits %d, want 5", u, node(u))
+		}
+		batch, err := g.GenerateBatchV7(10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parallel, err := g.GenerateBatchV7Parallel(10, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, u := range append(batch, parallel...) {
+			if node(u) != 5 {
+				t.Errorf("batch UUID %v node bits = %d, want 5", u, node(u))
+			}
+		}
+		if u := Must(g.Gen.NewV7()); node(u) != 5 {
+			t.Errorf("Gen.NewV7() = %v, node bits %d, want 5", u, node(u))
+		}
+	})
+
+	t.Run("Renewal", func(t *testing.T) {
+		now := now
+		a := newTestNodeAllocator(t, 2, &now)
+		g := NewGenWithOptions(WithEpochFunc(func() time.Time { return now }), WithNodeAllocator(a, ttl))
+		first := Must(g.NewV1())
+
+		// The lease is renewed after half its TTL, keeping the node.
+		for i := 0; i < 4; i++ {
+			now = now.Add(ttl / 3)
+			if u := Must(g.NewV1()); !bytes.Equal(u[10:], first[10:]) {
+				t.Fatalf("NewV1() node after renewal = %x, want %x", u[10:], first[10:])
+			}
+		}
+
+		// The lease is lost and its node leased by another process.
+		now = now.Add(ttl)
+		if _, err := a.Acquire(ttl); err != nil {
+			t.Fatal(err)
+		}
+		if u := Must(g.NewV1()); bytes.Equal(u[10:], first[10:]) {
+			t.Errorf("NewV1() node after a lost lease = %x, want another node", u[10:])
+		}
+
+		if err := g.ReleaseNode(); err != nil {
+			t.Fatalf("ReleaseNode() unexpected error: %v", err)
+		}
+		if err := g.ReleaseNode(); err != nil {
+			t.Fatalf("ReleaseNode() twice unexpected error: %v", err)
+		}
+		if l, err := a.Acquire(ttl); err != nil || l.ID != 1 {
+			t.Errorf("Acquire() after ReleaseNode = %+v, %v, want node 1", l, err)
+		}
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		errAlloc := errors.New("allocator error")
+		g := NewMonotonicGen(clock, WithNodeAllocator(failingNodeAllocator{errAlloc}, ttl), WithV7NodeBits(8))
+		if _, err := g.NewV1(); !errors.Is(err, errAlloc) {
+			t.Errorf("NewV1() error = %v, want %v", err, errAlloc)
+		}
+		if _, err := g.NewV6(); !errors.Is(err, errAlloc) {
+			t.Errorf("NewV6() error = %v, want %v", err, errAlloc)
+		}
+		if _, err := g.NewV7(); !errors.Is(err, errAlloc) {
+			t.Errorf("NewV7() error = %v, want %v", err, errAlloc)
+		}
+		if _, err := g.GenerateBatchV7(2); !errors.Is(err, errAlloc) {
+			t.Errorf("GenerateBatchV7() error = %v, want %v", err, errAlloc)
+		}
+
+		// An unexpired lease is used while the allocator fails.
+		now := now
+		a := newTestNodeAllocator(t, 1, &now)
+		alloc := &switchNodeAllocator{NodeAllocator: a}
+		g2 := NewGenWithOptions(WithEpochFunc(func() time.Time { return now }), WithNodeAllocator(alloc, ttl))
+		u := Must(g2.NewV1())
+		alloc.err = errAlloc
+		now = now.Add(ttl * 3 / 4)
+		if got, err := g2.NewV1(); err != nil || !bytes.Equal(got[10:], u[10:]) {
+			t.Errorf("NewV1() with a failing renewal = %v, %v, want node %x", got, err, u[10:])
+		}
+		now = now.Add(ttl)
+		if _, err := g2.NewV1(); !errors.Is(err, errAlloc) {
+			t.Errorf("NewV1() with an expired lease error = %v, want %v", err, errAlloc)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		a := newTestNodeAllocator(t, 1, &now)
+		g := NewGenWithOptions(WithNodeAllocator(a, 0))
+		if g.nodeAllocator != nil {
+			t.Errorf("WithNodeAllocator(a, 0) set the allocator")
+		}
+		if g := NewGenWithOptions(WithV7NodeBits(40)); g.v7NodeBits != 32 {
+			t.Errorf("WithV7NodeBits(40) bits = %d, want 32", g.v7NodeBits)
+		}
+	})
+}
+
+// switchNodeAllocator fails its calls with err, if set.
+type switchNodeAllocator struct {
+	NodeAllocator
+	err error
+}
+
+func (a *switchNodeAllocator) Acquire(ttl time.Duration) (NodeLease, error) {
+	if a.err != nil {
+		return NodeLease{}, a.err
+	}
+	return a.NodeAllocator.Acquire(ttl)
+}
+
+func (a *switchNodeAllocator) Renew(lease NodeLease, ttl time.Duration) (NodeLease, error) {
+	if a.err != nil {
+		return NodeLease{}, a.err
+	}
+	return a.NodeAllocator.Renew(lease, ttl)
+}
+
+func TestSetV7NodeBits(t *testing.T) {
+	randB := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	setV7NodeBits(randB, 0x1a5, 8)
+	if want := []byte{0xe9, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}; !bytes.Equal(randB, want) {
+		t.Errorf("setV7NodeBits() = %x, want %x", randB, want)
+	}
+}
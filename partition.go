@@ -0,0 +1,148 @@
+package uuid
+
+// PartitionHash is the hash function used by PartitionFor.
+type PartitionHash byte
+
+const (
+	// PartitionMurmur2 is the 32-bit murmur2 hash of the default partitioner
+	// of the Kafka Java client, which assigns a key to partition
+	// (murmur2(key) & 0x7fffffff) % numPartitions.
+	PartitionMurmur2 PartitionHash = iota
+
+	// PartitionFNV1a is the 32-bit FNV-1a hash of the default partitioner of
+	// the Sarama Go client, which assigns a key to partition
+	// |int32(fnv1a(key)) % numPartitions|.
+	PartitionFNV1a
+)
+
+// PartitionKey is the representation of a UUID hashed by PartitionFor, which
+// must match the bytes the producers sharing the topic send as the record
+// key.
+type PartitionKey byte
+
+const (
+	// PartitionKeyBinary hashes the 16 bytes of the UUID, as sent by
+	// producers serializing keys with MarshalBinary or Bytes.
+	PartitionKeyBinary PartitionKey = iota
+
+	// PartitionKeyText hashes the canonical string form of the UUID, as sent
+	// by producers serializing keys with String, or by Java producers using
+	// the UUIDSerializer or StringSerializer of the Kafka client.
+	PartitionKeyText
+)
+
+type partitionConfig struct {
+	hash PartitionHash
+	key  PartitionKey
+}
+
+// newPartitionConfig returns the configuration set by opts. It is kept out of
+// PartitionFor, so that the configuration only escapes to the heap when
+// options are given.
+func newPartitionConfig(opts []PartitionOption) partitionConfig {
+	var c partitionConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// PartitionOption configures PartitionFor.
+type PartitionOption func(*partitionConfig)
+
+// WithPartitionHash sets the hash function of PartitionFor, which is
+// PartitionMurmur2 by default.
+func WithPartitionHash(h PartitionHash) PartitionOption {
+	return func(c *partitionConfig) {
+		c.hash = h
+	}
+}
+
+// WithPartitionKey sets the representation of the UUID hashed by
+// PartitionFor, which is PartitionKeyBinary by default.
+func WithPartitionKey(k PartitionKey) PartitionOption {
+	return func(c *partitionConfig) {
+		c.key = k
+	}
+}
+
+// PartitionFor returns the Kafka partition, out of numPartitions, of records
+// keyed by u, as assigned by the default partitioner of the Kafka Java
+// client, or, with WithPartitionHash(PartitionFNV1a), of the Sarama Go
+// client. Producers keyed by the same UUID then write to the same partitions
+// whatever their language. PartitionFor returns -1 if numPartitions is not
+// positive.
+func PartitionFor(u UUID, numPartitions int, opts ...PartitionOption) int {
+	if numPartitions <= 0 {
+		return -1
+	}
+	var c partitionConfig
+	if len(opts) > 0 {
+		c = newPartitionConfig(opts)
+	}
+
+	var buf [36]byte
+	key := u[:]
+	if c.key == PartitionKeyText {
+		encodeCanonical(buf[:], u)
+		key = buf[:]
+	}
+
+	if c.hash == PartitionFNV1a {
+		p := int32(fnv1a32(key)) % int32(numPartitions)
+		if p < 0 {
+			p = -p
+		}
+		return int(p)
+	}
+	return int(murmur2(key)&0x7fffffff) % numPartitions
+}
+
+// murmur2 is the 32-bit murmur2 hash as implemented by the Kafka Java
+// client, with its seed.
+func murmur2(data []byte) uint32 {
+	const (
+		seed = 0x9747b28c
+		m    = 0x5bd1e995
+		r    = 24
+	)
+	n := len(data)
+	h := uint32(seed) ^ uint32(n)
+	for ; len(data) >= 4; data = data[4:] {
+		k := uint32(data[0]) | uint32(data[1])<<8 | uint32(data[2])<<16 | uint32(data[3])<<24
+		k *= m
+		k ^= k >> r
+		k *= m
+		h *= m
+		h ^= k
+	}
+	switch len(data) {
+	case 3:
+		h ^= uint32(data[2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(data[1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(data[0])
+		h *= m
+	}
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return h
+}
+
+// fnv1a32 is the 32-bit FNV-1a hash.
+func fnv1a32(data []byte) uint32 {
+	const (
+		offset = 2166136261
+		prime  = 16777619
+	)
+	h := uint32(offset)
+	for _, b := range data {
+		h ^= uint32(b)
+		h *= prime
+	}
+	return h
+}
@@ -0,0 +1,91 @@
+package uuid
+
+import (
+	"hash/fnv"
+	"testing"
+)
+
+func TestMurmur2(t *testing.T) {
+	// Test vectors of the murmur2 tests of the Kafka Java client.
+	tests := []struct {
+		in   string
+		want int32
+	}{
+		{"21", -973932308},
+		{"foobar", -790332482},
+		{"a-little-bit-long-string", -985981536},
+		{"a-little-bit-longer-string", -1486304829},
+		{"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8", -58897971},
+		{"abc", 479470107},
+	}
+	for _, tt := range tests {
+		if got := int32(murmur2([]byte(tt.in))); got != tt.want {
+			t.Errorf("murmur2(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFNV1a32(t *testing.T) {
+	for _, in := range []string{"", "a", "foobar", codecTestUUID.String()} {
+		h := fnv.New32a()
+		h.Write([]byte(in))
+		if got, want := fnv1a32([]byte(in)), h.Sum32(); got != want {
+			t.Errorf("fnv1a32(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestPartitionFor(t *testing.T) {
+	const n = 12
+	text := []byte(codecTestUUID.String())
+
+	if got, want := PartitionFor(codecTestUUID, n), int(murmur2(codecTestUUID[:])&0x7fffffff)%n; got != want {
+		t.Errorf("PartitionFor(%v, %d) = %d, want %d", codecTestUUID, n, got, want)
+	}
+	if got, want := PartitionFor(codecTestUUID, n, WithPartitionKey(PartitionKeyText)), int(murmur2(text)&0x7fffffff)%n; got != want {
+		t.Errorf("PartitionFor(%v, %d, text) = %d, want %d", codecTestUUID, n, got, want)
+	}
+
+	fnvPartition := func(key []byte) int {
+		p := int32(fnv1a32(key)) % n
+		if p < 0 {
+			p = -p
+		}
+		return int(p)
+	}
+	if got, want := PartitionFor(codecTestUUID, n, WithPartitionHash(PartitionFNV1a)), fnvPartition(codecTestUUID[:]); got != want {
+		t.Errorf("PartitionFor(%v, %d, fnv) = %d, want %d", codecTestUUID, n, got, want)
+	}
+	if got, want := PartitionFor(codecTestUUID, n, WithPartitionHash(PartitionFNV1a), WithPartitionKey(PartitionKeyText)), fnvPartition(text); got != want {
+		t.Errorf("PartitionFor(%v, %d, fnv, text) = %d, want %d", codecTestUUID, n, got, want)
+	}
+
+	t.Run("Range", func(t *testing.T) {
+		g := NewGen()
+		for i := 0; i < 1000; i++ {
+			u, _ := g.NewV4()
+			for _, h := range []PartitionHash{PartitionMurmur2, PartitionFNV1a} {
+				if p := PartitionFor(u, n, WithPartitionHash(h)); p < 0 || p >= n {
+					t.Fatalf("PartitionFor(%v, %d, %d) = %d, out of range", u, n, h, p)
+				}
+			}
+		}
+	})
+
+	t.Run("NoPartitions", func(t *testing.T) {
+		for _, n := range []int{0, -1} {
+			if got := PartitionFor(codecTestUUID, n); got != -1 {
+				t.Errorf("PartitionFor(%v, %d) = %d, want -1", codecTestUUID, n, got)
+			}
+		}
+	})
+
+	t.Run("Allocs", func(t *testing.T) {
+		allocs := testing.AllocsPerRun(100, func() {
+			PartitionFor(codecTestUUID, n)
+		})
+		if allocs != 0 {
+			t.Errorf("PartitionFor without options allocated %v times, want 0", allocs)
+		}
+	})
+}
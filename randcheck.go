@@ -0,0 +1,77 @@
+package uuid
+
+import (
+	"fmt"
+	"io"
+)
+
+// randCheckSize is the number of bytes read by VerifyRandSource: 256
+// blocks of the size of a UUID, with an expected count of 16 for each byte
+// value.
+const randCheckSize = 256 * Size
+
+// Bounds of the chi-squared statistic of the byte counts of a random sample
+// of randCheckSize bytes, with 255 degrees of freedom, about six standard
+// deviations from its mean of 255. Counts too close to uniform are rejected
+// as well, as they are the signature of counters.
+const (
+	randCheckMinChiSquared = 120
+	randCheckMaxChiSquared = 400
+)
+
+// VerifyRandSource reads a sample of a few kilobytes from r and performs
+// basic sanity checks on it, to catch the misconfiguration of a generator
+// with a source that is not random, such as a reader of zeros, a fixed test
+// fixture or a counter, which would generate colliding UUIDs. It returns an
+// error wrapping ErrBadRandSource if:
+//
+//   - r fails or ends before the end of the sample;
+//   - the sample repeats a block of the size of a UUID;
+//   - the distribution of the bytes of the sample is too far from, or too
+//     close to, the uniform distribution expected of a random source.
+//
+// The checks only detect gross defects, and do not prove that r is suitable
+// for generating UUIDs. A random source fails them with a negligible
+// probability.
+func VerifyRandSource(r io.Reader) error {
+	sample := make([]byte, randCheckSize)
+	if n, err := io.ReadFull(r, sample); err != nil {
+		return fmt.Errorf("%w: read %d of %d bytes: %v", ErrBadRandSource, n, len(sample), err)
+	}
+
+	blocks := make(map[UUID]struct{}, len(sample)/Size)
+	for i := 0; i < len(sample); i += Size {
+		var u UUID
+		copy(u[:], sample[i:])
+		if _, ok := blocks[u]; ok {
+			return fmt.Errorf("%w: repeated block %x", ErrBadRandSource, u[:])
+		}
+		blocks[u] = struct{}{}
+	}
+
+	var counts [256]int
+	for _, b := range sample {
+		counts[b]++
+	}
+	expected := float64(len(sample)) / 256
+	var chi2 float64
+	for _, c := range counts {
+		d := float64(c) - expected
+		chi2 += d * d / expected
+	}
+	if chi2 < randCheckMinChiSquared || chi2 > randCheckMaxChiSquared {
+		return fmt.Errorf("%w: byte distribution has chi-squared statistic %.1f, want between %d and %d",
+			ErrBadRandSource, chi2, randCheckMinChiSquared, randCheckMaxChiSquared)
+	}
+	return nil
+}
+
+// errReader is a reader failing with err, replacing the random source of a
+// generator which failed VerifyRandSource.
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
@@ -0,0 +1,88 @@
+package uuid
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	mrand "math/rand"
+	"testing"
+)
+
+// funcReader is a reader of the bytes returned by next.
+type funcReader func() byte
+
+func (r funcReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = r()
+	}
+	return len(p), nil
+}
+
+func TestVerifyRandSource(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if err := VerifyRandSource(rand.Reader); err != nil {
+			t.Fatalf("VerifyRandSource(crypto/rand.Reader) = %v", err)
+		}
+	}
+	if err := VerifyRandSource(mrand.New(mrand.NewSource(1))); err != nil {
+		t.Errorf("VerifyRandSource(math/rand) = %v", err)
+	}
+
+	var i int
+	tests := map[string]io.Reader{
+		"Zero":    funcReader(func() byte { return 0 }),
+		"Short":   bytes.NewReader(make([]byte, Size)),
+		"Faulty":  &faultyReader{readToFail: 0},
+		"Counter": funcReader(func() byte { i++; return byte(i) }),
+		"Biased": funcReader(func() byte {
+			var b [1]byte
+			rand.Read(b[:])
+			return b[0] & 0x7f
+		}),
+		// Each byte value 16 times in distinct blocks: [j, j+1, ..., j+15].
+		"Uniform": funcReader(func() byte {
+			b := byte(i/Size + i%Size)
+			i++
+			return b
+		}),
+	}
+	for name, r := range tests {
+		i = 0
+		if err := VerifyRandSource(r); !errors.Is(err, ErrBadRandSource) {
+			t.Errorf("%s: VerifyRandSource() = %v, want %v", name, err, ErrBadRandSource)
+		}
+	}
+}
+
+func TestWithRandSourceCheck(t *testing.T) {
+	g := NewGenWithOptions(WithRandSourceCheck())
+	if _, err := g.NewV4(); err != nil {
+		t.Errorf("NewV4() with crypto/rand.Reader unexpected error: %v", err)
+	}
+
+	zero := funcReader(func() byte { return 0 })
+	g = NewGenWithOptions(WithRandomReader(zero), WithRandSourceCheck())
+	if u, err := g.NewV4(); !errors.Is(err, ErrBadRandSource) {
+		t.Errorf("NewV4() with a reader of zeros = %v, %v, want %v", u, err, ErrBadRandSource)
+	}
+	if u, err := g.NewV7(); !errors.Is(err, ErrBadRandSource) {
+		t.Errorf("NewV7() with a reader of zeros = %v, %v, want %v", u, err, ErrBadRandSource)
+	}
+	if !FIPS {
+		if u, want := g.NewV5(NamespaceDNS, "www.example.com"), NewV5(NamespaceDNS, "www.example.com"); u != want {
+			t.Errorf("NewV5() = %v, want %v", u, want)
+		}
+	}
+
+	m := NewMonotonicGen(WithRandomReader(zero), WithRandSourceCheck())
+	if _, err := m.GenerateBatchV7(2); !errors.Is(err, ErrBadRandSource) {
+		t.Errorf("GenerateBatchV7() with a reader of zeros error = %v, want %v", err, ErrBadRandSource)
+	}
+
+	// Without the option, the reader is used as is.
+	g = NewGenWithOptions(WithRandomReader(zero))
+	if u, err := g.NewV4(); err != nil || u != Must(FromString("00000000-0000-4000-8000-000000000000")) {
+		t.Errorf("NewV4() with a reader of zeros = %v, %v", u, err)
+	}
+}
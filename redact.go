@@ -0,0 +1,66 @@
+package uuid
+
+import "strconv"
+
+// DefaultRedactedLen is the number of characters Redact keeps at each end of
+// the canonical form of a UUID.
+const DefaultRedactedLen = 4
+
+// redactedEllipsis replaces the hidden characters of a Redacted UUID.
+const redactedEllipsis = "…"
+
+// Redacted is a UUID printed with only its first Prefix and last Suffix
+// characters, as in 0196…c3f1, so that logs and other output shipped to third
+// parties do not hold full identifiers. Its String, MarshalText, GoString
+// and, with Go 1.21 and later, LogValue methods all redact the UUID.
+//
+// Negative lengths are treated as 0, and the suffix is shortened so that it
+// never overlaps the prefix.
+type Redacted struct {
+	UUID   UUID
+	Prefix int
+	Suffix int
+}
+
+// Redact returns u redacted to DefaultRedactedLen characters at each end.
+func Redact(u UUID) Redacted {
+	return Redacted{UUID: u, Prefix: DefaultRedactedLen, Suffix: DefaultRedactedLen}
+}
+
+// String returns the redacted canonical string representation of the UUID.
+func (r Redacted) String() string {
+	return string(r.appendRedacted(nil))
+}
+
+// GoString returns the redacted UUID as a Go string literal, so that the %#v
+// verb does not print the UUID bytes.
+func (r Redacted) GoString() string {
+	return strconv.Quote(r.String())
+}
+
+// MarshalText implements the encoding.TextMarshaler interface, returning the
+// redacted canonical string representation of the UUID. Redacted does not
+// implement encoding.TextUnmarshaler, since the UUID cannot be recovered.
+func (r Redacted) MarshalText() ([]byte, error) {
+	return r.appendRedacted(nil), nil
+}
+
+func (r Redacted) appendRedacted(b []byte) []byte {
+	var buf [36]byte
+	encodeCanonical(buf[:], r.UUID)
+	prefix := clampRedacted(r.Prefix, len(buf))
+	suffix := clampRedacted(r.Suffix, len(buf)-prefix)
+	b = append(b, buf[:prefix]...)
+	b = append(b, redactedEllipsis...)
+	return append(b, buf[len(buf)-suffix:]...)
+}
+
+func clampRedacted(n, max int) int {
+	switch {
+	case n < 0:
+		return 0
+	case n > max:
+		return max
+	}
+	return n
+}
@@ -0,0 +1,11 @@
+//go:build go1.21
+
+package uuid
+
+import "log/slog"
+
+// LogValue implements the slog.LogValuer interface, logging the redacted
+// canonical string representation of the UUID.
+func (r Redacted) LogValue() slog.Value {
+	return slog.StringValue(r.String())
+}
@@ -0,0 +1,19 @@
+//go:build go1.21
+
+package uuid
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestRedactedLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	logger.Info("test", "id", Redact(codecTestUUID))
+	if got := buf.String(); !strings.Contains(got, "id=6ba7…30c8") {
+		t.Errorf("logged %q, want id=6ba7…30c8", got)
+	}
+}
@@ -0,0 +1,40 @@
+package uuid
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRedacted(t *testing.T) {
+	const s = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	tests := []struct {
+		r    Redacted
+		want string
+	}{
+		{Redact(codecTestUUID), "6ba7…30c8"},
+		{Redacted{UUID: codecTestUUID, Prefix: 8, Suffix: 0}, "6ba7b810…"},
+		{Redacted{UUID: codecTestUUID, Prefix: 0, Suffix: 12}, "…00c04fd430c8"},
+		{Redacted{UUID: codecTestUUID}, "…"},
+		{Redacted{UUID: codecTestUUID, Prefix: -1, Suffix: -1}, "…"},
+		{Redacted{UUID: codecTestUUID, Prefix: 30, Suffix: 30}, s[:30] + "…" + s[30:]},
+		{Redacted{UUID: codecTestUUID, Prefix: 40, Suffix: 2}, s + "…"},
+	}
+	for _, tt := range tests {
+		if got := tt.r.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.r, got, tt.want)
+		}
+	}
+
+	r := Redact(codecTestUUID)
+	for _, format := range []string{"%v", "%s", "%+v", "%#v"} {
+		if got := fmt.Sprintf(format, r); strings.Contains(got, "b810") || strings.Contains(got, "0x") {
+			t.Errorf("Sprintf(%q) = %q, not redacted", format, got)
+		}
+	}
+	data, err := json.Marshal(struct{ ID Redacted }{r})
+	if err != nil || string(data) != `{"ID":"6ba7…30c8"}` {
+		t.Errorf("json.Marshal = %s, %v", data, err)
+	}
+}
@@ -0,0 +1,125 @@
+package uuid
+
+import (
+	"sort"
+	"sync"
+)
+
+// DefaultReplicas is the number of virtual nodes per node used by
+// NewHashRing when replicas is not positive.
+const DefaultReplicas = 128
+
+// HashRing is a consistent-hash ring whose nodes and keys are UUIDs. Each
+// node is placed on the ring at several pseudo-random points (virtual nodes)
+// derived with Hash64, and a key is owned by the node at the first point at
+// or after the key's hash. Adding or removing a node only moves the keys
+// owned by its points.
+//
+// A HashRing is safe for concurrent use.
+type HashRing struct {
+	mu       sync.RWMutex
+	replicas int
+	points   []ringPoint
+	nodes    map[UUID]struct{}
+}
+
+type ringPoint struct {
+	hash uint64
+	node UUID
+}
+
+// NewHashRing returns a ring containing nodes, each placed at replicas
+// virtual nodes. If replicas is not positive, DefaultReplicas is used.
+func NewHashRing(replicas int, nodes ...UUID) *HashRing {
+	if replicas <= 0 {
+		replicas = DefaultReplicas
+	}
+	r := &HashRing{
+		replicas: replicas,
+		nodes:    make(map[UUID]struct{}),
+	}
+	for _, node := range nodes {
+		r.add(node)
+	}
+	r.sort()
+	return r
+}
+
+func (r *HashRing) add(node UUID) bool {
+	if _, ok := r.nodes[node]; ok {
+		return false
+	}
+	r.nodes[node] = struct{}{}
+	for i := 0; i < r.replicas; i++ {
+		r.points = append(r.points, ringPoint{hash: node.Hash64(uint64(i)), node: node})
+	}
+	return true
+}
+
+func (r *HashRing) sort() {
+	sort.Slice(r.points, func(i, j int) bool {
+		a, b := r.points[i], r.points[j]
+		if a.hash != b.hash {
+			return a.hash < b.hash
+		}
+		// Break ties deterministically regardless of insertion order.
+		return a.node.Compare(b.node) < 0
+	})
+}
+
+// Add adds node to the ring. It reports whether the node was not already
+// present.
+func (r *HashRing) Add(node UUID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.add(node) {
+		return false
+	}
+	r.sort()
+	return true
+}
+
+// Remove removes node from the ring. It reports whether the node was present.
+func (r *HashRing) Remove(node UUID) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.nodes[node]; !ok {
+		return false
+	}
+	delete(r.nodes, node)
+	points := r.points[:0]
+	for _, p := range r.points {
+		if p.node != node {
+			points = append(points, p)
+		}
+	}
+	r.points = points
+	return true
+}
+
+// Owner returns the node owning key. It returns false if the ring is empty.
+func (r *HashRing) Owner(key UUID) (UUID, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.points) == 0 {
+		return Nil, false
+	}
+	h := key.Hash64(0)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.points[i].node, true
+}
+
+// Nodes returns the nodes in the ring, sorted in ascending byte order.
+func (r *HashRing) Nodes() []UUID {
+	r.mu.RLock()
+	nodes := make([]UUID, 0, len(r.nodes))
+	for node := range r.nodes {
+		nodes = append(nodes, node)
+	}
+	r.mu.RUnlock()
+	Sort(nodes)
+	return nodes
+}
@@ -0,0 +1,107 @@
+package uuid
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTestRingNodes(n int) []UUID {
+	nodes := make([]UUID, n)
+	for i := range nodes {
+		nodes[i] = NewV8SHA256(NamespaceOID, string(rune('a'+i)))
+	}
+	return nodes
+}
+
+func TestHashRingEmpty(t *testing.T) {
+	r := NewHashRing(0)
+	if r.replicas != DefaultReplicas {
+		t.Errorf("NewHashRing(0) uses %d replicas, want %d", r.replicas, DefaultReplicas)
+	}
+	if owner, ok := r.Owner(NamespaceDNS); ok {
+		t.Errorf("Owner(%v) on empty ring = %v, true", NamespaceDNS, owner)
+	}
+}
+
+func TestHashRingOwner(t *testing.T) {
+	nodes := newTestRingNodes(4)
+	r := NewHashRing(64, nodes...)
+	if got := r.Nodes(); !reflect.DeepEqual(got, sortedCopy(nodes)) {
+		t.Errorf("Nodes() = %v, want %v", got, nodes)
+	}
+
+	// Rings built in a different order must agree.
+	reversed := NewHashRing(64, nodes[3], nodes[2], nodes[1], nodes[0])
+	counts := make(map[UUID]int)
+	const keys = 8000
+	g := NewGenWithOptions(WithCustomPRNG(1))
+	for i := 0; i < keys; i++ {
+		key := Must(g.NewV4())
+		owner, ok := r.Owner(key)
+		if !ok {
+			t.Fatalf("Owner(%v) reported an empty ring", key)
+		}
+		if other, _ := reversed.Owner(key); other != owner {
+			t.Fatalf("Owner(%v) = %v, but %v in a ring built in reverse", key, owner, other)
+		}
+		counts[owner]++
+	}
+	for _, node := range nodes {
+		if c := counts[node]; c < keys/4*6/10 || c > keys/4*14/10 {
+			t.Errorf("node %v owns %d of %d keys, want about %d", node, c, keys, keys/4)
+		}
+	}
+}
+
+func TestHashRingAddRemove(t *testing.T) {
+	nodes := newTestRingNodes(5)
+	r := NewHashRing(64, nodes[:4]...)
+	keys := make([]UUID, 2000)
+	before := make([]UUID, len(keys))
+	for i := range keys {
+		keys[i] = Must(NewV4())
+		before[i], _ = r.Owner(keys[i])
+	}
+
+	if !r.Add(nodes[4]) {
+		t.Fatalf("Add(%v) = false for a new node", nodes[4])
+	}
+	if r.Add(nodes[4]) {
+		t.Errorf("Add(%v) = true for an existing node", nodes[4])
+	}
+	for i, key := range keys {
+		// Keys either stay put or move to the new node.
+		if owner, _ := r.Owner(key); owner != before[i] && owner != nodes[4] {
+			t.Errorf("Owner(%v) moved from %v to %v after adding %v", key, before[i], owner, nodes[4])
+		}
+	}
+
+	if !r.Remove(nodes[4]) {
+		t.Fatalf("Remove(%v) = false for an existing node", nodes[4])
+	}
+	if r.Remove(nodes[4]) {
+		t.Errorf("Remove(%v) = true for a removed node", nodes[4])
+	}
+	for i, key := range keys {
+		if owner, _ := r.Owner(key); owner != before[i] {
+			t.Errorf("Owner(%v) = %v after removing %v, want %v", key, owner, nodes[4], before[i])
+		}
+	}
+	if len(r.points) != 4*64 {
+		t.Errorf("ring has %d points, want %d", len(r.points), 4*64)
+	}
+}
+
+func sortedCopy(s []UUID) []UUID {
+	c := append([]UUID(nil), s...)
+	Sort(c)
+	return c
+}
+
+func BenchmarkHashRingOwner(b *testing.B) {
+	r := NewHashRing(0, newTestRingNodes(16)...)
+	key := Must(NewV4())
+	for i := 0; i < b.N; i++ {
+		r.Owner(key)
+	}
+}
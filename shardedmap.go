@@ -0,0 +1,141 @@
+package uuid
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ShardedMap is a concurrent map keyed by UUID. Keys are spread across
+// independently locked shards chosen from the UUID bytes with Hash64, so
+// there is no string conversion and little lock contention between goroutines
+// working on different keys.
+//
+// The zero value is not usable; create maps with NewShardedMap.
+type ShardedMap[V any] struct {
+	shards []shardedMapShard[V]
+	mask   uint64
+}
+
+type shardedMapShard[V any] struct {
+	mu sync.RWMutex
+	m  map[UUID]V
+
+	// Pads shards to separate cache lines to avoid false sharing.
+	_ [32]byte
+}
+
+// NewShardedMap returns an empty ShardedMap with at least the given number of
+// shards, rounded up to a power of two. If shards is not positive, a default
+// based on runtime.GOMAXPROCS is used.
+func NewShardedMap[V any](shards int) *ShardedMap[V] {
+	if shards <= 0 {
+		shards = 4 * runtime.GOMAXPROCS(0)
+	}
+	n := 1
+	for n < shards {
+		n <<= 1
+	}
+	m := &ShardedMap[V]{
+		shards: make([]shardedMapShard[V], n),
+		mask:   uint64(n - 1),
+	}
+	for i := range m.shards {
+		m.shards[i].m = make(map[UUID]V)
+	}
+	return m
+}
+
+func (m *ShardedMap[V]) shard(key UUID) *shardedMapShard[V] {
+	return &m.shards[key.Hash64(0)&m.mask]
+}
+
+// Load returns the value stored for key, and whether a value was present.
+func (m *ShardedMap[V]) Load(key UUID) (value V, ok bool) {
+	s := m.shard(key)
+	s.mu.RLock()
+	value, ok = s.m[key]
+	s.mu.RUnlock()
+	return value, ok
+}
+
+// Store sets the value for key.
+func (m *ShardedMap[V]) Store(key UUID, value V) {
+	s := m.shard(key)
+	s.mu.Lock()
+	s.m[key] = value
+	s.mu.Unlock()
+}
+
+// LoadOrStore returns the existing value for key if present. Otherwise, it
+// stores and returns value. The loaded result is true if the value was
+// loaded, false if stored.
+func (m *ShardedMap[V]) LoadOrStore(key UUID, value V) (actual V, loaded bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if actual, loaded = s.m[key]; loaded {
+		return actual, true
+	}
+	s.m[key] = value
+	return value, false
+}
+
+// LoadAndDelete deletes the value for key, returning the previous value if
+// any. The loaded result reports whether key was present.
+func (m *ShardedMap[V]) LoadAndDelete(key UUID) (value V, loaded bool) {
+	s := m.shard(key)
+	s.mu.Lock()
+	value, loaded = s.m[key]
+	delete(s.m, key)
+	s.mu.Unlock()
+	return value, loaded
+}
+
+// Delete deletes the value for key.
+func (m *ShardedMap[V]) Delete(key UUID) {
+	s := m.shard(key)
+	s.mu.Lock()
+	delete(s.m, key)
+	s.mu.Unlock()
+}
+
+// Len returns the number of keys in the map. Concurrent modifications may or
+// may not be reflected in the result.
+func (m *ShardedMap[V]) Len() int {
+	n := 0
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		n += len(s.m)
+		s.mu.RUnlock()
+	}
+	return n
+}
+
+// Range calls fn sequentially for each key and value in the map, in no
+// particular order. If fn returns false, Range stops the iteration.
+//
+// Like sync.Map, Range does not correspond to a consistent snapshot: each
+// shard is copied under its lock before fn is called, so fn may modify the
+// map, and concurrent modifications to shards not yet visited are reflected.
+func (m *ShardedMap[V]) Range(fn func(key UUID, value V) bool) {
+	type entry struct {
+		key   UUID
+		value V
+	}
+	var entries []entry
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.mu.RLock()
+		entries = entries[:0]
+		for k, v := range s.m {
+			entries = append(entries, entry{k, v})
+		}
+		s.mu.RUnlock()
+		for _, e := range entries {
+			if !fn(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package uuid
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestNewShardedMap(t *testing.T) {
+	tests := []struct {
+		shards, want int
+	}{
+		{shards: 1, want: 1},
+		{shards: 3, want: 4},
+		{shards: 64, want: 64},
+		{shards: 65, want: 128},
+	}
+	for _, tt := range tests {
+		if got := len(NewShardedMap[int](tt.shards).shards); got != tt.want {
+			t.Errorf("NewShardedMap(%d) has %d shards, want %d", tt.shards, got, tt.want)
+		}
+	}
+	if got := len(NewShardedMap[int](0).shards); got == 0 || got&(got-1) != 0 {
+		t.Errorf("NewShardedMap(0) has %d shards, want a positive power of two", got)
+	}
+}
+
+func TestShardedMap(t *testing.T) {
+	m := NewShardedMap[string](8)
+	if _, ok := m.Load(NamespaceDNS); ok {
+		t.Errorf("Load(%v) on empty map reported ok", NamespaceDNS)
+	}
+
+	m.Store(NamespaceDNS, "dns")
+	m.Store(NamespaceURL, "url")
+	if v, ok := m.Load(NamespaceDNS); !ok || v != "dns" {
+		t.Errorf("Load(%v) = %q, %t, want %q, true", NamespaceDNS, v, ok, "dns")
+	}
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", m.Len())
+	}
+
+	if v, loaded := m.LoadOrStore(NamespaceDNS, "other"); !loaded || v != "dns" {
+		t.Errorf("LoadOrStore(%v) = %q, %t, want %q, true", NamespaceDNS, v, loaded, "dns")
+	}
+	if v, loaded := m.LoadOrStore(NamespaceOID, "oid"); loaded || v != "oid" {
+		t.Errorf("LoadOrStore(%v) = %q, %t, want %q, false", NamespaceOID, v, loaded, "oid")
+	}
+
+	if v, loaded := m.LoadAndDelete(NamespaceURL); !loaded || v != "url" {
+		t.Errorf("LoadAndDelete(%v) = %q, %t, want %q, true", NamespaceURL, v, loaded, "url")
+	}
+	if _, loaded := m.LoadAndDelete(NamespaceURL); loaded {
+		t.Errorf("second LoadAndDelete(%v) reported loaded", NamespaceURL)
+	}
+
+	m.Delete(NamespaceOID)
+	if _, ok := m.Load(NamespaceOID); ok {
+		t.Errorf("Load(%v) after Delete reported ok", NamespaceOID)
+	}
+	if m.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", m.Len())
+	}
+}
+
+func TestShardedMapRange(t *testing.T) {
+	m := NewShardedMap[int](4)
+	want := make(map[UUID]int)
+	for i := 0; i < 100; i++ {
+		u := Must(NewV4())
+		m.Store(u, i)
+		want[u] = i
+	}
+	got := make(map[UUID]int)
+	m.Range(func(k UUID, v int) bool {
+		got[k] = v
+		// Modifying the map during Range must not deadlock.
+		m.Store(k, v)
+		return true
+	})
+	if len(got) != len(want) {
+		t.Fatalf("Range visited %d keys, want %d", len(got), len(want))
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("Range visited %v = %d, want %d", k, got[k], v)
+		}
+	}
+
+	visited := 0
+	m.Range(func(UUID, int) bool {
+		visited++
+		return false
+	})
+	if visited != 1 {
+		t.Errorf("Range visited %d keys after fn returned false, want 1", visited)
+	}
+}
+
+func TestShardedMapConcurrent(t *testing.T) {
+	m := NewShardedMap[int](0)
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			g := NewGen()
+			for i := 0; i < 1000; i++ {
+				u := Must(g.NewV7())
+				m.Store(u, w)
+				if v, ok := m.Load(u); !ok || v != w {
+					t.Errorf("Load(%v) = %d, %t, want %d, true", u, v, ok, w)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	if m.Len() != 8000 {
+		t.Errorf("Len() = %d, want 8000", m.Len())
+	}
+}
+
+func BenchmarkShardedMap(b *testing.B) {
+	m := NewShardedMap[int](0)
+	keys := make([]UUID, 1024)
+	for i := range keys {
+		keys[i] = Must(NewV7())
+		m.Store(keys[i], i)
+	}
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.Load(keys[i%len(keys)])
+			i++
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package uuid
+
+import "sort"
+
+// Less reports whether a sorts before b in byte order. The method expression
+// UUID.Compare can be used where a three-way comparison is needed, such as
+// with slices.SortFunc.
+func Less(a, b UUID) bool {
+	return a.Compare(b) < 0
+}
+
+// Slice attaches the methods of sort.Interface to []UUID, sorting in
+// ascending byte order.
+type Slice []UUID
+
+// Len implements sort.Interface.
+func (s Slice) Len() int { return len(s) }
+
+// Less implements sort.Interface.
+func (s Slice) Less(i, j int) bool { return s[i].Compare(s[j]) < 0 }
+
+// Swap implements sort.Interface.
+func (s Slice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+// Sort sorts s in ascending byte order, which is also ascending order of the
+// UUIDs' 128-bit integer values and of their canonical string forms.
+func Sort(s []UUID) {
+	sort.Sort(Slice(s))
+}
+
+// IsSorted reports whether s is sorted in ascending byte order.
+func IsSorted(s []UUID) bool {
+	for i := 1; i < len(s); i++ {
+		if s[i-1].Compare(s[i]) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Dedup removes consecutive duplicate UUIDs from s, in place, and returns the
+// shortened slice. When s is sorted, the result contains each UUID once.
+func Dedup(s []UUID) []UUID {
+	if len(s) < 2 {
+		return s
+	}
+	n := 1
+	for i := 1; i < len(s); i++ {
+		if s[i] != s[n-1] {
+			s[n] = s[i]
+			n++
+		}
+	}
+	return s[:n]
+}
+
+// Index returns the index of u in the sorted slice s, or -1 if u is not
+// present. It uses binary search, so s must be sorted in ascending byte order.
+func Index(s []UUID, u UUID) int {
+	i := sort.Search(len(s), func(i int) bool { return s[i].Compare(u) >= 0 })
+	if i < len(s) && s[i] == u {
+		return i
+	}
+	return -1
+}
+
+// Contains reports whether u is present in the sorted slice s. It uses binary
+// search, so s must be sorted in ascending byte order.
+func Contains(s []UUID, u UUID) bool {
+	return Index(s, u) >= 0
+}
+
+// Merge returns a new sorted slice containing the elements of the sorted
+// slices a and b. Duplicates are preserved; use Dedup on the result to remove
+// them.
+func Merge(a, b []UUID) []UUID {
+	out := make([]UUID, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if b[0].Compare(a[0]) < 0 {
+			out = append(out, b[0])
+			b = b[1:]
+		} else {
+			out = append(out, a[0])
+			a = a[1:]
+		}
+	}
+	out = append(out, a...)
+	return append(out, b...)
+}
@@ -0,0 +1,103 @@
+package uuid
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+var sliceTestUUIDs = []UUID{
+	Nil,
+	{15: 0x01},
+	{14: 0x01},
+	NamespaceDNS,
+	NamespaceURL,
+	{0: 0x80},
+	Max,
+}
+
+func TestSort(t *testing.T) {
+	s := []UUID{Max, NamespaceURL, {15: 0x01}, Nil, {0: 0x80}, NamespaceDNS, {14: 0x01}}
+	if IsSorted(s) {
+		t.Errorf("IsSorted(%v) = true, want false", s)
+	}
+	Sort(s)
+	if !reflect.DeepEqual(s, sliceTestUUIDs) {
+		t.Errorf("Sort() = %v, want %v", s, sliceTestUUIDs)
+	}
+	if !IsSorted(s) {
+		t.Errorf("IsSorted(%v) = false, want true", s)
+	}
+	if !IsSorted(nil) {
+		t.Error("IsSorted(nil) = false, want true")
+	}
+}
+
+func TestSlice(t *testing.T) {
+	s := Slice{Max, NamespaceURL, {15: 0x01}, Nil, {0: 0x80}, NamespaceDNS, {14: 0x01}}
+	sort.Sort(s)
+	if !reflect.DeepEqual([]UUID(s), sliceTestUUIDs) {
+		t.Errorf("sort.Sort(Slice) = %v, want %v", s, sliceTestUUIDs)
+	}
+	if !sort.IsSorted(s) {
+		t.Errorf("sort.IsSorted(%v) = false, want true", s)
+	}
+}
+
+func TestDedup(t *testing.T) {
+	tests := []struct {
+		in, want []UUID
+	}{
+		{in: nil, want: nil},
+		{in: []UUID{Nil}, want: []UUID{Nil}},
+		{in: []UUID{Nil, Nil, Nil}, want: []UUID{Nil}},
+		{in: []UUID{Nil, NamespaceDNS, NamespaceDNS, Max, Max}, want: []UUID{Nil, NamespaceDNS, Max}},
+		{in: []UUID{Nil, Max, Nil}, want: []UUID{Nil, Max, Nil}},
+	}
+	for _, tt := range tests {
+		in := append([]UUID(nil), tt.in...)
+		if got := Dedup(in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Dedup(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIndexContains(t *testing.T) {
+	for i, u := range sliceTestUUIDs {
+		if got := Index(sliceTestUUIDs, u); got != i {
+			t.Errorf("Index(%v) = %d, want %d", u, got, i)
+		}
+		if !Contains(sliceTestUUIDs, u) {
+			t.Errorf("Contains(%v) = false, want true", u)
+		}
+	}
+	for _, u := range []UUID{NamespaceOID, {15: 0x02}, {0: 0xfe}} {
+		if got := Index(sliceTestUUIDs, u); got != -1 {
+			t.Errorf("Index(%v) = %d, want -1", u, got)
+		}
+		if Contains(sliceTestUUIDs, u) {
+			t.Errorf("Contains(%v) = true, want false", u)
+		}
+	}
+	if Contains(nil, Nil) {
+		t.Error("Contains(nil, Nil) = true, want false")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := []UUID{Nil, NamespaceDNS, {0: 0x80}}
+	b := []UUID{{15: 0x01}, {14: 0x01}, NamespaceDNS, NamespaceURL, Max}
+	want := []UUID{Nil, {15: 0x01}, {14: 0x01}, NamespaceDNS, NamespaceDNS, NamespaceURL, {0: 0x80}, Max}
+	if got := Merge(a, b); !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge(%v, %v) = %v, want %v", a, b, got, want)
+	}
+	if got := Merge(b, a); !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge(%v, %v) = %v, want %v", b, a, got, want)
+	}
+	if got := Merge(nil, a); !reflect.DeepEqual(got, a) {
+		t.Errorf("Merge(nil, %v) = %v, want %v", a, got, a)
+	}
+	if got := Dedup(Merge(a, b)); !reflect.DeepEqual(got, sliceTestUUIDs) {
+		t.Errorf("Dedup(Merge(%v, %v)) = %v, want %v", a, b, got, sliceTestUUIDs)
+	}
+}
@@ -61,11 +61,22 @@ func (u *UUID) Scan(src interface{}) error {
 
 // NullUUID can be used with the standard sql package to represent a
 // UUID value that can be NULL in the database.
+//
+// NullUUID marshals to JSON null when it is not valid. Because encoding/json
+// never treats struct values as empty, the omitempty tag option has no effect
+// on NullUUID fields; use the omitzero tag option (Go 1.24 and later), which
+// consults IsZero, to omit invalid values instead.
 type NullUUID struct {
 	UUID  UUID
 	Valid bool
 }
 
+// IsZero reports whether u is NULL, that is, whether Valid is false. It lets
+// fields tagged with the encoding/json omitzero option be omitted when NULL.
+func (u NullUUID) IsZero() bool {
+	return !u.Valid
+}
+
 // Value implements the driver.Valuer interface.
 func (u NullUUID) Value() (driver.Value, error) {
 	if !u.Valid {
@@ -0,0 +1,16 @@
+//go:build go1.22
+
+package uuid
+
+import "database/sql"
+
+// SQLNull returns u as the generic sql.Null[UUID] type.
+func (u NullUUID) SQLNull() sql.Null[UUID] {
+	return sql.Null[UUID]{V: u.UUID, Valid: u.Valid}
+}
+
+// FromSQLNull returns the NullUUID equivalent of the generic sql.Null[UUID]
+// value n.
+func FromSQLNull(n sql.Null[UUID]) NullUUID {
+	return NullUUID{UUID: n.V, Valid: n.Valid}
+}
@@ -0,0 +1,42 @@
+//go:build go1.22
+
+package uuid
+
+import (
+	"database/sql"
+	"testing"
+)
+
+func TestNullUUIDSQLNull(t *testing.T) {
+	tests := []NullUUID{
+		{},
+		{UUID: codecTestUUID},
+		{Valid: true},
+		{UUID: codecTestUUID, Valid: true},
+	}
+	for _, nu := range tests {
+		n := nu.SQLNull()
+		if n.V != nu.UUID || n.Valid != nu.Valid {
+			t.Errorf("%#v.SQLNull() = %#v", nu, n)
+		}
+		if got := FromSQLNull(n); got != nu {
+			t.Errorf("FromSQLNull(%#v) = %#v, want %#v", n, got, nu)
+		}
+	}
+}
+
+func TestSQLNullScan(t *testing.T) {
+	var n sql.Null[UUID]
+	if err := n.Scan(codecTestData); err != nil {
+		t.Fatal(err)
+	}
+	if got := FromSQLNull(n); !got.Valid || got.UUID != codecTestUUID {
+		t.Errorf("FromSQLNull after Scan(%x) = %#v, want valid %v", codecTestData, got, codecTestUUID)
+	}
+	if err := n.Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := FromSQLNull(n); got.Valid {
+		t.Errorf("FromSQLNull after Scan(nil) = %#v, want invalid", got)
+	}
+}
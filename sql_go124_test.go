@@ -0,0 +1,35 @@
+//go:build go1.24
+
+package uuid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOmitZero(t *testing.T) {
+	type record struct {
+		ID     UUID     `json:"id,omitzero"`
+		Parent NullUUID `json:"parent,omitzero"`
+	}
+	tests := []struct {
+		in   record
+		want string
+	}{
+		{in: record{}, want: `{}`},
+		{in: record{Parent: NullUUID{UUID: codecTestUUID}}, want: `{}`},
+		{
+			in:   record{ID: codecTestUUID, Parent: NullUUID{Valid: true}},
+			want: `{"id":"6ba7b810-9dad-11d1-80b4-00c04fd430c8","parent":"00000000-0000-0000-0000-000000000000"}`,
+		},
+	}
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("json.Marshal(%#v) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
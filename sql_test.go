@@ -121,6 +121,8 @@ func TestNullUUID(t *testing.T) {
 		t.Run("UUID", testNullUUIDScanUUID)
 	})
 
+	t.Run("IsZero", testNullUUIDIsZero)
+
 	t.Run("MarshalJSON", func(t *testing.T) {
 		t.Run("Nil", testNullUUIDMarshalJSONNil)
 		t.Run("Null", testNullUUIDMarshalJSONNull)
@@ -208,6 +210,23 @@ func testNullUUIDScanUUID(t *testing.T) {
 	}
 }
 
+func testNullUUIDIsZero(t *testing.T) {
+	tests := []struct {
+		nu   NullUUID
+		want bool
+	}{
+		{nu: NullUUID{}, want: true},
+		{nu: NullUUID{UUID: codecTestUUID}, want: true},
+		{nu: NullUUID{Valid: true}, want: false},
+		{nu: NullUUID{UUID: codecTestUUID, Valid: true}, want: false},
+	}
+	for _, tt := range tests {
+		if got := tt.nu.IsZero(); got != tt.want {
+			t.Errorf("%#v.IsZero() = %t, want %t", tt.nu, got, tt.want)
+		}
+	}
+}
+
 func testNullUUIDMarshalJSONNil(t *testing.T) {
 	u := NullUUID{Valid: true}
 
@@ -0,0 +1,76 @@
+package uuid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+var (
+	_ driver.Valuer = BinaryUUID{}
+	_ sql.Scanner   = (*BinaryUUID)(nil)
+	_ driver.Valuer = StringUUID{}
+	_ sql.Scanner   = (*StringUUID)(nil)
+)
+
+// BinaryUUID is a UUID whose Value method returns its 16 bytes, for storage
+// in BINARY(16), BYTEA or similar columns. It scans the same values as UUID,
+// so a column can be read whatever its representation.
+type BinaryUUID UUID
+
+// Value implements the driver.Valuer interface.
+func (u BinaryUUID) Value() (driver.Value, error) {
+	return UUID(u).Bytes(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (u *BinaryUUID) Scan(src interface{}) error {
+	return (*UUID)(u).Scan(src)
+}
+
+// String returns the canonical string representation of the UUID.
+func (u BinaryUUID) String() string {
+	return UUID(u).String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u BinaryUUID) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (u *BinaryUUID) UnmarshalText(b []byte) error {
+	return (*UUID)(u).UnmarshalText(b)
+}
+
+// StringUUID is a UUID whose Value method returns its canonical string
+// representation, for storage in CHAR(36) or similar columns. It scans the
+// same values as UUID, so a column can be read whatever its representation.
+//
+// StringUUID behaves like UUID, whose Value method also returns a string,
+// and documents the representation chosen for a column.
+type StringUUID UUID
+
+// Value implements the driver.Valuer interface.
+func (u StringUUID) Value() (driver.Value, error) {
+	return UUID(u).String(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (u *StringUUID) Scan(src interface{}) error {
+	return (*UUID)(u).Scan(src)
+}
+
+// String returns the canonical string representation of the UUID.
+func (u StringUUID) String() string {
+	return UUID(u).String()
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u StringUUID) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (u *StringUUID) UnmarshalText(b []byte) error {
+	return (*UUID)(u).UnmarshalText(b)
+}
@@ -0,0 +1,77 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestBinaryUUID(t *testing.T) {
+	v, err := BinaryUUID(codecTestUUID).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, ok := v.([]byte); !ok || !bytes.Equal(b, codecTestData) {
+		t.Errorf("BinaryUUID(%v).Value() = %#v, want %x", codecTestUUID, v, codecTestData)
+	}
+
+	for _, src := range []interface{}{codecTestData, codecTestUUID.String(), []byte(codecTestUUID.String()), codecTestUUID} {
+		var got BinaryUUID
+		if err := got.Scan(src); err != nil {
+			t.Errorf("BinaryUUID.Scan(%#v) unexpected error: %v", src, err)
+		}
+		if UUID(got) != codecTestUUID {
+			t.Errorf("BinaryUUID.Scan(%#v) = %v, want %v", src, got, codecTestUUID)
+		}
+	}
+	var u BinaryUUID
+	if err := u.Scan(42); err == nil {
+		t.Error("BinaryUUID.Scan(42) succeeded, want error")
+	}
+
+	if got := BinaryUUID(codecTestUUID).String(); got != codecTestUUID.String() {
+		t.Errorf("BinaryUUID(%v).String() = %q", codecTestUUID, got)
+	}
+	data, err := json.Marshal(BinaryUUID(codecTestUUID))
+	if err != nil || string(data) != `"`+codecTestUUID.String()+`"` {
+		t.Errorf("json.Marshal(BinaryUUID(%v)) = %s, %v", codecTestUUID, data, err)
+	}
+	if err := json.Unmarshal(data, &u); err != nil || UUID(u) != codecTestUUID {
+		t.Errorf("json.Unmarshal(%s) = %v, %v, want %v", data, u, err, codecTestUUID)
+	}
+}
+
+func TestStringUUID(t *testing.T) {
+	v, err := StringUUID(codecTestUUID).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s, ok := v.(string); !ok || s != codecTestUUID.String() {
+		t.Errorf("StringUUID(%v).Value() = %#v, want %q", codecTestUUID, v, codecTestUUID.String())
+	}
+
+	for _, src := range []interface{}{codecTestData, codecTestUUID.String(), []byte(codecTestUUID.String()), codecTestUUID} {
+		var got StringUUID
+		if err := got.Scan(src); err != nil {
+			t.Errorf("StringUUID.Scan(%#v) unexpected error: %v", src, err)
+		}
+		if UUID(got) != codecTestUUID {
+			t.Errorf("StringUUID.Scan(%#v) = %v, want %v", src, got, codecTestUUID)
+		}
+	}
+	var u StringUUID
+	if err := u.Scan(42); err == nil {
+		t.Error("StringUUID.Scan(42) succeeded, want error")
+	}
+
+	if got := StringUUID(codecTestUUID).String(); got != codecTestUUID.String() {
+		t.Errorf("StringUUID(%v).String() = %q", codecTestUUID, got)
+	}
+	data, err := json.Marshal(StringUUID(codecTestUUID))
+	if err != nil || string(data) != `"`+codecTestUUID.String()+`"` {
+		t.Errorf("json.Marshal(StringUUID(%v)) = %s, %v", codecTestUUID, data, err)
+	}
+	if err := json.Unmarshal(data, &u); err != nil || UUID(u) != codecTestUUID {
+		t.Errorf("json.Unmarshal(%s) = %v, %v, want %v", data, u, err, codecTestUUID)
+	}
+}
@@ -0,0 +1,126 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Layout of the state returned by ExportState: a format version, the last
+// timestamp, the clock sequence, the flags and the V7 state of a
+// MonotonicGen, in big-endian byte order.
+const (
+	stateVersion = 1
+	stateSize    = 1 + 8 + 2 + 1 + 8
+
+	// stateClockSequenceSet is the flag of a state with a clock sequence,
+	// which is not set until a generator first needs it.
+	stateClockSequenceSet = 1 << 0
+)
+
+// ExportState returns the state g uses to keep its UUIDs unique and
+// ordered: the timestamp of the last UUID generated and the clock sequence.
+// Stashed outside of the process and restored with ImportState into the
+// generator of a later process, such as the next invocation of a serverless
+// function, it extends these guarantees across restarts, including when the
+// clock of the new process is behind. The state is a few bytes long and its
+// format is stable across releases.
+func (g *Gen) ExportState() []byte {
+	return g.exportState(0)
+}
+
+// ImportState restores the state exported by ExportState into g, usually
+// before g generates its first UUID. Unless the last timestamp of the state
+// is older than the one of g, the clock sequence of g is replaced and its
+// last timestamp advanced to the one of the state; importing an older state
+// does not change g, so that it neither goes back in time nor repeats a
+// clock sequence. It returns an
+// error wrapping ErrInvalidArgument if state is malformed.
+func (g *Gen) ImportState(state []byte) error {
+	_, err := g.importState(state)
+	return err
+}
+
+// ExportState returns the state of g, as Gen.ExportState does, with the
+// timestamp and counter of its last V7 UUID. The state of the V7 UUIDs of a
+// generator with a Coordinator is held by the Coordinator, and not exported.
+func (g *MonotonicGen) ExportState() []byte {
+	return g.Gen.exportState(g.state.Load())
+}
+
+// ImportState restores the state exported by ExportState into g, as
+// Gen.ImportState does. The timestamp and counter of its V7 UUIDs are
+// advanced to those of the state, if later, so that the next V7 UUIDs of g
+// sort after those generated before the state was exported. A state
+// exported by a Gen has no V7 state.
+func (g *MonotonicGen) ImportState(state []byte) error {
+	v7, err := g.Gen.importState(state)
+	if err != nil {
+		return err
+	}
+	for {
+		last := g.state.Load()
+		if v7 <= last || g.state.CompareAndSwap(last, v7) {
+			return nil
+		}
+	}
+}
+
+func (g *Gen) exportState(v7 uint64) []byte {
+	g.storageMutex.Lock()
+	defer g.storageMutex.Unlock()
+
+	// storageMutex excludes the changes of the clock sequence by
+	// getClockSequence but not its fast path, which only advances lastTime,
+	// nor SetClockSequence: the last timestamp is consistent with the clock
+	// sequence if the clock sequence did not change while it was loaded.
+	var lastTime uint64
+	var clockSeq uint32
+	var hasClockSeq bool
+	for {
+		clockSeq = g.clockSequence.Load()
+		hasClockSeq = g.hasClockSequence.Load()
+		lastTime = g.lastTime.Load()
+		if g.clockSequence.Load() == clockSeq {
+			break
+		}
+	}
+
+	b := make([]byte, stateSize)
+	b[0] = stateVersion
+	binary.BigEndian.PutUint64(b[1:], lastTime)
+	binary.BigEndian.PutUint16(b[9:], uint16(clockSeq)&clockSequenceMask)
+	if hasClockSeq {
+		b[11] |= stateClockSequenceSet
+	}
+	binary.BigEndian.PutUint64(b[12:], v7)
+	return b
+}
+
+// importState imports state into g, and returns its V7 state.
+func (g *Gen) importState(state []byte) (uint64, error) {
+	if len(state) != stateSize || state[0] != stateVersion {
+		return 0, fmt.Errorf("%w: malformed generator state %x", ErrInvalidArgument, state)
+	}
+	lastTime := binary.BigEndian.Uint64(state[1:])
+	clockSeq := binary.BigEndian.Uint16(state[9:])
+	flags := state[11]
+
+	g.storageMutex.Lock()
+	defer g.storageMutex.Unlock()
+	// The clock sequence of an older state may be behind the one g used
+	// for its last timestamp, and would repeat it: only a state at least as
+	// recent as g replaces it.
+	if lastTime < g.lastTime.Load() {
+		return binary.BigEndian.Uint64(state[12:]), nil
+	}
+	if flags&stateClockSequenceSet != 0 {
+		g.SetClockSequence(clockSeq)
+	}
+	for {
+		last := g.lastTime.Load()
+		if lastTime <= last || g.lastTime.CompareAndSwap(last, lastTime) {
+			break
+		}
+	}
+	return binary.BigEndian.Uint64(state[12:]), nil
+}
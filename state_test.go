@@ -0,0 +1,172 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGenState(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	newGen := func(at time.Time) *Gen {
+		return NewGenWithOptions(
+			WithEpochFunc(func() time.Time { return at }),
+			WithHWAddrFunc(func() (HWAddr, error) { return HWAddr{1, 2, 3, 4, 5, 6}, nil }),
+		)
+	}
+
+	t.Run("Format", func(t *testing.T) {
+		g := newGen(now)
+		want := "01" + "0000000000000000" + "0000" + "00" + "0000000000000000"
+		if got := hex.EncodeToString(g.ExportState()); got != want {
+			t.Errorf("ExportState() of a new generator = %s, want %s", got, want)
+		}
+		g.SetClockSequence(0x1234)
+		Must(g.NewV1())
+		ts, _ := g.getEpoch(now)
+		want = "01" + hex.EncodeToString(binary.BigEndian.AppendUint64(nil, ts)) + "1234" + "01" + "0000000000000000"
+		if got := hex.EncodeToString(g.ExportState()); got != want {
+			t.Errorf("ExportState() = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("ColdStart", func(t *testing.T) {
+		g := newGen(now)
+		u1 := Must(g.NewV1())
+		state := g.ExportState()
+
+		for _, at := range []time.Time{now, now.Add(-time.Second)} {
+			g2 := newGen(at)
+			if err := g2.ImportState(state); err != nil {
+				t.Fatalf("ImportState(%x) unexpected error: %v", state, err)
+			}
+			u2 := Must(g2.NewV1())
+			seq1, _ := u1.ClockSequence()
+			seq2, _ := u2.ClockSequence()
+			if seq2 != (seq1+1)&clockSequenceMask {
+				t.Errorf("clock sequence after ImportState at %v = %d, want %d", at, seq2, seq1+1)
+			}
+		}
+
+		// A later clock keeps the clock sequence.
+		g2 := newGen(now.Add(time.Second))
+		if err := g2.ImportState(state); err != nil {
+			t.Fatal(err)
+		}
+		u2 := Must(g2.NewV1())
+		seq1, _ := u1.ClockSequence()
+		if seq2, _ := u2.ClockSequence(); seq2 != seq1 {
+			t.Errorf("clock sequence after ImportState with a later clock = %d, want %d", seq2, seq1)
+		}
+	})
+
+	t.Run("NoClockSequence", func(t *testing.T) {
+		g := newGen(now)
+		g.SetClockSequence(42)
+		if err := g.ImportState(newGen(now).ExportState()); err != nil {
+			t.Fatal(err)
+		}
+		if seq, _ := g.ClockSequence(); seq != 42 {
+			t.Errorf("ClockSequence() after importing a state without one = %d, want 42", seq)
+		}
+	})
+
+	t.Run("Older", func(t *testing.T) {
+		old := newGen(now)
+		Must(old.NewV1())
+		g := newGen(now.Add(time.Hour))
+		Must(g.NewV1())
+		last := g.lastTime.Load()
+		if err := g.ImportState(old.ExportState()); err != nil {
+			t.Fatal(err)
+		}
+		if got := g.lastTime.Load(); got != last {
+			t.Errorf("last timestamp after importing an older state = %d, want %d", got, last)
+		}
+	})
+
+	t.Run("OlderClockSequence", func(t *testing.T) {
+		g := newGen(now)
+		g.SetClockSequence(100)
+		u1 := Must(g.NewV1())
+		u2 := Must(g.NewV1())
+
+		// A state older than u2, with the clock sequence before it.
+		old := newGen(now.Add(-time.Second))
+		old.SetClockSequence(100)
+		Must(old.NewV1())
+		if err := g.ImportState(old.ExportState()); err != nil {
+			t.Fatal(err)
+		}
+		if u := Must(g.NewV1()); u == u1 || u == u2 {
+			t.Errorf("NewV1() after importing an older state = %v, generated before", u)
+		}
+		if seq, _ := g.ClockSequence(); seq != 102 {
+			t.Errorf("ClockSequence() after importing an older state = %d, want 102", seq)
+		}
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		valid := newGen(now).ExportState()
+		badVersion := append([]byte(nil), valid...)
+		badVersion[0] = 2
+		for _, state := range [][]byte{nil, valid[:stateSize-1], append(valid, 0), badVersion} {
+			if err := newGen(now).ImportState(state); !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("ImportState(%x) error = %v, want %v", state, err, ErrInvalidArgument)
+			}
+			if err := NewMonotonicGen().ImportState(state); !errors.Is(err, ErrInvalidArgument) {
+				t.Errorf("MonotonicGen.ImportState(%x) error = %v, want %v", state, err, ErrInvalidArgument)
+			}
+		}
+	})
+}
+
+func TestMonotonicGenState(t *testing.T) {
+	now := time.UnixMilli(1700000000123)
+	newGen := func(at time.Time) *MonotonicGen {
+		return NewMonotonicGen(WithEpochFunc(func() time.Time { return at }))
+	}
+
+	g := newGen(now)
+	batch, err := g.GenerateBatchV7(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := batch[len(batch)-1]
+	state := g.ExportState()
+	if want := g.state.Load(); !bytes.Equal(state[12:], binary.BigEndian.AppendUint64(nil, want)) {
+		t.Errorf("ExportState() V7 state = %x, want %x", state[12:], binary.BigEndian.AppendUint64(nil, want))
+	}
+
+	// A new process, with its clock behind.
+	g2 := newGen(now.Add(-10 * time.Millisecond))
+	if u := Must(g2.NewV7()); u.Compare(last) > 0 {
+		t.Fatalf("test setup: %v generated with a clock behind sorts after %v", u, last)
+	}
+	g2 = newGen(now.Add(-10 * time.Millisecond))
+	if err := g2.ImportState(state); err != nil {
+		t.Fatalf("ImportState(%x) unexpected error: %v", state, err)
+	}
+	if u := Must(g2.NewV7()); u.Compare(last) <= 0 {
+		t.Errorf("NewV7() after ImportState = %v, want a UUID after %v", u, last)
+	}
+
+	// Importing an older state does not go back.
+	g3 := newGen(now.Add(time.Second))
+	u := Must(g3.NewV7())
+	if err := g3.ImportState(state); err != nil {
+		t.Fatal(err)
+	}
+	if next := Must(g3.NewV7()); next.Compare(u) <= 0 {
+		t.Errorf("NewV7() after importing an older state = %v, want a UUID after %v", next, u)
+	}
+
+	// The state of a Gen has no V7 state.
+	g4 := newGen(now)
+	if err := g4.ImportState(NewGen().ExportState()); err != nil || g4.state.Load() != 0 {
+		t.Errorf("ImportState() of the state of a Gen = %v, V7 state %d", err, g4.state.Load())
+	}
+}
@@ -0,0 +1,89 @@
+package uuid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+)
+
+// Tokenizer derives external UUIDs, or tokens, from internal UUIDs with
+// HMAC-SHA256, to pseudonymize identifiers: the same internal UUID and key
+// always give the same token, but a token cannot be traced back to its UUID
+// without the key.
+//
+// A token is a version 8 UUID whose first byte is the ID of the key it was
+// derived with, and whose other bits are taken from the HMAC of the key ID
+// and the internal UUID. Keys can then be rotated: a Tokenizer derives new
+// tokens with its current key, and verifies tokens derived with any of its
+// keys.
+//
+// A Tokenizer is immutable and safe for concurrent use. To rotate keys,
+// create a new Tokenizer with the new key as current, keeping the previous
+// keys as long as tokens derived with them must be verified.
+type Tokenizer struct {
+	current byte
+	keys    map[byte][]byte
+}
+
+// NewTokenizer returns a Tokenizer with the keys in keys, indexed by key ID,
+// deriving tokens with the key of ID current. It returns an error wrapping
+// ErrInvalidArgument if keys has no key of ID current, or has an empty key.
+func NewTokenizer(current byte, keys map[byte][]byte) (*Tokenizer, error) {
+	if _, ok := keys[current]; !ok {
+		return nil, fmt.Errorf("%w: no token key of ID %d", ErrInvalidArgument, current)
+	}
+	t := &Tokenizer{current: current, keys: make(map[byte][]byte, len(keys))}
+	for id, key := range keys {
+		if len(key) == 0 {
+			return nil, fmt.Errorf("%w: token key of ID %d is empty", ErrInvalidArgument, id)
+		}
+		t.keys[id] = append([]byte(nil), key...)
+	}
+	return t, nil
+}
+
+// KeyID returns the ID of the current key of t.
+func (t *Tokenizer) KeyID() byte {
+	return t.current
+}
+
+// Tokenize returns the token of u derived with the current key of t.
+func (t *Tokenizer) Tokenize(u UUID) UUID {
+	return tokenize(t.current, t.keys[t.current], u)
+}
+
+// Verify reports whether token was derived from u with one of the keys of t.
+func (t *Tokenizer) Verify(u, token UUID) bool {
+	id, err := TokenKeyID(token)
+	if err != nil {
+		return false
+	}
+	key, ok := t.keys[id]
+	if !ok {
+		return false
+	}
+	want := tokenize(id, key, u)
+	return hmac.Equal(want[:], token[:])
+}
+
+// TokenKeyID returns the ID of the key a token was derived with. It returns
+// an error wrapping ErrInvalidVersion if token is not a version 8 UUID of the
+// RFC 9562 variant.
+func TokenKeyID(token UUID) (byte, error) {
+	if token.Version() != 8 || token.Variant() != VariantRFC9562 {
+		return 0, fmt.Errorf("%w %s is version %d, not a version 8 token", ErrInvalidVersion, token, token.Version())
+	}
+	return token[0], nil
+}
+
+func tokenize(id byte, key []byte, u UUID) UUID {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte{id})
+	mac.Write(u[:])
+	var token UUID
+	token[0] = id
+	copy(token[1:], mac.Sum(nil))
+	token.SetVersion(8)
+	token.SetVariant(VariantRFC9562)
+	return token
+}
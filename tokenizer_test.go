@@ -0,0 +1,87 @@
+package uuid
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTokenizer(t *testing.T) {
+	keys := map[byte][]byte{1: []byte("key one"), 2: []byte("key two")}
+	t1, err := NewTokenizer(1, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t2, err := NewTokenizer(2, keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if t1.KeyID() != 1 || t2.KeyID() != 2 {
+		t.Errorf("KeyID() = %d, %d, want 1, 2", t1.KeyID(), t2.KeyID())
+	}
+
+	u := codecTestUUID
+	tok1 := t1.Tokenize(u)
+	if tok1 != t1.Tokenize(u) {
+		t.Errorf("Tokenize(%v) is not deterministic", u)
+	}
+	if tok1.Version() != 8 || tok1.Variant() != VariantRFC9562 {
+		t.Errorf("Tokenize(%v) = %v, want a version 8 UUID", u, tok1)
+	}
+	if id, err := TokenKeyID(tok1); err != nil || id != 1 {
+		t.Errorf("TokenKeyID(%v) = %d, %v, want 1", tok1, id, err)
+	}
+	tok2 := t2.Tokenize(u)
+	if tok2 == tok1 {
+		t.Errorf("Tokenize(%v) is the same with different keys", u)
+	}
+	if other := t1.Tokenize(Max); other == tok1 {
+		t.Errorf("Tokenize(%v) = Tokenize(%v)", Max, u)
+	}
+
+	// Rotating keys keeps the tokens of the previous key verifiable.
+	for _, tk := range []*Tokenizer{t1, t2} {
+		for _, tok := range []UUID{tok1, tok2} {
+			if !tk.Verify(u, tok) {
+				t.Errorf("Tokenizer %d: Verify(%v, %v) = false, want true", tk.KeyID(), u, tok)
+			}
+		}
+		if tk.Verify(Max, tok1) {
+			t.Errorf("Tokenizer %d: Verify(%v, %v) = true, want false", tk.KeyID(), Max, tok1)
+		}
+	}
+
+	// A token of a removed key, or not a token at all, is not verified.
+	t3, err := NewTokenizer(2, map[byte][]byte{2: keys[2]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if t3.Verify(u, tok1) {
+		t.Errorf("Verify(%v, %v) with key 1 removed = true, want false", u, tok1)
+	}
+	if t3.Verify(u, u) {
+		t.Errorf("Verify(%v, %v) = true, want false", u, u)
+	}
+	forged := tok2
+	forged[15] ^= 1
+	if t3.Verify(u, forged) {
+		t.Errorf("Verify(%v, %v) = true, want false", u, forged)
+	}
+
+	// Changing the caller's map does not change the Tokenizer.
+	keys[1][0] ^= 1
+	if t1.Tokenize(u) != tok1 {
+		t.Error("Tokenizer uses the caller's key slice")
+	}
+}
+
+func TestTokenizerErrors(t *testing.T) {
+	if _, err := NewTokenizer(1, map[byte][]byte{2: []byte("k")}); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("NewTokenizer(missing current) error = %v, want %v", err, ErrInvalidArgument)
+	}
+	if _, err := NewTokenizer(1, map[byte][]byte{1: nil}); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("NewTokenizer(empty key) error = %v, want %v", err, ErrInvalidArgument)
+	}
+	if _, err := TokenKeyID(codecTestUUID); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("TokenKeyID(%v) error = %v, want %v", codecTestUUID, err, ErrInvalidVersion)
+	}
+}
@@ -0,0 +1,129 @@
+package uuid
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Uint128 is an unsigned 128-bit integer split into its high and low 64-bit
+// halves. It provides allocation-free interoperability with systems that model
+// UUIDs as unsigned 128-bit integers, using the same big-endian interpretation
+// as BigInt.
+type Uint128 struct {
+	Hi uint64
+	Lo uint64
+}
+
+// Uint128 returns the UUID interpreted as an unsigned 128-bit integer.
+func (u UUID) Uint128() Uint128 {
+	hi, lo := u.Uint64Pair()
+	return Uint128{Hi: hi, Lo: lo}
+}
+
+// UUID returns the UUID whose unsigned 128-bit integer value is x.
+func (x Uint128) UUID() UUID {
+	return FromUint64Pair(x.Hi, x.Lo)
+}
+
+// Add returns u + n, interpreting u as an unsigned 128-bit integer as
+// Uint128 does, and whether the sum is representable. If it is not, the sum
+// wraps around past Max, from Nil. The sum is a key following u in the byte
+// order of Compare, for range scans and pagination cursors, rather than a
+// UUID of the version and variant of u.
+func (u UUID) Add(n uint64) (UUID, bool) {
+	x := u.Uint128()
+	lo, carry := bits.Add64(x.Lo, n, 0)
+	hi, carry := bits.Add64(x.Hi, 0, carry)
+	return Uint128{Hi: hi, Lo: lo}.UUID(), carry == 0
+}
+
+// Incr returns u + 1, the key immediately following u in the byte order of
+// Compare, and false if u is Max, the sum then wrapping around to Nil. See
+// Add.
+func (u UUID) Incr() (UUID, bool) {
+	return u.Add(1)
+}
+
+// Compare returns -1, 0 or +1 depending on whether x is less than, equal to or
+// greater than y.
+func (x Uint128) Compare(y Uint128) int {
+	switch {
+	case x.Hi < y.Hi:
+		return -1
+	case x.Hi > y.Hi:
+		return 1
+	case x.Lo < y.Lo:
+		return -1
+	case x.Lo > y.Lo:
+		return 1
+	}
+	return 0
+}
+
+// Less reports whether x is less than y.
+func (x Uint128) Less(y Uint128) bool {
+	return x.Compare(y) < 0
+}
+
+// String returns the base 10 representation of x.
+func (x Uint128) String() string {
+	b, _ := x.MarshalText()
+	return string(b)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+// The encoding is the base 10 representation of x.
+func (x Uint128) MarshalText() ([]byte, error) {
+	// The largest value has 39 decimal digits.
+	var buf [39]byte
+	i := len(buf)
+	for x.Hi != 0 {
+		// Peel off 19 digits at a time, which is the largest power of ten
+		// that fits in a uint64.
+		var r uint64
+		x.Hi, r = bits.Div64(0, x.Hi, 1e19)
+		x.Lo, r = bits.Div64(r, x.Lo, 1e19)
+		for j := 0; j < 19; j++ {
+			i--
+			buf[i] = byte('0' + r%10)
+			r /= 10
+		}
+	}
+	for {
+		i--
+		buf[i] = byte('0' + x.Lo%10)
+		x.Lo /= 10
+		if x.Lo == 0 {
+			break
+		}
+	}
+	return append([]byte(nil), buf[i:]...), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+// It accepts the base 10 representation of an unsigned 128-bit integer.
+func (x *Uint128) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		return fmt.Errorf("%w %q", ErrInvalidFormat, b)
+	}
+	var v Uint128
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return fmt.Errorf("%w %q", ErrInvalidFormat, b)
+		}
+		hiHi, hiLo := bits.Mul64(v.Hi, 10)
+		loHi, loLo := bits.Mul64(v.Lo, 10)
+		hi, carry := bits.Add64(hiLo, loHi, 0)
+		if hiHi != 0 || carry != 0 {
+			return fmt.Errorf("%w, got %s", ErrIntegerOutOfRange, b)
+		}
+		lo, carry := bits.Add64(loLo, uint64(c-'0'), 0)
+		hi, carry = bits.Add64(hi, 0, carry)
+		if carry != 0 {
+			return fmt.Errorf("%w, got %s", ErrIntegerOutOfRange, b)
+		}
+		v = Uint128{Hi: hi, Lo: lo}
+	}
+	*x = v
+	return nil
+}
@@ -0,0 +1,170 @@
+package uuid
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestUint128(t *testing.T) {
+	t.Run("Conversion", testUint128Conversion)
+	t.Run("Compare", testUint128Compare)
+	t.Run("MarshalText", testUint128MarshalText)
+	t.Run("UnmarshalText", testUint128UnmarshalText)
+	t.Run("JSON", testUint128JSON)
+	t.Run("Add", testUint128Add)
+}
+
+func testUint128Add(t *testing.T) {
+	tests := []struct {
+		u    string
+		n    uint64
+		want string
+		ok   bool
+	}{
+		{"00000000-0000-0000-0000-000000000000", 0, "00000000-0000-0000-0000-000000000000", true},
+		{"00000000-0000-0000-0000-000000000000", 1, "00000000-0000-0000-0000-000000000001", true},
+		{"6ba7b810-9dad-11d1-80b4-00c04fd430c8", 0x100, "6ba7b810-9dad-11d1-80b4-00c04fd431c8", true},
+		{"6ba7b810-9dad-11d1-ffff-ffffffffffff", 1, "6ba7b810-9dad-11d2-0000-000000000000", true},
+		{"6ba7b810-9dad-11d1-ffff-ffffffffff00", 0x101, "6ba7b810-9dad-11d2-0000-000000000001", true},
+		{"00000000-0000-0000-ffff-ffffffffffff", ^uint64(0), "00000000-0000-0001-ffff-fffffffffffe", true},
+		{"ffffffff-ffff-ffff-ffff-fffffffffffe", 1, "ffffffff-ffff-ffff-ffff-ffffffffffff", true},
+		{"ffffffff-ffff-ffff-ffff-ffffffffffff", 1, "00000000-0000-0000-0000-000000000000", false},
+		{"ffffffff-ffff-ffff-ffff-fffffffffff0", 0x20, "00000000-0000-0000-0000-000000000010", false},
+	}
+	for _, tt := range tests {
+		u := Must(FromString(tt.u))
+		got, ok := u.Add(tt.n)
+		if got.String() != tt.want || ok != tt.ok {
+			t.Errorf("%v.Add(%#x) = %v, %t, want %s, %t", u, tt.n, got, ok, tt.want, tt.ok)
+		}
+		// Check against big.Int arithmetic.
+		sum := u.BigInt()
+		sum.Add(sum, new(big.Int).SetUint64(tt.n))
+		if fits := sum.BitLen() <= 128; fits != tt.ok {
+			t.Errorf("%v + %#x fits in 128 bits: %t, want %t", u, tt.n, fits, tt.ok)
+		} else if fits && sum.Cmp(got.BigInt()) != 0 {
+			t.Errorf("%v + %#x = %v, want %v", u, tt.n, got.BigInt(), sum)
+		}
+		if tt.n == 1 {
+			if got, ok := u.Incr(); got.String() != tt.want || ok != tt.ok {
+				t.Errorf("%v.Incr() = %v, %t, want %s, %t", u, got, ok, tt.want, tt.ok)
+			}
+			if tt.ok && u.Compare(got) >= 0 {
+				t.Errorf("%v.Incr() = %v does not sort after it", u, got)
+			}
+		}
+	}
+}
+
+func testUint128Conversion(t *testing.T) {
+	for _, u := range []UUID{Nil, Max, NamespaceDNS, codecTestUUID} {
+		x := u.Uint128()
+		hi, lo := u.Uint64Pair()
+		if x.Hi != hi || x.Lo != lo {
+			t.Errorf("%v.Uint128() = %#v, want {Hi: %#x, Lo: %#x}", u, x, hi, lo)
+		}
+		if got := x.UUID(); got != u {
+			t.Errorf("%#v.UUID() = %v, want %v", x, got, u)
+		}
+	}
+}
+
+func testUint128Compare(t *testing.T) {
+	tests := []struct {
+		x, y Uint128
+		want int
+	}{
+		{x: Uint128{}, y: Uint128{}, want: 0},
+		{x: Uint128{Lo: 1}, y: Uint128{Lo: 2}, want: -1},
+		{x: Uint128{Hi: 1}, y: Uint128{Lo: ^uint64(0)}, want: 1},
+		{x: Uint128{Hi: 1, Lo: 5}, y: Uint128{Hi: 2}, want: -1},
+		{x: Uint128{Hi: 2, Lo: 5}, y: Uint128{Hi: 2, Lo: 4}, want: 1},
+	}
+	for _, tt := range tests {
+		if got := tt.x.Compare(tt.y); got != tt.want {
+			t.Errorf("%v.Compare(%v) = %d, want %d", tt.x, tt.y, got, tt.want)
+		}
+		if got := tt.x.Less(tt.y); got != (tt.want < 0) {
+			t.Errorf("%v.Less(%v) = %t, want %t", tt.x, tt.y, got, tt.want < 0)
+		}
+	}
+}
+
+func testUint128MarshalText(t *testing.T) {
+	for _, u := range []UUID{Nil, Max, NamespaceDNS, {15: 9}, {7: 1}, {0: 0x80}} {
+		want := u.BigInt().String()
+		got, err := u.Uint128().MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("%v.Uint128().MarshalText() = %s, want %s", u, got, want)
+		}
+		if s := u.Uint128().String(); s != want {
+			t.Errorf("%v.Uint128().String() = %s, want %s", u, s, want)
+		}
+	}
+}
+
+func testUint128UnmarshalText(t *testing.T) {
+	for _, want := range []UUID{Nil, Max, NamespaceDNS, {15: 9}, {7: 1}, {0: 0x80}} {
+		var x Uint128
+		if err := x.UnmarshalText([]byte(want.BigInt().String())); err != nil {
+			t.Fatalf("UnmarshalText(%s) unexpected error: %v", want.BigInt(), err)
+		}
+		if got := x.UUID(); got != want {
+			t.Errorf("UnmarshalText(%s) = %v, want %v", want.BigInt(), got, want)
+		}
+	}
+
+	invalid := []struct {
+		text string
+		err  error
+	}{
+		{text: "", err: ErrInvalidFormat},
+		{text: "-1", err: ErrInvalidFormat},
+		{text: "12a", err: ErrInvalidFormat},
+		{text: "340282366920938463463374607431768211456", err: ErrIntegerOutOfRange},
+		{text: "3402823669209384634633746074317682114550", err: ErrIntegerOutOfRange},
+	}
+	for _, tt := range invalid {
+		x := Uint128{Hi: 1, Lo: 1}
+		err := x.UnmarshalText([]byte(tt.text))
+		if !errors.Is(err, tt.err) {
+			t.Errorf("UnmarshalText(%q) error = %v, want %v", tt.text, err, tt.err)
+		}
+		if x != (Uint128{Hi: 1, Lo: 1}) {
+			t.Errorf("UnmarshalText(%q) modified the receiver: %v", tt.text, x)
+		}
+	}
+}
+
+func testUint128JSON(t *testing.T) {
+	type S struct {
+		ID Uint128
+	}
+	in := S{ID: NamespaceDNS.Uint128()}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"ID":"143098242404177361603877621312831893704"}`; string(b) != want {
+		t.Errorf("json.Marshal(%v) = %s, want %s", in, b, want)
+	}
+	var out S
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("json.Unmarshal(%s) = %v, want %v", b, out, in)
+	}
+}
+
+func BenchmarkUint128MarshalText(b *testing.B) {
+	x := Max.Uint128()
+	for i := 0; i < b.N; i++ {
+		x.MarshalText()
+	}
+}
@@ -38,6 +38,7 @@
 package uuid
 
 import (
+	"bytes"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
@@ -140,11 +141,70 @@ func TimestampFromV7(u UUID) (Timestamp, error) {
 		(int64(u[4]) << 8) |
 		int64(u[5])
 
-	// convert to format expected by Timestamp
-	tsNanos := epochStart + time.UnixMilli(t).UTC().UnixNano()/100
+	// convert to format expected by Timestamp, without time.Time.UnixNano,
+	// which overflows after the year 2262
+	tsNanos := epochStart + uint64(t)*(_100nsPerSecond/1000)
 	return Timestamp(tsNanos), nil
 }
 
+// timestampOf returns the Timestamp embedded within u and true if u is an
+// RFC 9562 UUID of a time-based version (1, 6 or 7), or false otherwise.
+func timestampOf(u UUID) (Timestamp, bool) {
+	if u.Variant() != VariantRFC9562 {
+		return 0, false
+	}
+	var ts Timestamp
+	var err error
+	switch u.Version() {
+	case V1:
+		ts, err = TimestampFromV1(u)
+	case V6:
+		ts, err = TimestampFromV6(u)
+	case V7:
+		ts, err = TimestampFromV7(u)
+	default:
+		return 0, false
+	}
+	return ts, err == nil
+}
+
+// Time returns the time embedded within a version 1, 6 or 7 UUID, with the
+// 100-nanosecond precision of versions 1 and 6, or the millisecond precision
+// of version 7. It returns an error for UUIDs of any other version or
+// variant.
+func (u UUID) Time() (time.Time, error) {
+	ts, ok := timestampOf(u)
+	if !ok {
+		if u.Variant() != VariantRFC9562 {
+			return time.Time{}, fmt.Errorf("%w: %s has variant %d, not the RFC 9562 variant", ErrInvalidVariant, u, u.Variant())
+		}
+		return time.Time{}, fmt.Errorf("%w %s is version %d, not version 1, 6 or 7", ErrInvalidVersion, u, u.Version())
+	}
+	return ts.Time()
+}
+
+// CompareByTime returns an integer comparing a and b chronologically by their
+// embedded timestamps, so that version 1, 6 and 7 UUIDs can be ordered
+// together, for example while migrating from one version to another. UUIDs
+// with equal timestamps are ordered by Compare. UUIDs without an embedded
+// timestamp sort after all time-based UUIDs, in byte order, which keeps the
+// ordering consistent for use with sorting functions.
+func CompareByTime(a, b UUID) int {
+	ta, aok := timestampOf(a)
+	tb, bok := timestampOf(b)
+	switch {
+	case aok && !bok:
+		return -1
+	case !aok && bok:
+		return 1
+	case aok && ta < tb:
+		return -1
+	case aok && ta > tb:
+		return 1
+	}
+	return a.Compare(b)
+}
+
 // Nil is the nil UUID, as specified in RFC-9562, that has all 128 bits set to
 // zero.
 var Nil = UUID{}
@@ -183,6 +243,20 @@ func (u UUID) IsNil() bool {
 	return u == Nil
 }
 
+// IsZero reports whether the UUID is the nil UUID. It is equivalent to IsNil,
+// and lets fields tagged with the encoding/json omitzero option, and other
+// encoders checking for an IsZero method, omit nil UUIDs.
+func (u UUID) IsZero() bool {
+	return u == Nil
+}
+
+// Compare returns an integer comparing u and other in byte order, which is
+// also the order of their 128-bit integer values and of their canonical string
+// forms. The result is 0 if u == other, -1 if u < other, and +1 if u > other.
+func (u UUID) Compare(other UUID) int {
+	return bytes.Compare(u[:], other[:])
+}
+
 // Version returns the algorithm version used to generate the UUID.
 func (u UUID) Version() byte {
 	return u[6] >> 4
@@ -238,6 +312,52 @@ func (u UUID) String() string {
 	return string(buf[:])
 }
 
+// HashString returns the 32 hex digits of the UUID without hyphens:
+// xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx.
+func (u UUID) HashString() string {
+	var buf [32]byte
+	hex.Encode(buf[:], u[:])
+	return string(buf[:])
+}
+
+// Format is a text representation of a UUID. All formats use lowercase hex
+// digits and are accepted by UnmarshalText.
+type Format byte
+
+// UUID text formats.
+const (
+	FormatCanonical Format = iota // xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+	FormatHash                    // xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx
+	FormatBraced                  // {xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx}
+	FormatURN                     // urn:uuid:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx
+)
+
+// AppendFormat appends the text representation of the UUID in format f to b
+// and returns the extended buffer. Unknown formats use FormatCanonical.
+func (u UUID) AppendFormat(b []byte, f Format) []byte {
+	switch f {
+	case FormatHash:
+		var buf [32]byte
+		hex.Encode(buf[:], u[:])
+		return append(b, buf[:]...)
+	case FormatBraced:
+		var buf [38]byte
+		buf[0] = '{'
+		encodeCanonical(buf[1:], u)
+		buf[37] = '}'
+		return append(b, buf[:]...)
+	case FormatURN:
+		var buf [45]byte
+		copy(buf[:], "urn:uuid:")
+		encodeCanonical(buf[9:], u)
+		return append(b, buf[:]...)
+	default:
+		var buf [36]byte
+		encodeCanonical(buf[:], u)
+		return append(b, buf[:]...)
+	}
+}
+
 // Format implements fmt.Formatter for UUID values.
 //
 // The behavior is as follows:
@@ -23,16 +23,24 @@ package uuid
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 )
 
 func TestUUID(t *testing.T) {
 	t.Run("IsNil", testUUIDIsNil)
+	t.Run("IsZero", testUUIDIsZero)
 	t.Run("Bytes", testUUIDBytes)
 	t.Run("String", testUUIDString)
+	t.Run("HashString", testUUIDHashString)
+	t.Run("AppendFormat", testUUIDAppendFormat)
+	t.Run("Compare", testUUIDCompare)
 	t.Run("Version", testUUIDVersion)
 	t.Run("Variant", testUUIDVariant)
 	t.Run("SetVersion", testUUIDSetVersion)
@@ -49,6 +57,21 @@ func testUUIDIsNil(t *testing.T) {
 	}
 }
 
+func testUUIDIsZero(t *testing.T) {
+	for _, tt := range []struct {
+		u    UUID
+		want bool
+	}{
+		{u: Nil, want: true},
+		{u: UUID{15: 0x01}, want: false},
+		{u: NamespaceDNS, want: false},
+	} {
+		if got := tt.u.IsZero(); got != tt.want {
+			t.Errorf("%v.IsZero() = %t, want %t", tt.u, got, tt.want)
+		}
+	}
+}
+
 func testUUIDBytes(t *testing.T) {
 	got := codecTestUUID.Bytes()
 	want := codecTestData
@@ -65,6 +88,63 @@ func testUUIDString(t *testing.T) {
 	}
 }
 
+func testUUIDHashString(t *testing.T) {
+	got := NamespaceDNS.HashString()
+	want := "6ba7b8109dad11d180b400c04fd430c8"
+	if got != want {
+		t.Errorf("%v.HashString() = %q, want %q", NamespaceDNS, got, want)
+	}
+	if u := Must(FromString(got)); u != NamespaceDNS {
+		t.Errorf("FromString(%q) = %v, want %v", got, u, NamespaceDNS)
+	}
+}
+
+func testUUIDAppendFormat(t *testing.T) {
+	tests := []struct {
+		f    Format
+		want string
+	}{
+		{f: FormatCanonical, want: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{f: FormatHash, want: "6ba7b8109dad11d180b400c04fd430c8"},
+		{f: FormatBraced, want: "{6ba7b810-9dad-11d1-80b4-00c04fd430c8}"},
+		{f: FormatURN, want: "urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{f: Format(255), want: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+	}
+	for _, tt := range tests {
+		got := string(NamespaceDNS.AppendFormat([]byte("id="), tt.f))
+		if want := "id=" + tt.want; got != want {
+			t.Errorf("%v.AppendFormat(%d) = %q, want %q", NamespaceDNS, tt.f, got, want)
+		}
+		if u := Must(FromString(tt.want)); u != NamespaceDNS {
+			t.Errorf("FromString(%q) = %v, want %v", tt.want, u, NamespaceDNS)
+		}
+	}
+}
+
+func testUUIDCompare(t *testing.T) {
+	tests := []struct {
+		a, b UUID
+		want int
+	}{
+		{a: Nil, b: Nil, want: 0},
+		{a: Nil, b: Max, want: -1},
+		{a: Max, b: Nil, want: 1},
+		{a: NamespaceDNS, b: NamespaceURL, want: -1},
+		{a: UUID{0: 0x01}, b: UUID{15: 0xff}, want: 1},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Compare(tt.b); got != tt.want {
+			t.Errorf("%v.Compare(%v) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got, want := tt.a.Compare(tt.b), strings.Compare(tt.a.String(), tt.b.String()); got != want {
+			t.Errorf("%v.Compare(%v) = %d, but the strings compare as %d", tt.a, tt.b, got, want)
+		}
+		if got := Less(tt.a, tt.b); got != (tt.want < 0) {
+			t.Errorf("Less(%v, %v) = %t, want %t", tt.a, tt.b, got, tt.want < 0)
+		}
+	}
+}
+
 func testUUIDVersion(t *testing.T) {
 	u := UUID{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}
 	if got, want := u.Version(), V1; got != want {
@@ -266,7 +346,7 @@ func TestTimestampFromV7(t *testing.T) {
 		// v7 is unix_ts_ms, so zero value time is unix epoch
 		{u: Must(FromString("00000000-0000-7000-0000-000000000000")), want: 122192928000000000},
 		{u: Must(FromString("018a8fec-3ced-7164-995f-93c80cbdc575")), want: 139139245386050000},
-		{u: Must(FromString("ffffffff-ffff-7fff-ffff-ffffffffffff")), want: Timestamp(epochStart + time.UnixMilli((1<<48)-1).UTC().UnixNano()/100)},
+		{u: Must(FromString("ffffffff-ffff-7fff-ffff-ffffffffffff")), want: Timestamp(epochStart + ((1<<48)-1)*10000)},
 	}
 	for _, tt := range tests {
 		got, err := TimestampFromV7(tt.u)
@@ -357,3 +437,92 @@ func FuzzNewMonotonicV7(f *testing.F) {
 		}
 	})
 }
+
+func TestCompareByTime(t *testing.T) {
+	g := NewGen()
+	base := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(fn func(time.Time) (UUID, error), d time.Duration) UUID {
+		t.Helper()
+		u, err := fn(base.Add(d))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return u
+	}
+	v1a := at(g.NewV1AtTime, 0)
+	v7a := at(g.NewV7AtTime, time.Millisecond)
+	v6a := at(g.NewV6AtTime, 2*time.Millisecond)
+	v1b := at(g.NewV1AtTime, 3*time.Millisecond)
+	v7b := at(g.NewV7AtTime, time.Second)
+	v4a := UUID{0x00, 6: 0x40, 8: 0x80}
+	v4b := UUID{0xff, 6: 0x40, 8: 0x80}
+	want := []UUID{v1a, v7a, v6a, v1b, v7b, v4a, v4b}
+
+	got := []UUID{v4b, v7b, v1b, v4a, v6a, v7a, v1a}
+	sort.Slice(got, func(i, j int) bool { return CompareByTime(got[i], got[j]) < 0 })
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sorted by CompareByTime = %v, want %v", got, want)
+	}
+	for i, a := range want {
+		for j, b := range want {
+			wantCmp := 0
+			if i < j {
+				wantCmp = -1
+			} else if i > j {
+				wantCmp = 1
+			}
+			if got := CompareByTime(a, b); got != wantCmp {
+				t.Errorf("CompareByTime(%v, %v) = %d, want %d", a, b, got, wantCmp)
+			}
+		}
+	}
+
+	// Same timestamp falls back to byte order.
+	v7c := at(g.NewV7AtTime, time.Second)
+	if got, want := CompareByTime(v7b, v7c), v7b.Compare(v7c); got != want {
+		t.Errorf("CompareByTime(%v, %v) = %d, want %d", v7b, v7c, got, want)
+	}
+}
+
+func TestUUIDTime(t *testing.T) {
+	at := time.Date(2024, 6, 1, 12, 34, 56, 123456700, time.UTC)
+	g := NewGen()
+	tests := []struct {
+		name string
+		new  func(time.Time) (UUID, error)
+		want time.Time
+	}{
+		{name: "V1", new: g.NewV1AtTime, want: at},
+		{name: "V6", new: g.NewV6AtTime, want: at},
+		{name: "V7", new: g.NewV7AtTime, want: at.Truncate(time.Millisecond)},
+	}
+	for _, tt := range tests {
+		u, err := tt.new(at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := u.Time()
+		if err != nil {
+			t.Fatalf("%s: %v.Time() unexpected error: %v", tt.name, u, err)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: %v.Time() = %v, want %v", tt.name, u, got, tt.want)
+		}
+	}
+
+	v1 := Must(g.NewV1AtTime(at))
+	microsoft := v1
+	microsoft.SetVariant(VariantMicrosoft)
+	v5 := Must(FromString("2ed6657d-e927-568b-95e1-2665a8aea6a2"))
+	for u, want := range map[UUID]error{
+		Nil:           ErrInvalidVariant,
+		Max:           ErrInvalidVariant,
+		microsoft:     ErrInvalidVariant,
+		Must(NewV4()): ErrInvalidVersion,
+		v5:            ErrInvalidVersion,
+	} {
+		if _, err := u.Time(); !errors.Is(err, want) {
+			t.Errorf("%v.Time() error = %v, want %v", u, err, want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Package uuidarrow stores the UUID types of github.com/gofrs/uuid/v5 in
+// Apache Arrow columns with github.com/apache/arrow-go/v18.
+//
+// UUIDs are stored as the canonical arrow.uuid extension type, whose storage
+// is FixedSizeBinary(16). Columns of this type keep each UUID in 16 bytes,
+// rather than as a dictionary-encoded or plain string, and are recognized
+// as UUIDs by other Arrow implementations, and by the Parquet writer of
+// arrow-go, which maps them to the Parquet UUID logical type.
+//
+// Arrow-go implements the extension type in its arrow/extensions package,
+// for the UUID type of github.com/google/uuid. The Builder and Reader of
+// this package append and read uuid.UUID values instead.
+package uuidarrow
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/extensions"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/gofrs/uuid/v5"
+)
+
+// Type returns the arrow.uuid extension type.
+func Type() *extensions.UUIDType {
+	return extensions.NewUUIDType()
+}
+
+// Field returns a field of the arrow.uuid extension type.
+func Field(name string, nullable bool) arrow.Field {
+	return arrow.Field{Name: name, Type: Type(), Nullable: nullable}
+}
+
+// Builder builds arrays of the arrow.uuid extension type from uuid.UUID
+// values.
+type Builder struct {
+	*extensions.UUIDBuilder
+}
+
+// NewBuilder returns a new Builder allocating memory from mem.
+func NewBuilder(mem memory.Allocator) *Builder {
+	return &Builder{extensions.NewUUIDBuilder(mem)}
+}
+
+// Append appends u to the array.
+func (b *Builder) Append(u uuid.UUID) {
+	b.AppendBytes(u)
+}
+
+// AppendNullUUID appends nu.UUID to the array if nu is valid, and a null
+// otherwise.
+func (b *Builder) AppendNullUUID(nu uuid.NullUUID) {
+	if !nu.Valid {
+		b.AppendNull()
+		return
+	}
+	b.AppendBytes(nu.UUID)
+}
+
+// AppendValues appends the values of v to the array. If valid is not empty,
+// it must have the length of v, and the values of v whose valid element is
+// false are appended as nulls.
+func (b *Builder) AppendValues(v []uuid.UUID, valid []bool) {
+	if len(valid) != 0 && len(valid) != len(v) {
+		panic("uuidarrow: len(v) != len(valid) && len(valid) != 0")
+	}
+	b.Reserve(len(v))
+	for i, u := range v {
+		if len(valid) != 0 && !valid[i] {
+			b.AppendNull()
+			continue
+		}
+		b.AppendBytes(u)
+	}
+}
+
+// NewUUIDArray returns a new array of the values appended to b, and resets
+// b so that it can be used to build a new array. The array must be released
+// by the caller.
+func (b *Builder) NewUUIDArray() *extensions.UUIDArray {
+	return b.NewArray().(*extensions.UUIDArray)
+}
+
+// Reader reads uuid.UUID values from an array of the arrow.uuid extension
+// type, or from its FixedSizeBinary(16) storage.
+type Reader struct {
+	arr *array.FixedSizeBinary
+}
+
+// NewReader returns a Reader of arr, which must be an array of the
+// arrow.uuid extension type, or a FixedSizeBinary(16) array, such as one
+// read from a file written without the extension type. The Reader does not
+// retain arr.
+func NewReader(arr arrow.Array) (*Reader, error) {
+	if ext, ok := arr.(array.ExtensionArray); ok {
+		if ext.ExtensionType().ExtensionName() != Type().ExtensionName() {
+			return nil, fmt.Errorf("uuidarrow: cannot read UUIDs from an array of extension type %s", ext.ExtensionType().ExtensionName())
+		}
+		arr = ext.Storage()
+	}
+	fsb, ok := arr.(*array.FixedSizeBinary)
+	if !ok || fsb.DataType().(*arrow.FixedSizeBinaryType).ByteWidth != uuid.Size {
+		return nil, fmt.Errorf("uuidarrow: cannot read UUIDs from an array of type %v", arr.DataType())
+	}
+	return &Reader{arr: fsb}, nil
+}
+
+// Len returns the length of the array.
+func (r *Reader) Len() int {
+	return r.arr.Len()
+}
+
+// IsNull reports whether the element at index i is null.
+func (r *Reader) IsNull(i int) bool {
+	return r.arr.IsNull(i)
+}
+
+// Value returns the UUID at index i, or uuid.Nil if the element is null.
+func (r *Reader) Value(i int) uuid.UUID {
+	var u uuid.UUID
+	if r.arr.IsValid(i) {
+		copy(u[:], r.arr.Value(i))
+	}
+	return u
+}
+
+// NullValue returns the UUID at index i as a uuid.NullUUID, which is
+// invalid if the element is null.
+func (r *Reader) NullValue(i int) uuid.NullUUID {
+	return uuid.NullUUID{UUID: r.Value(i), Valid: r.arr.IsValid(i)}
+}
+
+// Values returns the UUIDs of the array, with null elements as uuid.Nil.
+func (r *Reader) Values() []uuid.UUID {
+	v := make([]uuid.UUID, r.arr.Len())
+	for i := range v {
+		v[i] = r.Value(i)
+	}
+	return v
+}
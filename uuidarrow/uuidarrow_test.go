@@ -0,0 +1,142 @@
+package uuidarrow
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/gofrs/uuid/v5"
+)
+
+var testUUID = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+func TestBuilderAndReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	other := uuid.Must(uuid.NewV4())
+	b := NewBuilder(mem)
+	defer b.Release()
+	b.Append(testUUID)
+	b.AppendNull()
+	b.AppendNullUUID(uuid.NullUUID{UUID: other, Valid: true})
+	b.AppendNullUUID(uuid.NullUUID{UUID: other})
+	b.AppendValues([]uuid.UUID{other, testUUID}, []bool{false, true})
+	b.AppendValues([]uuid.UUID{other}, nil)
+	arr := b.NewUUIDArray()
+	defer arr.Release()
+
+	if !arrow.TypeEqual(arr.DataType(), Type()) {
+		t.Errorf("DataType() = %v, want %v", arr.DataType(), Type())
+	}
+	if got := arr.Storage().DataType(); !arrow.TypeEqual(got, &arrow.FixedSizeBinaryType{ByteWidth: uuid.Size}) {
+		t.Errorf("storage type = %v, want fixed_size_binary[16]", got)
+	}
+
+	want := []uuid.NullUUID{
+		{UUID: testUUID, Valid: true},
+		{},
+		{UUID: other, Valid: true},
+		{},
+		{},
+		{UUID: testUUID, Valid: true},
+		{UUID: other, Valid: true},
+	}
+	for _, a := range []arrow.Array{arr, arr.Storage()} {
+		r, err := NewReader(a)
+		if err != nil {
+			t.Fatalf("NewReader(%v) unexpected error: %v", a.DataType(), err)
+		}
+		if r.Len() != len(want) {
+			t.Fatalf("Len() = %d, want %d", r.Len(), len(want))
+		}
+		values := r.Values()
+		for i, w := range want {
+			if got := r.NullValue(i); got != w {
+				t.Errorf("NullValue(%d) = %+v, want %+v", i, got, w)
+			}
+			if got := r.Value(i); got != w.UUID || values[i] != w.UUID {
+				t.Errorf("Value(%d) = %v, Values()[%d] = %v, want %v", i, got, i, values[i], w.UUID)
+			}
+			if r.IsNull(i) == w.Valid {
+				t.Errorf("IsNull(%d) = %t, want %t", i, r.IsNull(i), !w.Valid)
+			}
+		}
+	}
+	if got := arr.Value(0); got != [uuid.Size]byte(testUUID) {
+		t.Errorf("UUIDArray.Value(0) = %v, want %v", got, testUUID)
+	}
+}
+
+func TestNewReaderInvalid(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	sb.Append(testUUID.String())
+	strs := sb.NewArray()
+	defer strs.Release()
+
+	fb := array.NewFixedSizeBinaryBuilder(mem, &arrow.FixedSizeBinaryType{ByteWidth: 8})
+	defer fb.Release()
+	fb.Append(testUUID[:8])
+	short := fb.NewArray()
+	defer short.Release()
+
+	for _, a := range []arrow.Array{strs, short} {
+		if _, err := NewReader(a); err == nil {
+			t.Errorf("NewReader(%v) succeeded, want error", a.DataType())
+		}
+	}
+}
+
+func TestIPCRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.DefaultAllocator)
+	defer mem.AssertSize(t, 0)
+
+	b := NewBuilder(mem)
+	defer b.Release()
+	b.AppendValues([]uuid.UUID{testUUID, uuid.Nil}, []bool{true, false})
+	arr := b.NewUUIDArray()
+	defer arr.Release()
+
+	schema := arrow.NewSchema([]arrow.Field{Field("id", true)}, nil)
+	rec := array.NewRecordBatch(schema, []arrow.Array{arr}, int64(arr.Len()))
+	defer rec.Release()
+
+	var buf bytes.Buffer
+	w := ipc.NewWriter(&buf, ipc.WithSchema(schema), ipc.WithAllocator(mem))
+	if err := w.Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	rd, err := ipc.NewReader(&buf, ipc.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rd.Release()
+	if !rd.Next() {
+		t.Fatalf("ipc.Reader.Next() = false: %v", rd.Err())
+	}
+	col := rd.RecordBatch().Column(0)
+	if !arrow.TypeEqual(col.DataType(), Type()) {
+		t.Errorf("read column type = %v, want %v", col.DataType(), Type())
+	}
+	r, err := NewReader(col)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := r.NullValue(0); got != (uuid.NullUUID{UUID: testUUID, Valid: true}) {
+		t.Errorf("NullValue(0) = %+v, want %v", got, testUUID)
+	}
+	if !r.IsNull(1) {
+		t.Error("IsNull(1) = false, want true")
+	}
+}
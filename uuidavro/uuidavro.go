@@ -0,0 +1,149 @@
+// Package uuidavro provides helpers for storing the UUID type of
+// github.com/gofrs/uuid/v5 in Avro's uuid logical type, which annotates
+// either a string holding the canonical form of the UUID, or, since Avro
+// 1.12, a fixed of size 16 holding its bytes.
+//
+// With github.com/hamba/avro/v2, uuid.UUID struct fields are encoded as
+// either form without help, through their encoding.TextMarshaler
+// implementation and their [16]byte underlying type. The TypeConverters
+// extend this to values decoded into, or encoded from, interfaces, such as
+// the map[string]any records used by generic schema-registry consumers.
+//
+// The Append and Read functions implement the binary encoding of both forms
+// for use with other Avro libraries.
+package uuidavro
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/hamba/avro/v2"
+)
+
+// LogicalType is the name of Avro's uuid logical type.
+const LogicalType = "uuid"
+
+// StringSchema returns the schema of the string form of the uuid logical
+// type: {"type":"string","logicalType":"uuid"}.
+func StringSchema() *avro.PrimitiveSchema {
+	return avro.NewPrimitiveSchema(avro.String, avro.NewPrimitiveLogicalSchema(avro.UUID))
+}
+
+// FixedSchema returns the schema of a fixed form of the uuid logical type
+// with the given name and namespace:
+// {"type":"fixed","name":name,"size":16,"logicalType":"uuid"}.
+func FixedSchema(name, namespace string) (*avro.FixedSchema, error) {
+	return avro.NewFixedSchema(name, namespace, uuid.Size, nil, avro.WithProps(map[string]any{"logicalType": LogicalType}))
+}
+
+// isUUIDFixed reports whether s is a fixed form of the uuid logical type.
+func isUUIDFixed(s avro.Schema) bool {
+	if ref, ok := s.(*avro.RefSchema); ok {
+		s = ref.Schema()
+	}
+	f, ok := s.(*avro.FixedSchema)
+	return ok && f.Size() == uuid.Size && f.Prop("logicalType") == LogicalType
+}
+
+// TypeConverters returns the hamba/avro type converters for the uuid
+// logical type. Values of the uuid logical type decoded into interfaces are
+// converted to uuid.UUID, and uuid.UUID values encoded from interfaces are
+// converted to the form of the schema. The converter of the fixed form
+// applies to all fixed schemas, but leaves values of other fixed schemas
+// unchanged.
+func TypeConverters() []avro.TypeConverter {
+	return []avro.TypeConverter{
+		avro.TypeConversionFuncs{
+			AvroType:        avro.String,
+			AvroLogicalType: avro.UUID,
+			EncoderTypeConversion: func(in any, _ avro.Schema) (any, error) {
+				if u, ok := in.(uuid.UUID); ok {
+					return u.String(), nil
+				}
+				return in, nil
+			},
+			DecoderTypeConversion: func(in any, _ avro.Schema) (any, error) {
+				s, ok := in.(string)
+				if !ok {
+					return in, nil
+				}
+				return uuid.FromString(s)
+			},
+		},
+		avro.TypeConversionFuncs{
+			AvroType: avro.Fixed,
+			EncoderTypeConversion: func(in any, s avro.Schema) (any, error) {
+				if u, ok := in.(uuid.UUID); ok && isUUIDFixed(s) {
+					return [uuid.Size]byte(u), nil
+				}
+				return in, nil
+			},
+			DecoderTypeConversion: func(in any, s avro.Schema) (any, error) {
+				if b, ok := in.([uuid.Size]byte); ok && isUUIDFixed(s) {
+					return uuid.UUID(b), nil
+				}
+				return in, nil
+			},
+		},
+	}
+}
+
+// RegisterTypeConverters registers the TypeConverters with api, or with
+// avro.DefaultConfig if api is nil.
+func RegisterTypeConverters(api avro.API) {
+	if api == nil {
+		api = avro.DefaultConfig
+	}
+	api.RegisterTypeConverters(TypeConverters()...)
+}
+
+// canonicalLength is the length of the canonical string form of a UUID.
+const canonicalLength = 36
+
+// AppendString appends the Avro binary encoding of the string form of u to
+// b: its length as a zig-zag varint, followed by its canonical form.
+func AppendString(b []byte, u uuid.UUID) []byte {
+	b = binary.AppendVarint(b, canonicalLength)
+	return u.AppendFormat(b, uuid.FormatCanonical)
+}
+
+// AppendFixed appends the Avro binary encoding of the fixed form of u to b,
+// which is its 16 bytes.
+func AppendFixed(b []byte, u uuid.UUID) []byte {
+	return append(b, u[:]...)
+}
+
+var errShort = errors.New("uuidavro: unexpected end of data")
+
+// ReadString decodes a UUID from the Avro binary encoding of its string form
+// at the start of b, returning it and the number of bytes read.
+func ReadString(b []byte) (uuid.UUID, int, error) {
+	n, l := binary.Varint(b)
+	if l <= 0 {
+		return uuid.Nil, 0, errShort
+	}
+	if n < 0 {
+		return uuid.Nil, 0, fmt.Errorf("uuidavro: invalid string length %d", n)
+	}
+	if n > int64(len(b)-l) {
+		return uuid.Nil, 0, errShort
+	}
+	u, err := uuid.FromString(string(b[l : l+int(n)]))
+	if err != nil {
+		return uuid.Nil, 0, err
+	}
+	return u, l + int(n), nil
+}
+
+// ReadFixed decodes a UUID from the Avro binary encoding of its fixed form
+// at the start of b, returning it and the number of bytes read.
+func ReadFixed(b []byte) (uuid.UUID, int, error) {
+	if len(b) < uuid.Size {
+		return uuid.Nil, 0, errShort
+	}
+	var u uuid.UUID
+	copy(u[:], b)
+	return u, uuid.Size, nil
+}
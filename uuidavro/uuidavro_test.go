@@ -0,0 +1,115 @@
+package uuidavro
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/hamba/avro/v2"
+)
+
+var testUUID = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+const recordSchema = `{
+	"type": "record",
+	"name": "Order",
+	"fields": [
+		{"name": "id", "type": {"type": "string", "logicalType": "uuid"}},
+		{"name": "ref", "type": {"type": "fixed", "name": "UUID", "size": 16, "logicalType": "uuid"}},
+		{"name": "hash", "type": {"type": "fixed", "name": "Hash", "size": 16}}
+	]
+}`
+
+type order struct {
+	ID   uuid.UUID `avro:"id"`
+	Ref  uuid.UUID `avro:"ref"`
+	Hash [16]byte  `avro:"hash"`
+}
+
+func TestSchemas(t *testing.T) {
+	if got, want := StringSchema().String(), `{"type":"string","logicalType":"uuid"}`; got != want {
+		t.Errorf("StringSchema() = %s, want %s", got, want)
+	}
+	fixed, err := FixedSchema("UUID", "com.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isUUIDFixed(fixed) {
+		t.Errorf("FixedSchema() = %s, is not a uuid fixed schema", fixed)
+	}
+	// String returns the canonical form, which omits the logical type.
+	js, err := fixed.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsed := avro.MustParse(string(js)); !isUUIDFixed(parsed) {
+		t.Errorf("parsed FixedSchema() = %s, is not a uuid fixed schema", js)
+	}
+}
+
+func TestStruct(t *testing.T) {
+	schema := avro.MustParse(recordSchema)
+	in := order{ID: testUUID, Ref: testUUID, Hash: [16]byte{1}}
+	data, err := avro.Marshal(schema, in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := AppendFixed(AppendString(nil, testUUID), testUUID)
+	if !bytes.HasPrefix(data, want) {
+		t.Errorf("Marshal(%+v) = %x, want prefix %x", in, data, want)
+	}
+	var out order
+	if err := avro.Unmarshal(schema, data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("Unmarshal(Marshal(%+v)) = %+v", in, out)
+	}
+}
+
+func TestTypeConverters(t *testing.T) {
+	api := avro.Config{}.Freeze()
+	RegisterTypeConverters(api)
+	schema := avro.MustParse(recordSchema)
+
+	in := map[string]any{"id": testUUID, "ref": testUUID, "hash": [16]byte{1}}
+	data, err := api.Marshal(schema, in)
+	if err != nil {
+		t.Fatalf("Marshal(%v) unexpected error: %v", in, err)
+	}
+	var out map[string]any
+	if err := api.Unmarshal(schema, data, &out); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+	if out["id"] != testUUID || out["ref"] != testUUID {
+		t.Errorf("Unmarshal() = %v, want id and ref %v", out, testUUID)
+	}
+	if out["hash"] != [16]byte{1} {
+		t.Errorf("Unmarshal() hash = %#v, want an unconverted [16]byte", out["hash"])
+	}
+}
+
+func TestReadAppend(t *testing.T) {
+	b := AppendString([]byte{0xff}, testUUID)
+	if len(b) != 1+1+36 || b[1] != 72 {
+		t.Fatalf("AppendString() = %x, want length prefix 72 and 36 bytes", b)
+	}
+	u, n, err := ReadString(b[1:])
+	if err != nil || u != testUUID || n != 37 {
+		t.Errorf("ReadString(%x) = %v, %d, %v, want %v, 37, <nil>", b[1:], u, n, err, testUUID)
+	}
+	b = AppendFixed(nil, testUUID)
+	u, n, err = ReadFixed(b)
+	if err != nil || u != testUUID || n != 16 {
+		t.Errorf("ReadFixed(%x) = %v, %d, %v, want %v, 16, <nil>", b, u, n, err, testUUID)
+	}
+
+	for _, b := range [][]byte{nil, {72, '6'}, {1}, append([]byte{72}, bytes.Repeat([]byte{'x'}, 36)...)} {
+		if _, _, err := ReadString(b); err == nil {
+			t.Errorf("ReadString(%x) succeeded, want error", b)
+		}
+	}
+	if _, _, err := ReadFixed(testUUID[:15]); err == nil {
+		t.Error("ReadFixed(15 bytes) succeeded, want error")
+	}
+}
@@ -0,0 +1,9 @@
+module github.com/gofrs/uuid/v5/uuidbson
+
+go 1.25.0
+
+require github.com/gofrs/uuid/v5 v5.0.0
+
+require go.mongodb.org/mongo-driver/v2 v2.9.1
+
+replace github.com/gofrs/uuid/v5 => ../
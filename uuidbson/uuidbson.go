@@ -0,0 +1,200 @@
+// Package uuidbson encodes the UUID types of github.com/gofrs/uuid/v5 as
+// BSON binary values of subtype 4, the standard representation of UUIDs in
+// MongoDB, for use with the MongoDB Go driver v2.
+//
+// Register a Codec with the registry used by a client to handle uuid.UUID
+// and uuid.NullUUID everywhere:
+//
+//	opts := options.Client().ApplyURI(uri).SetRegistry(uuidbson.NewRegistry(uuidbson.Codec{}))
+//
+// Alternatively, the UUID type implements bson.ValueMarshaler and
+// bson.ValueUnmarshaler and needs no registration.
+//
+// Besides subtype 4, UUIDs are decoded from legacy binary values of subtype
+// 3, in the byte order chosen by Codec.Legacy, and from strings, so that
+// documents which stored UUIDs as strings can still be read.
+package uuidbson
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+
+	"github.com/gofrs/uuid/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// LegacyByteOrder is the byte order of UUIDs stored as BSON binary values of
+// the legacy subtype 3, which differed between drivers.
+type LegacyByteOrder byte
+
+const (
+	// LegacyStandard is the byte order of subtype 4, used by the legacy
+	// Python driver.
+	LegacyStandard LegacyByteOrder = iota
+
+	// LegacyJava is the byte order of the legacy Java driver, which
+	// reverses the bytes of each half of the UUID.
+	LegacyJava
+
+	// LegacyCSharp is the byte order of the legacy C# driver, which stores
+	// the first three fields of the UUID in little-endian order, like a
+	// Microsoft GUID.
+	LegacyCSharp
+)
+
+// Codec is a bson.ValueEncoder and bson.ValueDecoder for uuid.UUID and
+// uuid.NullUUID values. UUIDs are encoded as binary values of subtype 4,
+// and invalid NullUUIDs as null.
+type Codec struct {
+	// Legacy is the byte order of decoded binary values of subtype 3.
+	Legacy LegacyByteOrder
+}
+
+var (
+	tUUID     = reflect.TypeOf(uuid.UUID{})
+	tNullUUID = reflect.TypeOf(uuid.NullUUID{})
+)
+
+// Register registers c for uuid.UUID and uuid.NullUUID with reg.
+func Register(reg *bson.Registry, c Codec) {
+	reg.RegisterTypeEncoder(tUUID, c)
+	reg.RegisterTypeDecoder(tUUID, c)
+	reg.RegisterTypeEncoder(tNullUUID, c)
+	reg.RegisterTypeDecoder(tNullUUID, c)
+}
+
+// NewRegistry returns a new bson.Registry with the default codecs and c.
+func NewRegistry(c Codec) *bson.Registry {
+	reg := bson.NewRegistry()
+	Register(reg, c)
+	return reg
+}
+
+// EncodeValue implements the bson.ValueEncoder interface.
+func (c Codec) EncodeValue(ec bson.EncodeContext, vw bson.ValueWriter, val reflect.Value) error {
+	switch val.Type() {
+	case tUUID:
+		u := val.Interface().(uuid.UUID)
+		return vw.WriteBinaryWithSubtype(u[:], bson.TypeBinaryUUID)
+	case tNullUUID:
+		nu := val.Interface().(uuid.NullUUID)
+		if !nu.Valid {
+			return vw.WriteNull()
+		}
+		return vw.WriteBinaryWithSubtype(nu.UUID[:], bson.TypeBinaryUUID)
+	}
+	return bson.ValueEncoderError{Name: "uuidbson.Codec", Types: []reflect.Type{tUUID, tNullUUID}, Received: val}
+}
+
+// DecodeValue implements the bson.ValueDecoder interface. Decoding null
+// into a uuid.UUID sets it to uuid.Nil.
+func (c Codec) DecodeValue(dc bson.DecodeContext, vr bson.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || (val.Type() != tUUID && val.Type() != tNullUUID) {
+		return bson.ValueDecoderError{Name: "uuidbson.Codec", Types: []reflect.Type{tUUID, tNullUUID}, Received: val}
+	}
+	var nu uuid.NullUUID
+	var err error
+	switch t := vr.Type(); t {
+	case bson.TypeBinary:
+		var data []byte
+		var subtype byte
+		if data, subtype, err = vr.ReadBinary(); err == nil {
+			nu.UUID, err = c.fromBinary(subtype, data)
+			nu.Valid = err == nil
+		}
+	case bson.TypeString:
+		var s string
+		if s, err = vr.ReadString(); err == nil {
+			nu.UUID, err = uuid.FromString(s)
+			nu.Valid = err == nil
+		}
+	case bson.TypeNull:
+		err = vr.ReadNull()
+	case bson.TypeUndefined:
+		err = vr.ReadUndefined()
+	default:
+		err = fmt.Errorf("uuidbson: cannot decode BSON %v into a UUID", t)
+	}
+	if err != nil {
+		return err
+	}
+	if val.Type() == tNullUUID {
+		val.Set(reflect.ValueOf(nu))
+	} else {
+		val.Set(reflect.ValueOf(nu.UUID))
+	}
+	return nil
+}
+
+// fromBinary returns the UUID held by a binary value of the given subtype.
+func (c Codec) fromBinary(subtype byte, data []byte) (uuid.UUID, error) {
+	if subtype != bson.TypeBinaryUUID && subtype != bson.TypeBinaryUUIDOld {
+		return uuid.Nil, fmt.Errorf("uuidbson: cannot decode BSON binary subtype %d into a UUID", subtype)
+	}
+	u, err := uuid.FromBytes(data)
+	if err != nil || subtype == bson.TypeBinaryUUID {
+		return u, err
+	}
+	switch c.Legacy {
+	case LegacyStandard:
+	case LegacyJava:
+		reverse(u[0:8])
+		reverse(u[8:16])
+	case LegacyCSharp:
+		reverse(u[0:4])
+		reverse(u[4:6])
+		reverse(u[6:8])
+	default:
+		return uuid.Nil, fmt.Errorf("uuidbson: unknown legacy byte order %d", c.Legacy)
+	}
+	return u, nil
+}
+
+func reverse(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
+
+// UUID is a uuid.UUID implementing bson.ValueMarshaler and
+// bson.ValueUnmarshaler, for use without registering a Codec. Legacy binary
+// values of subtype 3 are decoded in the LegacyStandard byte order.
+type UUID uuid.UUID
+
+// MarshalBSONValue implements the bson.ValueMarshaler interface.
+func (u UUID) MarshalBSONValue() (byte, []byte, error) {
+	data := make([]byte, 4+1+uuid.Size)
+	binary.LittleEndian.PutUint32(data, uuid.Size)
+	data[4] = bson.TypeBinaryUUID
+	copy(data[5:], u[:])
+	return byte(bson.TypeBinary), data, nil
+}
+
+// UnmarshalBSONValue implements the bson.ValueUnmarshaler interface.
+func (u *UUID) UnmarshalBSONValue(typ byte, data []byte) error {
+	rv := bson.RawValue{Type: bson.Type(typ), Value: data}
+	switch rv.Type {
+	case bson.TypeBinary:
+		subtype, b, ok := rv.BinaryOK()
+		if !ok {
+			return fmt.Errorf("uuidbson: invalid BSON binary value")
+		}
+		v, err := Codec{}.fromBinary(subtype, b)
+		if err != nil {
+			return err
+		}
+		*u = UUID(v)
+	case bson.TypeString:
+		s, ok := rv.StringValueOK()
+		if !ok {
+			return fmt.Errorf("uuidbson: invalid BSON string value")
+		}
+		return (*uuid.UUID)(u).UnmarshalText([]byte(s))
+	case bson.TypeNull, bson.TypeUndefined:
+		*u = UUID(uuid.Nil)
+	default:
+		return fmt.Errorf("uuidbson: cannot decode BSON %v into a UUID", rv.Type)
+	}
+	return nil
+}
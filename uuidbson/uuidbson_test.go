@@ -0,0 +1,166 @@
+package uuidbson
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var testUUID = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+type doc struct {
+	ID  uuid.UUID     `bson:"id"`
+	Ref uuid.NullUUID `bson:"ref"`
+}
+
+func marshal(t *testing.T, c Codec, v any) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	enc := bson.NewEncoder(bson.NewDocumentWriter(&buf))
+	enc.SetRegistry(NewRegistry(c))
+	if err := enc.Encode(v); err != nil {
+		t.Fatalf("Encode(%v) unexpected error: %v", v, err)
+	}
+	return buf.Bytes()
+}
+
+func unmarshal(c Codec, data []byte, v any) error {
+	dec := bson.NewDecoder(bson.NewDocumentReader(bytes.NewReader(data)))
+	dec.SetRegistry(NewRegistry(c))
+	return dec.Decode(v)
+}
+
+func TestCodec(t *testing.T) {
+	in := doc{ID: testUUID, Ref: uuid.NullUUID{UUID: testUUID, Valid: true}}
+	data := marshal(t, Codec{}, in)
+
+	raw := bson.Raw(data)
+	for _, key := range []string{"id", "ref"} {
+		subtype, b, ok := raw.Lookup(key).BinaryOK()
+		if !ok || subtype != bson.TypeBinaryUUID || !bytes.Equal(b, testUUID[:]) {
+			t.Errorf("%s encoded as %v, want binary subtype 4 of %x", key, raw.Lookup(key), testUUID[:])
+		}
+	}
+
+	var out doc
+	if err := unmarshal(Codec{}, data, &out); err != nil {
+		t.Fatalf("Decode() unexpected error: %v", err)
+	}
+	if out != in {
+		t.Errorf("Decode() = %+v, want %+v", out, in)
+	}
+
+	t.Run("Null", func(t *testing.T) {
+		data := marshal(t, Codec{}, doc{ID: testUUID})
+		if got := bson.Raw(data).Lookup("ref").Type; got != bson.TypeNull {
+			t.Errorf("invalid NullUUID encoded as %v, want null", got)
+		}
+		out := doc{Ref: uuid.NullUUID{UUID: testUUID, Valid: true}}
+		if err := unmarshal(Codec{}, data, &out); err != nil {
+			t.Fatal(err)
+		}
+		if out.Ref.Valid || out.Ref.UUID != uuid.Nil {
+			t.Errorf("Decode(null) = %+v, want the zero NullUUID", out.Ref)
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		data, err := bson.Marshal(bson.D{{Key: "id", Value: testUUID.String()}, {Key: "ref", Value: testUUID.String()}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out doc
+		if err := unmarshal(Codec{}, data, &out); err != nil {
+			t.Fatal(err)
+		}
+		if out != in {
+			t.Errorf("Decode() = %+v, want %+v", out, in)
+		}
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, v := range []any{
+			bson.D{{Key: "id", Value: int32(42)}},
+			bson.D{{Key: "id", Value: "not-a-uuid"}},
+			bson.D{{Key: "id", Value: bson.Binary{Subtype: bson.TypeBinaryGeneric, Data: testUUID[:]}}},
+			bson.D{{Key: "id", Value: bson.Binary{Subtype: bson.TypeBinaryUUID, Data: testUUID[:8]}}},
+		} {
+			data, err := bson.Marshal(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var out doc
+			if err := unmarshal(Codec{}, data, &out); err == nil {
+				t.Errorf("Decode(%v) succeeded, want error", v)
+			}
+		}
+	})
+}
+
+func TestCodecLegacy(t *testing.T) {
+	tests := []struct {
+		order LegacyByteOrder
+		data  string
+	}{
+		{LegacyStandard, "6ba7b8109dad11d180b400c04fd430c8"},
+		{LegacyJava, "d111ad9d10b8a76bc830d44fc000b480"},
+		{LegacyCSharp, "10b8a76bad9dd11180b400c04fd430c8"},
+	}
+	for _, tt := range tests {
+		b := uuid.Must(uuid.FromString(tt.data))
+		data, err := bson.Marshal(bson.D{{Key: "id", Value: bson.Binary{Subtype: bson.TypeBinaryUUIDOld, Data: b[:]}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out doc
+		if err := unmarshal(Codec{Legacy: tt.order}, data, &out); err != nil {
+			t.Fatalf("Decode() with legacy order %d unexpected error: %v", tt.order, err)
+		}
+		if out.ID != testUUID {
+			t.Errorf("Decode(%s) with legacy order %d = %v, want %v", tt.data, tt.order, out.ID, testUUID)
+		}
+	}
+}
+
+func TestUUID(t *testing.T) {
+	type wrapped struct {
+		ID UUID `bson:"id"`
+	}
+	data, err := bson.Marshal(wrapped{ID: UUID(testUUID)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	subtype, b, ok := bson.Raw(data).Lookup("id").BinaryOK()
+	if !ok || subtype != bson.TypeBinaryUUID || !bytes.Equal(b, testUUID[:]) {
+		t.Errorf("UUID encoded as %v, want binary subtype 4 of %x", bson.Raw(data).Lookup("id"), testUUID[:])
+	}
+
+	for _, v := range []any{
+		bson.D{{Key: "id", Value: bson.Binary{Subtype: bson.TypeBinaryUUID, Data: testUUID[:]}}},
+		bson.D{{Key: "id", Value: bson.Binary{Subtype: bson.TypeBinaryUUIDOld, Data: testUUID[:]}}},
+		bson.D{{Key: "id", Value: testUUID.String()}},
+	} {
+		data, err := bson.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out wrapped
+		if err := bson.Unmarshal(data, &out); err != nil {
+			t.Fatalf("Unmarshal(%v) unexpected error: %v", v, err)
+		}
+		if uuid.UUID(out.ID) != testUUID {
+			t.Errorf("Unmarshal(%v) = %v, want %v", v, uuid.UUID(out.ID), testUUID)
+		}
+	}
+
+	data, err = bson.Marshal(bson.D{{Key: "id", Value: int32(42)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out wrapped
+	if err := bson.Unmarshal(data, &out); err == nil {
+		t.Error("Unmarshal(int32) succeeded, want error")
+	}
+}
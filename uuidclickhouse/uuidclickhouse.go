@@ -0,0 +1,111 @@
+// Package uuidclickhouse converts the UUID types of github.com/gofrs/uuid/v5
+// to and from the byte order of ClickHouse UUID columns, and provides value
+// types for github.com/ClickHouse/clickhouse-go/v2.
+//
+// ClickHouse stores a UUID as two 64-bit little-endian integers, and sends
+// it in this order in the Native and RowBinary formats: the 16 bytes of
+// 6ba7b810-9dad-11d1-80b4-00c04fd430c8 are sent as
+//
+//	d1 11 ad 9d 10 b8 a7 6b c8 30 d4 4f c0 00 b4 80
+//
+// Copying these bytes into a uuid.UUID, or the bytes of a uuid.UUID into a
+// raw RowBinary body or a FixedString(16) column, produces mirrored UUIDs.
+// ToClickHouseBytes and FromClickHouseBytes convert between the two orders,
+// and Swap repairs UUIDs which were already mirrored.
+//
+// The UUID and NullUUID types hold UUIDs in rows appended to batches and in
+// scan destinations of clickhouse-go, with both its native interface and
+// database/sql. clickhouse-go handles the byte order of UUID columns, and
+// these types pass it the driver's UUID type rather than a string to parse:
+//
+//	batch.Append(uuidclickhouse.UUID(id), uuidclickhouse.NullUUID(parent))
+package uuidclickhouse
+
+import (
+	"database/sql/driver"
+
+	"github.com/gofrs/uuid/v5"
+	googleuuid "github.com/google/uuid"
+)
+
+// Swap returns u with the bytes of each 8-byte half reversed, converting a
+// UUID between the RFC 9562 byte order and the ClickHouse one. Swap is its
+// own inverse.
+func Swap(u uuid.UUID) uuid.UUID {
+	var s uuid.UUID
+	for i := 0; i < 8; i++ {
+		s[i] = u[7-i]
+		s[8+i] = u[15-i]
+	}
+	return s
+}
+
+// ToClickHouseBytes returns the 16 bytes of u in the ClickHouse byte order.
+func ToClickHouseBytes(u uuid.UUID) [uuid.Size]byte {
+	return Swap(u)
+}
+
+// FromClickHouseBytes returns the UUID of 16 bytes in the ClickHouse byte
+// order. It returns an error if b is not 16 bytes long.
+func FromClickHouseBytes(b []byte) (uuid.UUID, error) {
+	var u uuid.UUID
+	if err := u.UnmarshalBinary(b); err != nil {
+		return uuid.Nil, err
+	}
+	return Swap(u), nil
+}
+
+// scan stores in u the UUID held by src, a value of a UUID column returned
+// by clickhouse-go or any value accepted by uuid.UUID.Scan.
+func scan(u *uuid.UUID, src interface{}) error {
+	if g, ok := src.(googleuuid.UUID); ok {
+		*u = uuid.UUID(g)
+		return nil
+	}
+	return u.Scan(src)
+}
+
+// UUID is a uuid.UUID appended to and scanned from ClickHouse UUID columns.
+type UUID uuid.UUID
+
+// Value implements the driver.Valuer interface, returning the UUID type of
+// clickhouse-go.
+func (u UUID) Value() (driver.Value, error) {
+	return googleuuid.UUID(u), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (u *UUID) Scan(src interface{}) error {
+	return scan((*uuid.UUID)(u), src)
+}
+
+// String returns the canonical form of the UUID.
+func (u UUID) String() string {
+	return uuid.UUID(u).String()
+}
+
+// NullUUID is a uuid.NullUUID appended to and scanned from ClickHouse
+// Nullable(UUID) columns.
+type NullUUID uuid.NullUUID
+
+// Value implements the driver.Valuer interface, returning nil if the UUID is
+// invalid, and the UUID type of clickhouse-go otherwise.
+func (u NullUUID) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return googleuuid.UUID(u.UUID), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (u *NullUUID) Scan(src interface{}) error {
+	if src == nil {
+		u.UUID, u.Valid = uuid.Nil, false
+		return nil
+	}
+	if err := scan(&u.UUID, src); err != nil {
+		return err
+	}
+	u.Valid = true
+	return nil
+}
@@ -0,0 +1,129 @@
+package uuidclickhouse
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ClickHouse/ch-go/proto"
+	"github.com/ClickHouse/clickhouse-go/v2/lib/column"
+	"github.com/gofrs/uuid/v5"
+	googleuuid "github.com/google/uuid"
+)
+
+var testUUID = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+// testClickHouseBytes are the bytes of testUUID in the ClickHouse byte order.
+var testClickHouseBytes = []byte{0xd1, 0x11, 0xad, 0x9d, 0x10, 0xb8, 0xa7, 0x6b, 0xc8, 0x30, 0xd4, 0x4f, 0xc0, 0x00, 0xb4, 0x80}
+
+func TestClickHouseBytes(t *testing.T) {
+	if got := ToClickHouseBytes(testUUID); !bytes.Equal(got[:], testClickHouseBytes) {
+		t.Errorf("ToClickHouseBytes(%v) = %x, want %x", testUUID, got, testClickHouseBytes)
+	}
+	got, err := FromClickHouseBytes(testClickHouseBytes)
+	if err != nil || got != testUUID {
+		t.Errorf("FromClickHouseBytes(%x) = %v, %v, want %v", testClickHouseBytes, got, err, testUUID)
+	}
+	if _, err := FromClickHouseBytes(testClickHouseBytes[:8]); !errors.Is(err, uuid.ErrIncorrectByteLength) {
+		t.Errorf("FromClickHouseBytes(8 bytes) error = %v, want %v", err, uuid.ErrIncorrectByteLength)
+	}
+	for _, u := range []uuid.UUID{testUUID, uuid.Nil, uuid.Max, uuid.Must(uuid.NewV7())} {
+		if got := Swap(Swap(u)); got != u {
+			t.Errorf("Swap(Swap(%v)) = %v", u, got)
+		}
+	}
+}
+
+// encode returns the Native format encoding of col.
+func encode(col column.Interface) []byte {
+	var buf proto.Buffer
+	col.Encode(&buf)
+	return buf.Buf
+}
+
+func TestUUIDColumn(t *testing.T) {
+	col, err := column.Type("UUID").Column("id", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := col.AppendRow(UUID(testUUID)); err != nil {
+		t.Fatal(err)
+	}
+	if got := encode(col)[:uuid.Size]; !bytes.Equal(got, testClickHouseBytes) {
+		t.Errorf("encoded column = %x, want %x", got, testClickHouseBytes)
+	}
+
+	var got UUID
+	if err := col.ScanRow(&got, 0); err != nil {
+		t.Fatal(err)
+	}
+	if uuid.UUID(got) != testUUID {
+		t.Errorf("ScanRow = %v, want %v", got, testUUID)
+	}
+
+	// The database/sql interface of clickhouse-go scans the values of the
+	// column.
+	got = UUID{}
+	if err := got.Scan(col.Row(0, false)); err != nil || uuid.UUID(got) != testUUID {
+		t.Errorf("Scan(%v) = %v, %v, want %v", col.Row(0, false), got, err, testUUID)
+	}
+}
+
+func TestNullUUIDColumn(t *testing.T) {
+	col, err := column.Type("Nullable(UUID)").Column("parent", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := uuid.NullUUID{UUID: testUUID, Valid: true}
+	for _, v := range []NullUUID{NullUUID(valid), {}} {
+		if err := col.AppendRow(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got NullUUID
+	if err := col.ScanRow(&got, 0); err != nil || uuid.NullUUID(got) != valid {
+		t.Errorf("ScanRow(0) = %+v, %v, want %+v", got, err, valid)
+	}
+	if err := col.ScanRow(&got, 1); err != nil || got.Valid {
+		t.Errorf("ScanRow(1) = %+v, %v, want an invalid NullUUID", got, err)
+	}
+}
+
+func TestValue(t *testing.T) {
+	v, err := UUID(testUUID).Value()
+	if g, ok := v.(googleuuid.UUID); err != nil || !ok || g.String() != testUUID.String() {
+		t.Errorf("UUID.Value() = %v, %v, want %v", v, err, testUUID)
+	}
+	if v, err := (NullUUID{}).Value(); v != nil || err != nil {
+		t.Errorf("NullUUID{}.Value() = %v, %v, want nil", v, err)
+	}
+	if got := UUID(testUUID).String(); got != testUUID.String() {
+		t.Errorf("UUID.String() = %q, want %q", got, testUUID)
+	}
+}
+
+func TestScan(t *testing.T) {
+	for _, src := range []interface{}{
+		googleuuid.UUID(testUUID),
+		testUUID.String(),
+		testUUID.Bytes(),
+	} {
+		var got UUID
+		if err := got.Scan(src); err != nil || uuid.UUID(got) != testUUID {
+			t.Errorf("Scan(%v) = %v, %v, want %v", src, got, err, testUUID)
+		}
+	}
+	var u UUID
+	if err := u.Scan(42); !errors.Is(err, uuid.ErrTypeConvertError) {
+		t.Errorf("Scan(42) error = %v, want %v", err, uuid.ErrTypeConvertError)
+	}
+
+	nu := NullUUID{UUID: testUUID, Valid: true}
+	if err := nu.Scan(nil); err != nil || nu.Valid || nu.UUID != uuid.Nil {
+		t.Errorf("Scan(nil) = %+v, %v, want an invalid NullUUID", nu, err)
+	}
+	if err := nu.Scan("not-a-uuid"); err == nil || nu.Valid {
+		t.Errorf("Scan(not-a-uuid) = %+v, %v, want an error", nu, err)
+	}
+}
@@ -0,0 +1,136 @@
+// Package uuidcql binds the UUID types of github.com/gofrs/uuid/v5 to
+// Cassandra uuid and timeuuid columns with github.com/gocql/gocql.
+//
+// Gocql only marshals its own gocql.UUID type and plain [16]byte arrays, so
+// uuid.UUID values must be converted to the UUID and NullUUID types of this
+// package, which implement gocql.Marshaler and gocql.Unmarshaler:
+//
+//	err := session.Query(`INSERT INTO events (id, parent) VALUES (?, ?)`,
+//		uuidcql.UUID(id), uuidcql.NullUUID(parent)).Exec()
+//
+//	var id uuid.UUID
+//	err := session.Query(`SELECT id FROM events LIMIT 1`).Scan((*uuidcql.UUID)(&id))
+//
+// Cassandra timeuuid columns only accept version 1 UUIDs. ToTimeUUID converts
+// version 6 UUIDs to version 1, and MinTimeUUID and MaxTimeUUID return the
+// bounds used to select a time range of a timeuuid column, like the CQL
+// functions minTimeuuid and maxTimeuuid.
+package uuidcql
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/gofrs/uuid/v5"
+)
+
+// UUID is a uuid.UUID implementing gocql.Marshaler and gocql.Unmarshaler.
+// It is stored in uuid and timeuuid columns as 16 bytes, in text columns in
+// the canonical form, and in blob columns as 16 bytes.
+type UUID uuid.UUID
+
+// MarshalCQL implements the gocql.Marshaler interface. Marshaling a UUID of
+// another version than 1 into a timeuuid column returns an error.
+func (u UUID) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+	switch t := info.Type(); t {
+	case gocql.TypeTimeUUID:
+		if v := uuid.UUID(u).Version(); v != uuid.V1 {
+			return nil, fmt.Errorf("%w %s is version %d, not a timeuuid", uuid.ErrInvalidVersion, uuid.UUID(u), v)
+		}
+		return u[:], nil
+	case gocql.TypeUUID, gocql.TypeBlob:
+		return u[:], nil
+	case gocql.TypeVarchar, gocql.TypeText, gocql.TypeAscii:
+		return uuid.UUID(u).MarshalText()
+	default:
+		return nil, fmt.Errorf("uuidcql: cannot marshal a UUID into CQL type %v", t)
+	}
+}
+
+// UnmarshalCQL implements the gocql.Unmarshaler interface. A null or empty
+// value is unmarshaled as uuid.Nil.
+func (u *UUID) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	if len(data) == 0 {
+		*u = UUID(uuid.Nil)
+		return nil
+	}
+	switch t := info.Type(); t {
+	case gocql.TypeUUID, gocql.TypeTimeUUID, gocql.TypeBlob:
+		return (*uuid.UUID)(u).UnmarshalBinary(data)
+	case gocql.TypeVarchar, gocql.TypeText, gocql.TypeAscii:
+		return (*uuid.UUID)(u).UnmarshalText(data)
+	default:
+		return fmt.Errorf("uuidcql: cannot unmarshal CQL type %v into a UUID", t)
+	}
+}
+
+// NullUUID is a uuid.NullUUID implementing gocql.Marshaler and
+// gocql.Unmarshaler. An invalid NullUUID is stored as null, and null or
+// empty values are unmarshaled as an invalid NullUUID.
+type NullUUID uuid.NullUUID
+
+// MarshalCQL implements the gocql.Marshaler interface.
+func (nu NullUUID) MarshalCQL(info gocql.TypeInfo) ([]byte, error) {
+	if !nu.Valid {
+		return nil, nil
+	}
+	return UUID(nu.UUID).MarshalCQL(info)
+}
+
+// UnmarshalCQL implements the gocql.Unmarshaler interface.
+func (nu *NullUUID) UnmarshalCQL(info gocql.TypeInfo, data []byte) error {
+	if len(data) == 0 {
+		*nu = NullUUID{}
+		return nil
+	}
+	if err := (*UUID)(&nu.UUID).UnmarshalCQL(info, data); err != nil {
+		return err
+	}
+	nu.Valid = true
+	return nil
+}
+
+// FromGocql returns u as a uuid.UUID.
+func FromGocql(u gocql.UUID) uuid.UUID {
+	return uuid.UUID(u)
+}
+
+// ToGocql returns u as a gocql.UUID.
+func ToGocql(u uuid.UUID) gocql.UUID {
+	return gocql.UUID(u)
+}
+
+// ToTimeUUID returns u as a version 1 UUID, which can be stored in a
+// timeuuid column. Version 1 UUIDs are returned unchanged, and version 6
+// UUIDs are converted with uuid.V6ToV1; other versions return an error
+// wrapping uuid.ErrInvalidVersion.
+func ToTimeUUID(u uuid.UUID) (uuid.UUID, error) {
+	switch u.Version() {
+	case uuid.V1:
+		return u, nil
+	case uuid.V6:
+		return uuid.V6ToV1(u)
+	default:
+		return uuid.Nil, fmt.Errorf("%w %s is version %d, not version 1 or 6", uuid.ErrInvalidVersion, u, u.Version())
+	}
+}
+
+// MinTimeUUID returns the smallest version 1 UUID with the timestamp of t,
+// in the order of Cassandra timeuuid columns, which compares timestamps
+// first. It is not unique, and is only meant as the lower bound of a range
+// query:
+//
+//	SELECT * FROM events WHERE id >= ? AND id <= ?
+//
+// with uuidcql.UUID(MinTimeUUID(from)) and uuidcql.UUID(MaxTimeUUID(to)).
+func MinTimeUUID(t time.Time) uuid.UUID {
+	return uuid.UUID(gocql.MinTimeUUID(t))
+}
+
+// MaxTimeUUID returns the largest version 1 UUID with the timestamp of t, in
+// the order of Cassandra timeuuid columns. It is not unique, and is only
+// meant as the upper bound of a range query.
+func MaxTimeUUID(t time.Time) uuid.UUID {
+	return uuid.UUID(gocql.MaxTimeUUID(t))
+}
@@ -0,0 +1,159 @@
+package uuidcql
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/gofrs/uuid/v5"
+)
+
+var testUUID = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+func nativeType(t gocql.Type) gocql.TypeInfo {
+	return gocql.NewNativeType(4, t, "")
+}
+
+func TestUUID(t *testing.T) {
+	tests := []struct {
+		typ  gocql.Type
+		want []byte
+	}{
+		{gocql.TypeUUID, testUUID[:]},
+		{gocql.TypeTimeUUID, testUUID[:]},
+		{gocql.TypeBlob, testUUID[:]},
+		{gocql.TypeText, []byte(testUUID.String())},
+		{gocql.TypeVarchar, []byte(testUUID.String())},
+	}
+	for _, tt := range tests {
+		info := nativeType(tt.typ)
+		data, err := gocql.Marshal(info, UUID(testUUID))
+		if err != nil {
+			t.Fatalf("Marshal(%v, %v) unexpected error: %v", info, testUUID, err)
+		}
+		if !bytes.Equal(data, tt.want) {
+			t.Errorf("Marshal(%v, %v) = %x, want %x", info, testUUID, data, tt.want)
+		}
+		var got uuid.UUID
+		if err := gocql.Unmarshal(info, data, (*UUID)(&got)); err != nil {
+			t.Fatalf("Unmarshal(%v, %x) unexpected error: %v", info, data, err)
+		}
+		if got != testUUID {
+			t.Errorf("Unmarshal(%v, %x) = %v, want %v", info, data, got, testUUID)
+		}
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		v4 := uuid.Must(uuid.NewV4())
+		if _, err := gocql.Marshal(nativeType(gocql.TypeTimeUUID), UUID(v4)); !errors.Is(err, uuid.ErrInvalidVersion) {
+			t.Errorf("Marshal(timeuuid, %v) error = %v, want %v", v4, err, uuid.ErrInvalidVersion)
+		}
+		if _, err := gocql.Marshal(nativeType(gocql.TypeInt), UUID(testUUID)); err == nil {
+			t.Error("Marshal(int) succeeded, want error")
+		}
+		for _, tt := range []struct {
+			typ  gocql.Type
+			data []byte
+		}{
+			{gocql.TypeUUID, testUUID[:8]},
+			{gocql.TypeText, []byte("not-a-uuid")},
+			{gocql.TypeInt, []byte{0, 0, 0, 42}},
+		} {
+			var got UUID
+			if err := gocql.Unmarshal(nativeType(tt.typ), tt.data, &got); err == nil {
+				t.Errorf("Unmarshal(%v, %x) succeeded, want error", tt.typ, tt.data)
+			}
+		}
+	})
+
+	t.Run("Null", func(t *testing.T) {
+		got := testUUID
+		if err := gocql.Unmarshal(nativeType(gocql.TypeUUID), nil, (*UUID)(&got)); err != nil {
+			t.Fatal(err)
+		}
+		if got != uuid.Nil {
+			t.Errorf("Unmarshal(null) = %v, want %v", got, uuid.Nil)
+		}
+	})
+}
+
+func TestNullUUID(t *testing.T) {
+	info := nativeType(gocql.TypeUUID)
+	data, err := gocql.Marshal(info, NullUUID{})
+	if err != nil || data != nil {
+		t.Errorf("Marshal(invalid NullUUID) = %x, %v, want null", data, err)
+	}
+	valid := uuid.NullUUID{UUID: testUUID, Valid: true}
+	data, err = gocql.Marshal(info, NullUUID(valid))
+	if err != nil || !bytes.Equal(data, testUUID[:]) {
+		t.Errorf("Marshal(%+v) = %x, %v, want %x", valid, data, err, testUUID[:])
+	}
+
+	var got uuid.NullUUID
+	if err := gocql.Unmarshal(info, data, (*NullUUID)(&got)); err != nil || got != valid {
+		t.Errorf("Unmarshal(%x) = %+v, %v, want %+v", data, got, err, valid)
+	}
+	if err := gocql.Unmarshal(info, nil, (*NullUUID)(&got)); err != nil || got.Valid {
+		t.Errorf("Unmarshal(null) = %+v, %v, want an invalid NullUUID", got, err)
+	}
+	if err := gocql.Unmarshal(info, testUUID[:8], (*NullUUID)(&got)); err == nil {
+		t.Error("Unmarshal(8 bytes) succeeded, want error")
+	}
+}
+
+func TestGocqlConversion(t *testing.T) {
+	g := ToGocql(testUUID)
+	if g.String() != testUUID.String() {
+		t.Errorf("ToGocql(%v) = %v", testUUID, g)
+	}
+	if got := FromGocql(g); got != testUUID {
+		t.Errorf("FromGocql(%v) = %v, want %v", g, got, testUUID)
+	}
+}
+
+func TestToTimeUUID(t *testing.T) {
+	v1 := uuid.Must(uuid.FromString("c232ab00-9414-11ec-b3c8-9f6bdeced846"))
+	v6 := uuid.Must(uuid.FromString("1ec9414c-232a-6b00-b3c8-9f6bdeced846"))
+	for _, u := range []uuid.UUID{v1, v6} {
+		got, err := ToTimeUUID(u)
+		if err != nil {
+			t.Fatalf("ToTimeUUID(%v) unexpected error: %v", u, err)
+		}
+		if got != v1 {
+			t.Errorf("ToTimeUUID(%v) = %v, want %v", u, got, v1)
+		}
+	}
+	for _, u := range []uuid.UUID{uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV7()), uuid.Nil} {
+		if _, err := ToTimeUUID(u); !errors.Is(err, uuid.ErrInvalidVersion) {
+			t.Errorf("ToTimeUUID(%v) error = %v, want %v", u, err, uuid.ErrInvalidVersion)
+		}
+	}
+}
+
+func TestMinMaxTimeUUID(t *testing.T) {
+	at := time.Date(2022, 2, 22, 19, 22, 22, 123456700, time.UTC)
+	lo, hi := MinTimeUUID(at), MaxTimeUUID(at)
+	for _, u := range []uuid.UUID{lo, hi} {
+		if u.Version() != uuid.V1 || u.Variant() != uuid.VariantRFC9562 {
+			t.Errorf("%v has version %d and variant %d, want version 1 of the RFC 9562 variant", u, u.Version(), u.Variant())
+		}
+		ts, err := uuid.TimestampFromV1(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := ts.Time(); !got.Equal(at) {
+			t.Errorf("%v has time %v, want %v", u, got, at)
+		}
+	}
+
+	// Cassandra compares the clock sequence and node bytes of timeuuids with
+	// equal timestamps as signed bytes.
+	if want := []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80}; !bytes.Equal(lo[8:], want) {
+		t.Errorf("MinTimeUUID(%v) clock sequence and node = %x, want %x", at, lo[8:], want)
+	}
+	if want := []byte{0xbf, 0x7f, 0x7f, 0x7f, 0x7f, 0x7f, 0x7f, 0x7f}; !bytes.Equal(hi[8:], want) {
+		t.Errorf("MaxTimeUUID(%v) clock sequence and node = %x, want %x", at, hi[8:], want)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !unix
+
+package uuidflock
+
+import "os"
+
+const supported = false
+
+func lock(f *os.File) error {
+	return ErrUnsupported
+}
+
+func unlock(f *os.File) error {
+	return ErrUnsupported
+}
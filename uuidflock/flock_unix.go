@@ -0,0 +1,23 @@
+//go:build unix
+
+package uuidflock
+
+import (
+	"os"
+	"syscall"
+)
+
+const supported = true
+
+func lock(f *os.File) error {
+	for {
+		err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
+
+func unlock(f *os.File) error {
+	return syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+}
@@ -0,0 +1,183 @@
+//go:build unix
+
+package uuidflock
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestReserve(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state")
+	c1, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c1.Close()
+	c2, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c2.Close()
+
+	for _, tt := range []struct {
+		c         *Coordinator
+		ms, n     uint64
+		wantFirst uint64
+	}{
+		{c1, 100, 1, 100 << 12},
+		{c2, 100, 3, 100<<12 + 1},
+		{c1, 99, 1, 100<<12 + 4},
+		{c2, 101, 2, 101 << 12},
+		{c1, 101, 1, 101<<12 + 2},
+	} {
+		first, err := tt.c.Reserve(tt.ms, tt.n)
+		if err != nil || first != tt.wantFirst {
+			t.Errorf("Reserve(%d, %d) = %#x, %v, want %#x", tt.ms, tt.n, first, err, tt.wantFirst)
+		}
+	}
+
+	c1.Close()
+	c3, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c3.Close()
+	if first, err := c3.Reserve(0, 1); err != nil || first != 101<<12+3 {
+		t.Errorf("Reserve(0, 1) after reopening = %#x, %v, want %#x", first, err, 101<<12+3)
+	}
+	if _, err := c1.Reserve(0, 1); err == nil {
+		t.Error("Reserve() after Close() returned no error")
+	}
+}
+
+func TestOpen(t *testing.T) {
+	if _, err := Open(filepath.Join(t.TempDir(), "missing", "state")); err == nil {
+		t.Error("Open() in a missing directory returned no error")
+	}
+}
+
+func TestGenerators(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state")
+	const generators, calls = 4, 200
+	results := make([][]uuid.UUID, generators)
+	var wg sync.WaitGroup
+	for i := range results {
+		c, err := Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer c.Close()
+		g := uuid.NewMonotonicGen(uuid.WithCoordinator(c))
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < calls; j++ {
+				u, err := g.NewV7()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				results[i] = append(results[i], u)
+			}
+		}(i)
+	}
+	wg.Wait()
+	checkUnique(t, results)
+}
+
+// TestProcesses runs itself in child processes generating UUIDs with a
+// Coordinator on the same file.
+func TestProcesses(t *testing.T) {
+	if path := os.Getenv("UUIDFLOCK_TEST_PATH"); path != "" {
+		generate(t, path)
+		return
+	}
+
+	path := filepath.Join(t.TempDir(), "state")
+	const processes = 3
+	outputs := make([]bytes.Buffer, processes)
+	cmds := make([]*exec.Cmd, processes)
+	for i := range cmds {
+		cmds[i] = exec.Command(os.Args[0], "-test.run=^TestProcesses$")
+		cmds[i].Env = append(os.Environ(), "UUIDFLOCK_TEST_PATH="+path)
+		cmds[i].Stdout = &outputs[i]
+		cmds[i].Stderr = os.Stderr
+		if err := cmds[i].Start(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	results := make([][]uuid.UUID, processes)
+	for i, cmd := range cmds {
+		if err := cmd.Wait(); err != nil {
+			t.Fatalf("process %d: %v", i, err)
+		}
+		s := bufio.NewScanner(&outputs[i])
+		for s.Scan() {
+			u, err := uuid.FromString(s.Text())
+			if err != nil {
+				continue // the test framework output
+			}
+			results[i] = append(results[i], u)
+		}
+		if len(results[i]) != 500 {
+			t.Fatalf("process %d generated %d UUIDs, want 500", i, len(results[i]))
+		}
+	}
+	checkUnique(t, results)
+}
+
+// generate prints 500 UUIDs, generated in batches of 10 with a Coordinator
+// on the file at path.
+func generate(t *testing.T, path string) {
+	c, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	g := uuid.NewMonotonicGen(uuid.WithCoordinator(c))
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	for i := 0; i < 50; i++ {
+		batch, err := g.GenerateBatchV7(10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, u := range batch {
+			w.WriteString(u.String() + "\n")
+		}
+		if i%10 == 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+// checkUnique checks that the UUIDs of each generator are sorted, and that
+// the timestamps and counters of the UUIDs of all the generators are unique.
+func checkUnique(t *testing.T, results [][]uuid.UUID) {
+	t.Helper()
+	seen := make(map[[8]byte]string)
+	for i, us := range results {
+		if !uuid.IsSorted(us) {
+			t.Errorf("UUIDs of generator %d are not sorted", i)
+		}
+		for j, u := range us {
+			var state [8]byte
+			copy(state[:], u[:8])
+			at := strconv.Itoa(i) + "/" + strconv.Itoa(j)
+			if prev, ok := seen[state]; ok {
+				t.Fatalf("timestamp and counter of UUID %s generated as %s and %s", u, prev, at)
+			}
+			seen[state] = at
+		}
+	}
+}
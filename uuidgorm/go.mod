@@ -0,0 +1,16 @@
+module github.com/gofrs/uuid/v5/uuidgorm
+
+go 1.19
+
+require (
+	github.com/gofrs/uuid/v5 v5.0.0
+	gorm.io/gorm v1.31.2
+)
+
+require (
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	golang.org/x/text v0.20.0 // indirect
+)
+
+replace github.com/gofrs/uuid/v5 => ../
@@ -0,0 +1,176 @@
+// Package uuidgorm integrates the UUID type of github.com/gofrs/uuid/v5 with
+// GORM.
+//
+// The UUID type stores UUIDs in the most suitable column type for each
+// dialect: uuid on PostgreSQL, BINARY(16) on MySQL, and CHAR(36) elsewhere.
+// The Plugin fills in zero-valued UUID primary keys when records are
+// created:
+//
+//	type Order struct {
+//		ID    uuidgorm.UUID `gorm:"primaryKey"`
+//		Total int
+//	}
+//
+//	db.Use(uuidgorm.Plugin{Version: uuid.V7})
+package uuidgorm
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+
+	"github.com/gofrs/uuid/v5"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// UUID is a uuid.UUID with GORM data type support. It is stored as 16 bytes
+// on dialects whose UUID column type is binary, and in its canonical string
+// form otherwise. Use *UUID for nullable columns.
+type UUID uuid.UUID
+
+// Nil is the zero UUID.
+var Nil UUID
+
+// String returns the canonical string form of the UUID.
+func (u UUID) String() string {
+	return uuid.UUID(u).String()
+}
+
+// IsNil returns true if the UUID is equal to the nil UUID.
+func (u UUID) IsNil() bool {
+	return u == Nil
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (u UUID) MarshalText() ([]byte, error) {
+	return uuid.UUID(u).MarshalText()
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (u *UUID) UnmarshalText(text []byte) error {
+	return (*uuid.UUID)(u).UnmarshalText(text)
+}
+
+// Value implements the driver.Valuer interface, returning the canonical
+// string form of the UUID. GORM uses GormValue instead.
+func (u UUID) Value() (driver.Value, error) {
+	return uuid.UUID(u).Value()
+}
+
+// Scan implements the sql.Scanner interface. A 16-byte slice is handled as
+// the binary form of the UUID, and other slices and strings as its text
+// form.
+func (u *UUID) Scan(src interface{}) error {
+	return (*uuid.UUID)(u).Scan(src)
+}
+
+// GormDataType implements the schema.GormDataTypeInterface interface.
+func (UUID) GormDataType() string {
+	return "uuid"
+}
+
+// GormDBDataType returns the column type of the UUID in db's dialect.
+func (UUID) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return "uuid"
+	case "mysql":
+		return "BINARY(16)"
+	}
+	return "CHAR(36)"
+}
+
+// GormValue implements the gorm.Valuer interface, encoding the UUID for db's
+// dialect.
+func (u UUID) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if db.Dialector.Name() == "mysql" {
+		return clause.Expr{SQL: "?", Vars: []interface{}{u[:]}}
+	}
+	return clause.Expr{SQL: "?", Vars: []interface{}{u.String()}}
+}
+
+// Plugin is a gorm.Plugin that generates UUIDs for zero-valued primary keys
+// of type UUID or uuid.UUID when records are created.
+type Plugin struct {
+	// Version is the version of the generated UUIDs: uuid.V4 or uuid.V7. The
+	// zero value means uuid.V7.
+	Version byte
+
+	// Generator generates the UUIDs. If nil, uuid.DefaultGenerator is used.
+	Generator uuid.Generator
+}
+
+// Name implements the gorm.Plugin interface.
+func (Plugin) Name() string {
+	return "uuidgorm"
+}
+
+// Initialize implements the gorm.Plugin interface.
+func (p Plugin) Initialize(db *gorm.DB) error {
+	gen := p.Generator
+	if gen == nil {
+		gen = uuid.DefaultGenerator
+	}
+	var newUUID func() (uuid.UUID, error)
+	switch p.Version {
+	case 0, uuid.V7:
+		newUUID = gen.NewV7
+	case uuid.V4:
+		newUUID = gen.NewV4
+	default:
+		return fmt.Errorf("uuidgorm: unsupported UUID version %d", p.Version)
+	}
+	return db.Callback().Create().Before("gorm:create").Register("uuidgorm:primary_key", func(db *gorm.DB) {
+		if db.Statement.Schema == nil {
+			return
+		}
+		if err := setPrimaryKeys(db.Statement, newUUID); err != nil {
+			db.AddError(err)
+		}
+	})
+}
+
+var (
+	uuidType     = reflect.TypeOf(uuid.UUID{})
+	gormUUIDType = reflect.TypeOf(UUID{})
+)
+
+// setPrimaryKeys sets the zero-valued UUID primary keys of the records in
+// stmt to new UUIDs.
+func setPrimaryKeys(stmt *gorm.Statement, newUUID func() (uuid.UUID, error)) error {
+	for _, field := range stmt.Schema.PrimaryFields {
+		if field.FieldType != uuidType && field.FieldType != gormUUIDType {
+			continue
+		}
+		set := func(rv reflect.Value) error {
+			if _, zero := field.ValueOf(stmt.Context, rv); !zero {
+				return nil
+			}
+			u, err := newUUID()
+			if err != nil {
+				return err
+			}
+			if field.FieldType == gormUUIDType {
+				return field.Set(stmt.Context, rv, UUID(u))
+			}
+			return field.Set(stmt.Context, rv, u)
+		}
+		rv := reflect.Indirect(stmt.ReflectValue)
+		switch rv.Kind() {
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < rv.Len(); i++ {
+				if err := set(reflect.Indirect(rv.Index(i))); err != nil {
+					return err
+				}
+			}
+		case reflect.Struct:
+			if err := set(rv); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package uuidgorm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"gorm.io/gorm"
+	"gorm.io/gorm/utils/tests"
+)
+
+var testUUID = UUID(uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8")))
+
+// dialector is a dry-run dialector reporting the given name.
+type dialector struct {
+	tests.DummyDialector
+	name string
+}
+
+func (d dialector) Name() string { return d.name }
+
+func open(t *testing.T, name string) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(dialector{name: name}, &gorm.Config{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+type order struct {
+	ID    UUID `gorm:"primaryKey"`
+	Total int
+}
+
+type plainOrder struct {
+	ID    uuid.UUID `gorm:"primaryKey"`
+	Total int
+}
+
+func TestGormDBDataType(t *testing.T) {
+	tests := []struct {
+		dialect string
+		want    string
+	}{
+		{"postgres", "uuid"},
+		{"mysql", "BINARY(16)"},
+		{"sqlite", "CHAR(36)"},
+		{"sqlserver", "CHAR(36)"},
+	}
+	for _, tt := range tests {
+		if got := (UUID{}).GormDBDataType(open(t, tt.dialect), nil); got != tt.want {
+			t.Errorf("GormDBDataType(%s) = %q, want %q", tt.dialect, got, tt.want)
+		}
+	}
+}
+
+func TestGormValue(t *testing.T) {
+	stmt := open(t, "postgres").Create(&order{ID: testUUID}).Statement
+	if len(stmt.Vars) == 0 || stmt.Vars[0] != testUUID.String() {
+		t.Errorf("postgres Create vars = %v, want %q first", stmt.Vars, testUUID.String())
+	}
+	stmt = open(t, "mysql").Create(&order{ID: testUUID}).Statement
+	if len(stmt.Vars) == 0 {
+		t.Fatal("mysql Create has no vars")
+	}
+	if b, ok := stmt.Vars[0].([]byte); !ok || !bytes.Equal(b, testUUID[:]) {
+		t.Errorf("mysql Create vars = %v, want %x first", stmt.Vars, testUUID[:])
+	}
+}
+
+func TestScan(t *testing.T) {
+	for _, src := range []interface{}{testUUID[:], testUUID.String(), []byte(testUUID.String())} {
+		var got UUID
+		if err := got.Scan(src); err != nil {
+			t.Fatalf("Scan(%v) unexpected error: %v", src, err)
+		}
+		if got != testUUID {
+			t.Errorf("Scan(%v) = %v, want %v", src, got, testUUID)
+		}
+	}
+}
+
+func TestPlugin(t *testing.T) {
+	for _, version := range []byte{0, uuid.V4, uuid.V7} {
+		db := open(t, "sqlite")
+		if err := db.Use(Plugin{Version: version}); err != nil {
+			t.Fatal(err)
+		}
+		want := version
+		if want == 0 {
+			want = uuid.V7
+		}
+
+		o := order{Total: 1}
+		if err := db.Create(&o).Error; err != nil {
+			t.Fatal(err)
+		}
+		if o.ID.IsNil() || uuid.UUID(o.ID).Version() != want {
+			t.Errorf("Plugin{Version: %d} set ID %v, want a version %d UUID", version, o.ID, want)
+		}
+
+		p := plainOrder{Total: 1}
+		if err := db.Create(&p).Error; err != nil {
+			t.Fatal(err)
+		}
+		if p.ID.IsNil() || p.ID.Version() != want {
+			t.Errorf("Plugin{Version: %d} set ID %v, want a version %d UUID", version, p.ID, want)
+		}
+
+		batch := []*order{{ID: testUUID}, {}, {}}
+		if err := db.Create(&batch).Error; err != nil {
+			t.Fatal(err)
+		}
+		if batch[0].ID != testUUID {
+			t.Errorf("Plugin changed the existing ID %v to %v", testUUID, batch[0].ID)
+		}
+		if batch[1].ID.IsNil() || batch[2].ID.IsNil() || batch[1].ID == batch[2].ID {
+			t.Errorf("Plugin set IDs %v and %v, want distinct UUIDs", batch[1].ID, batch[2].ID)
+		}
+	}
+
+	if err := open(t, "sqlite").Use(Plugin{Version: uuid.V5}); err == nil {
+		t.Error("Use(Plugin{Version: 5}) succeeded, want error")
+	}
+}
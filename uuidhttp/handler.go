@@ -0,0 +1,193 @@
+package uuidhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// DefaultMaxCount is the default maximum number of UUIDs generated by a
+// single request to a Handler.
+const DefaultMaxCount = 1000
+
+// Handler is an http.Handler generating UUIDs, for clients which cannot link
+// this package. The version is the last element of the request path, so
+// that the handler can be mounted under any prefix:
+//
+//	GET /v1, /v4, /v6, /v7
+//	GET /v3?ns=...&name=..., /v5?ns=...&name=...
+//
+// The namespace of versions 3 and 5 is either a UUID or one of dns, url, oid
+// and x500. The query may also have the following parameters:
+//
+//	n       the number of UUIDs to generate, 1 by default; versions 3
+//	        and 5 are deterministic and only accept 1
+//	format  canonical (the default), hash, braced or urn
+//
+// The UUIDs are returned one per line as text/plain, or, if the Accept
+// header of the request includes application/json, as a JSON object:
+//
+//	{"uuids":["0189a6ae-c23e-7b5e-9b0a-e4a6e5e1f7a3"]}
+//
+// Errors are returned as text/plain with the status 400 for invalid
+// requests, 404 for unknown versions and 405 for methods other than GET and
+// HEAD.
+type Handler struct {
+	// Generator generates the UUIDs. If nil, the UUIDs of each version are
+	// generated by uuid.VersionGenerator at the time of the request.
+	Generator uuid.Generator
+
+	// MaxCount is the maximum number of UUIDs generated by a single
+	// request. If zero, DefaultMaxCount is used.
+	MaxCount int
+}
+
+// namespaces are the names of the predefined namespaces accepted by the ns
+// parameter.
+var namespaces = map[string]uuid.UUID{
+	"dns":  uuid.NamespaceDNS,
+	"url":  uuid.NamespaceURL,
+	"oid":  uuid.NamespaceOID,
+	"x500": uuid.NamespaceX500,
+}
+
+// formats are the names of the formats accepted by the format parameter.
+var formats = map[string]uuid.Format{
+	"canonical": uuid.FormatCanonical,
+	"hash":      uuid.FormatHash,
+	"braced":    uuid.FormatBraced,
+	"urn":       uuid.FormatURN,
+}
+
+// generator returns the generator of the UUIDs of version v.
+func (h *Handler) generator(v byte) uuid.Generator {
+	if h.Generator != nil {
+		return h.Generator
+	}
+	return uuid.VersionGenerator(v)
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	maxCount := h.MaxCount
+	if maxCount <= 0 {
+		maxCount = DefaultMaxCount
+	}
+
+	q := r.URL.Query()
+	n := 1
+	if s := q.Get("n"); s != "" {
+		var err error
+		if n, err = strconv.Atoi(s); err != nil || n < 1 || n > maxCount {
+			http.Error(w, fmt.Sprintf("n must be an integer between 1 and %d", maxCount), http.StatusBadRequest)
+			return
+		}
+	}
+	format := uuid.FormatCanonical
+	if s := q.Get("format"); s != "" {
+		f, ok := formats[s]
+		if !ok {
+			http.Error(w, fmt.Sprintf("unknown format %q", s), http.StatusBadRequest)
+			return
+		}
+		format = f
+	}
+
+	var next func() (uuid.UUID, error)
+	switch version := path.Base(r.URL.Path); version {
+	case "v1":
+		next = h.generator(uuid.V1).NewV1
+	case "v4":
+		next = h.generator(uuid.V4).NewV4
+	case "v6":
+		next = h.generator(uuid.V6).NewV6
+	case "v7":
+		next = h.generator(uuid.V7).NewV7
+	case "v3", "v5":
+		ns, err := parseNamespace(q.Get("ns"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !q.Has("name") {
+			http.Error(w, "missing name", http.StatusBadRequest)
+			return
+		}
+		if n != 1 {
+			http.Error(w, fmt.Sprintf("%s UUIDs are deterministic, n must be 1", version), http.StatusBadRequest)
+			return
+		}
+		newName := h.generator(uuid.V3).NewV3
+		if version == "v5" {
+			newName = h.generator(uuid.V5).NewV5
+		}
+		name := q.Get("name")
+		next = func() (uuid.UUID, error) {
+			return newName(ns, name), nil
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	ids := make([]string, n)
+	buf := make([]byte, 0, 45)
+	for i := range ids {
+		u, err := next()
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		ids[i] = string(u.AppendFormat(buf, format))
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	if acceptsJSON(r) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			UUIDs []string `json:"uuids"`
+		}{ids})
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte(strings.Join(ids, "\n") + "\n"))
+}
+
+// parseNamespace returns the namespace named or formatted by s.
+func parseNamespace(s string) (uuid.UUID, error) {
+	if s == "" {
+		return uuid.Nil, errors.New("missing ns")
+	}
+	if ns, ok := namespaces[strings.ToLower(s)]; ok {
+		return ns, nil
+	}
+	ns, err := uuid.FromString(s)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid ns: %w", err)
+	}
+	return ns, nil
+}
+
+// acceptsJSON reports whether the Accept header of r includes
+// application/json.
+func acceptsJSON(r *http.Request) bool {
+	for _, v := range r.Header.Values("Accept") {
+		for _, t := range strings.Split(v, ",") {
+			if mt, _, _ := strings.Cut(strings.TrimSpace(t), ";"); strings.EqualFold(strings.TrimSpace(mt), "application/json") {
+				return true
+			}
+		}
+	}
+	return false
+}
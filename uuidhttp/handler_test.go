@@ -0,0 +1,195 @@
+package uuidhttp
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func get(h http.Handler, target, accept string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func lines(rec *httptest.ResponseRecorder) []string {
+	return strings.Split(strings.TrimSuffix(rec.Body.String(), "\n"), "\n")
+}
+
+func TestHandlerVersions(t *testing.T) {
+	h := &Handler{}
+	for _, tt := range []struct {
+		path    string
+		version byte
+	}{
+		{"/v1", uuid.V1},
+		{"/v4", uuid.V4},
+		{"/v6", uuid.V6},
+		{"/v7", uuid.V7},
+		{"/uuid/v7", uuid.V7},
+	} {
+		rec := get(h, tt.path, "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s status = %d, want %d: %s", tt.path, rec.Code, http.StatusOK, rec.Body)
+		}
+		if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+			t.Errorf("GET %s Content-Type = %q, want text/plain", tt.path, ct)
+		}
+		got := lines(rec)
+		if len(got) != 1 {
+			t.Fatalf("GET %s returned %q, want 1 UUID", tt.path, got)
+		}
+		u, err := uuid.FromString(got[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.Version() != tt.version {
+			t.Errorf("GET %s = %v, want version %d", tt.path, u, tt.version)
+		}
+	}
+}
+
+func TestHandlerVersionGenerator(t *testing.T) {
+	at := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	gen := uuid.NewGenWithOptions(uuid.WithEpochFunc(func() time.Time { return at }))
+	if err := uuid.SetVersionGenerator(uuid.V7, gen); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { uuid.SetVersionGenerator(uuid.V7, nil) })
+
+	for path, want := range map[string]bool{"/v7": true, "/v6": false} {
+		rec := get(&Handler{}, path, "")
+		u, err := uuid.FromString(strings.TrimSpace(rec.Body.String()))
+		if err != nil {
+			t.Fatalf("GET %s returned %q: %v", path, rec.Body, err)
+		}
+		if tm, _ := u.Time(); tm.Equal(at) != want {
+			t.Errorf("GET %s = %v at %v, want the time of uuid.VersionGenerator(uuid.V7): %t", path, u, tm, want)
+		}
+	}
+}
+
+func TestHandlerName(t *testing.T) {
+	if uuid.FIPS {
+		t.Skip("NewV3 and NewV5 are disabled by the uuid_fips build tag")
+	}
+	h := &Handler{}
+	for _, tt := range []struct {
+		target string
+		want   uuid.UUID
+	}{
+		{"/v5?ns=dns&name=www.example.com", uuid.NewV5(uuid.NamespaceDNS, "www.example.com")},
+		{"/v5?ns=URL&name=", uuid.NewV5(uuid.NamespaceURL, "")},
+		{"/v3?ns=" + testUUID.String() + "&name=a%20b", uuid.NewV3(testUUID, "a b")},
+	} {
+		rec := get(h, tt.target, "")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s status = %d, want %d: %s", tt.target, rec.Code, http.StatusOK, rec.Body)
+		}
+		if got := lines(rec); len(got) != 1 || got[0] != tt.want.String() {
+			t.Errorf("GET %s = %q, want %v", tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestHandlerBatchAndFormat(t *testing.T) {
+	h := &Handler{}
+	rec := get(h, "/v7?n=5&format=urn", "")
+	got := lines(rec)
+	if len(got) != 5 {
+		t.Fatalf("GET /v7?n=5 returned %d UUIDs, want 5", len(got))
+	}
+	seen := make(map[string]bool)
+	for _, s := range got {
+		if !strings.HasPrefix(s, "urn:uuid:") || seen[s] {
+			t.Errorf("GET /v7?n=5&format=urn returned %q", got)
+			break
+		}
+		seen[s] = true
+	}
+
+	if uuid.FIPS {
+		return
+	}
+	for _, f := range []uuid.Format{uuid.FormatCanonical, uuid.FormatHash, uuid.FormatBraced, uuid.FormatURN} {
+		var name string
+		for k, v := range formats {
+			if v == f {
+				name = k
+			}
+		}
+		want := string(uuid.NewV5(uuid.NamespaceOID, "x").AppendFormat(nil, f))
+		if got := lines(get(h, "/v5?ns=oid&name=x&format="+name, "")); got[0] != want {
+			t.Errorf("format=%s returned %q, want %q", name, got[0], want)
+		}
+	}
+}
+
+func TestHandlerJSON(t *testing.T) {
+	rec := get(&Handler{}, "/v4?n=3", "text/html, application/json;q=0.9")
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	var body struct {
+		UUIDs []string `json:"uuids"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if len(body.UUIDs) != 3 {
+		t.Fatalf("uuids = %q, want 3 UUIDs", body.UUIDs)
+	}
+	for _, s := range body.UUIDs {
+		if u, err := uuid.FromString(s); err != nil || u.Version() != uuid.V4 {
+			t.Errorf("uuid %q is not a version 4 UUID", s)
+		}
+	}
+}
+
+func TestHandlerErrors(t *testing.T) {
+	h := &Handler{MaxCount: 10}
+	for _, tt := range []struct {
+		target string
+		code   int
+	}{
+		{"/v2", http.StatusNotFound},
+		{"/", http.StatusNotFound},
+		{"/v4?n=0", http.StatusBadRequest},
+		{"/v4?n=11", http.StatusBadRequest},
+		{"/v4?n=x", http.StatusBadRequest},
+		{"/v4?format=base64", http.StatusBadRequest},
+		{"/v5?name=x", http.StatusBadRequest},
+		{"/v5?ns=bogus&name=x", http.StatusBadRequest},
+		{"/v5?ns=dns", http.StatusBadRequest},
+		{"/v3?ns=dns&name=x&n=2", http.StatusBadRequest},
+	} {
+		if rec := get(h, tt.target, ""); rec.Code != tt.code {
+			t.Errorf("GET %s status = %d, want %d", tt.target, rec.Code, tt.code)
+		}
+	}
+	if rec := get(h, "/v4?n=10", ""); rec.Code != http.StatusOK {
+		t.Errorf("GET /v4?n=10 status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/v4", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != "GET, HEAD" {
+		t.Errorf("POST /v4 status = %d, Allow = %q", rec.Code, rec.Header().Get("Allow"))
+	}
+
+	rec = httptest.NewRecorder()
+	(&Handler{Generator: failingGen{}}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v7", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("GET /v7 with a failing generator status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,120 @@
+// Package uuidhttp provides HTTP middleware ensuring that every request has
+// a request ID, a UUID carried in the X-Request-ID header.
+//
+// The middleware accepts the request ID sent by the client if it is a UUID
+// of the RFC 9562 variant in the canonical form, in any case. It generates a
+// version 7 UUID for requests without one or with any other value, so that
+// services do not propagate malformed or oversized IDs. The request ID is
+// set on the request and response headers in the canonical form, and stored
+// in the request context:
+//
+//	handler := uuidhttp.Middleware(mux)
+//
+//	func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+//		id, _ := uuidhttp.FromContext(r.Context())
+//		...
+//	}
+//
+// Handler is an http.Handler generating UUIDs, which can be embedded in a
+// service to provide UUIDs to clients in other languages.
+package uuidhttp
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Header is the default request ID header.
+const Header = "X-Request-ID"
+
+// canonicalLength is the length of the canonical string form of a UUID.
+const canonicalLength = 36
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx holding the request ID u.
+func NewContext(ctx context.Context, u uuid.UUID) context.Context {
+	return context.WithValue(ctx, contextKey{}, u)
+}
+
+// FromContext returns the request ID held by ctx, and false if ctx holds
+// none.
+func FromContext(ctx context.Context) (uuid.UUID, bool) {
+	u, ok := ctx.Value(contextKey{}).(uuid.UUID)
+	return u, ok
+}
+
+// Parse returns the request ID in s, and false if s is not a valid request
+// ID: a UUID of the RFC 9562 variant in the canonical form.
+func Parse(s string) (uuid.UUID, bool) {
+	if len(s) != canonicalLength {
+		return uuid.Nil, false
+	}
+	var u uuid.UUID
+	if err := u.Parse(s); err != nil || u.Variant() != uuid.VariantRFC9562 {
+		return uuid.Nil, false
+	}
+	return u, true
+}
+
+type config struct {
+	header string
+	gen    uuid.Generator
+}
+
+// Option configures the middleware returned by New.
+type Option func(*config)
+
+// WithHeader sets the request ID header, which is Header by default.
+func WithHeader(name string) Option {
+	return func(c *config) {
+		c.header = name
+	}
+}
+
+// WithGenerator sets the generator of the version 7 UUIDs of requests
+// without a valid request ID. By default, it is uuid.VersionGenerator(uuid.V7)
+// at the time of each request.
+func WithGenerator(gen uuid.Generator) Option {
+	return func(c *config) {
+		c.gen = gen
+	}
+}
+
+// New returns the request ID middleware configured by opts.
+func New(opts ...Option) func(http.Handler) http.Handler {
+	c := config{header: Header}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			u, ok := Parse(r.Header.Get(c.header))
+			if !ok {
+				gen := c.gen
+				if gen == nil {
+					gen = uuid.VersionGenerator(uuid.V7)
+				}
+				var err error
+				if u, err = gen.NewV7(); err != nil {
+					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+					return
+				}
+			}
+			id := u.String()
+			r = r.WithContext(NewContext(r.Context(), u))
+			r.Header = r.Header.Clone()
+			r.Header.Set(c.header, id)
+			w.Header().Set(c.header, id)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Middleware wraps next with the request ID middleware, using the default
+// header and generator.
+func Middleware(next http.Handler) http.Handler {
+	return New()(next)
+}
@@ -0,0 +1,155 @@
+package uuidhttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+var testUUID = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+func TestParse(t *testing.T) {
+	valid := []string{
+		testUUID.String(),
+		strings.ToUpper(testUUID.String()),
+		"01890a5d-ac96-774b-bcce-b302099a8057",
+	}
+	for _, s := range valid {
+		u, ok := Parse(s)
+		if !ok || !strings.EqualFold(u.String(), s) {
+			t.Errorf("Parse(%q) = %v, %t, want %s, true", s, u, ok, s)
+		}
+	}
+	invalid := []string{
+		"",
+		"request-1",
+		"6ba7b8109dad11d180b400c04fd430c8",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+		"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430cg",
+		uuid.Nil.String(),
+		uuid.Max.String(),
+		strings.Repeat("a", 1000),
+	}
+	for _, s := range invalid {
+		if u, ok := Parse(s); ok {
+			t.Errorf("Parse(%q) = %v, true, want false", s, u)
+		}
+	}
+}
+
+func TestContext(t *testing.T) {
+	if u, ok := FromContext(context.Background()); ok {
+		t.Errorf("FromContext(empty) = %v, true, want false", u)
+	}
+	u, ok := FromContext(NewContext(context.Background(), testUUID))
+	if !ok || u != testUUID {
+		t.Errorf("FromContext(NewContext(%v)) = %v, %t", testUUID, u, ok)
+	}
+}
+
+// serve runs a request with the given request ID header through h, and
+// returns the request ID seen by the handler and the response.
+func serve(t *testing.T, h func(http.Handler) http.Handler, header, value string) (uuid.UUID, *httptest.ResponseRecorder) {
+	t.Helper()
+	var seen uuid.UUID
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, ok := FromContext(r.Context())
+		if !ok {
+			t.Error("request context has no request ID")
+		}
+		if got := r.Header.Get(header); got != u.String() {
+			t.Errorf("request header %s = %q, want %q", header, got, u)
+		}
+		seen = u
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if value != "" {
+		req.Header.Set(header, value)
+	}
+	rec := httptest.NewRecorder()
+	h(next).ServeHTTP(rec, req)
+	if value != "" && req.Header.Get(header) != value {
+		t.Errorf("inbound request header changed to %q", req.Header.Get(header))
+	}
+	return seen, rec
+}
+
+func TestMiddleware(t *testing.T) {
+	got, rec := serve(t, Middleware, Header, strings.ToUpper(testUUID.String()))
+	if got != testUUID {
+		t.Errorf("request ID = %v, want %v", got, testUUID)
+	}
+	if h := rec.Header().Get(Header); h != testUUID.String() {
+		t.Errorf("response header %s = %q, want %q", Header, h, testUUID)
+	}
+
+	for _, value := range []string{"", "request-1", uuid.Nil.String()} {
+		got, rec := serve(t, Middleware, Header, value)
+		if got.Version() != uuid.V7 || got.Variant() != uuid.VariantRFC9562 {
+			t.Errorf("request ID for %q = %v, want a version 7 UUID", value, got)
+		}
+		if h := rec.Header().Get(Header); h != got.String() {
+			t.Errorf("response header %s = %q, want %q", Header, h, got)
+		}
+	}
+}
+
+type failingGen struct {
+	uuid.Generator
+}
+
+func (failingGen) NewV7() (uuid.UUID, error) {
+	return uuid.Nil, errors.New("no entropy")
+}
+
+func TestNew(t *testing.T) {
+	const header = "X-Correlation-ID"
+	at := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	gen := uuid.NewGenWithOptions(uuid.WithEpochFunc(func() time.Time { return at }))
+	mw := New(WithHeader(header), WithGenerator(gen))
+
+	got, rec := serve(t, mw, header, "")
+	ts, err := uuid.TimestampFromV7(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tm, _ := ts.Time(); !tm.Equal(at) {
+		t.Errorf("request ID %v has time %v, want %v", got, tm, at)
+	}
+	if h := rec.Header().Get(header); h != got.String() {
+		t.Errorf("response header %s = %q, want %q", header, h, got)
+	}
+	if h := rec.Header().Get(Header); h != "" {
+		t.Errorf("response header %s = %q, want none", Header, h)
+	}
+
+	t.Run("VersionGenerator", func(t *testing.T) {
+		// The generator of V7 is resolved for each request, after New.
+		mw := New()
+		if err := uuid.SetVersionGenerator(uuid.V7, gen); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { uuid.SetVersionGenerator(uuid.V7, nil) })
+		got, _ := serve(t, mw, Header, "")
+		if tm, err := got.Time(); err != nil || !tm.Equal(at) {
+			t.Errorf("request ID %v has time %v, %v, want %v", got, tm, err, at)
+		}
+	})
+
+	t.Run("GeneratorError", func(t *testing.T) {
+		called := false
+		next := http.HandlerFunc(func(http.ResponseWriter, *http.Request) { called = true })
+		rec := httptest.NewRecorder()
+		New(WithGenerator(failingGen{}))(next).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		if called || rec.Code != http.StatusInternalServerError {
+			t.Errorf("handler called = %t, status = %d, want false, %d", called, rec.Code, http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,34 @@
+// Package uuidjs exposes the generation and parsing of
+// github.com/gofrs/uuid/v5 to JavaScript, when compiled to WebAssembly with
+// GOOS=js and GOARCH=wasm, so that a JavaScript application embedding Go
+// does not need its own UUID implementation:
+//
+//	func main() {
+//		uuidjs.Export(js.Global().Get("uuid"), nil)
+//		select {}
+//	}
+//
+// Export sets the following functions on a JavaScript object. Namespaces
+// and parsed UUIDs are strings in any form accepted by uuid.FromString, and
+// UUIDs are returned in the canonical form:
+//
+//	newV1(), newV4(), newV6(), newV7()
+//	        a new UUID, or null if the generator failed
+//	newV3(ns, name), newV5(ns, name)
+//	        the name-based UUID, or null if ns is invalid
+//	newBatch(version, n)
+//	        an Array of n new UUIDs of version 1, 4, 6 or 7
+//	newBatchBytes(version, n)
+//	        a Uint8Array of the 16 bytes of each of n new UUIDs
+//	parse(s)
+//	        an object with the canonical form (uuid), version, variant and
+//	        bytes (a Uint8Array) of the UUID, or null if s is invalid
+//	parseError(s)
+//	        the error parsing s, or null if s is valid
+//
+// Crossing the boundary between JavaScript and WebAssembly is much slower
+// than generating a UUID: newBatch and newBatchBytes generate many UUIDs in a
+// single call, and newBatchBytes copies them to JavaScript at once.
+//
+// The package is empty on other platforms.
+package uuidjs
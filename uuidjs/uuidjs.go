@@ -0,0 +1,172 @@
+//go:build js && wasm
+
+package uuidjs
+
+import (
+	"strconv"
+	"syscall/js"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// generator returns the function of g generating UUIDs of the given
+// version, or nil if version is not 1, 4, 6 or 7.
+func generator(g uuid.Generator, version int) func() (uuid.UUID, error) {
+	switch version {
+	case 1:
+		return g.NewV1
+	case 4:
+		return g.NewV4
+	case 6:
+		return g.NewV6
+	case 7:
+		return g.NewV7
+	}
+	return nil
+}
+
+// bytesToJS returns a new Uint8Array holding b.
+func bytesToJS(b []byte) js.Value {
+	a := js.Global().Get("Uint8Array").New(len(b))
+	js.CopyBytesToJS(a, b)
+	return a
+}
+
+// arg returns the i-th argument in args, or undefined if there are fewer.
+func arg(args []js.Value, i int) js.Value {
+	if i < len(args) {
+		return args[i]
+	}
+	return js.Undefined()
+}
+
+// intArg returns the i-th argument in args as an int, and false if it is
+// not a number.
+func intArg(args []js.Value, i int) (int, bool) {
+	v := arg(args, i)
+	if v.Type() != js.TypeNumber {
+		return 0, false
+	}
+	return v.Int(), true
+}
+
+// parse returns the UUID of the i-th argument in args.
+func parse(args []js.Value, i int) (uuid.UUID, error) {
+	v := arg(args, i)
+	if v.Type() != js.TypeString {
+		return uuid.Nil, uuid.ErrInvalidFormat
+	}
+	return uuid.FromString(v.String())
+}
+
+// Export sets the functions of this package on target, generating UUIDs
+// with g, or uuid.DefaultGenerator if g is nil. It returns a function
+// releasing the resources of the functions, after which JavaScript must no
+// longer call them.
+func Export(target js.Value, g uuid.Generator) (release func()) {
+	if g == nil {
+		g = uuid.DefaultGenerator
+	}
+	var funcs []js.Func
+	set := func(name string, fn func(args []js.Value) interface{}) {
+		f := js.FuncOf(func(this js.Value, args []js.Value) interface{} {
+			return fn(args)
+		})
+		funcs = append(funcs, f)
+		target.Set(name, f)
+	}
+
+	for _, version := range []int{1, 4, 6, 7} {
+		gen := generator(g, version)
+		set("newV"+strconv.Itoa(version), func([]js.Value) interface{} {
+			u, err := gen()
+			if err != nil {
+				return nil
+			}
+			return u.String()
+		})
+	}
+	for name, newName := range map[string]func(uuid.UUID, string) uuid.UUID{
+		"newV3": g.NewV3,
+		"newV5": g.NewV5,
+	} {
+		newName := newName
+		set(name, func(args []js.Value) interface{} {
+			ns, err := parse(args, 0)
+			if err != nil || arg(args, 1).Type() != js.TypeString {
+				return nil
+			}
+			return newName(ns, args[1].String()).String()
+		})
+	}
+
+	// batch returns n UUIDs generated with the generator of the version in
+	// args, and false if the arguments are invalid or the generator failed.
+	batch := func(args []js.Value) ([]uuid.UUID, bool) {
+		version, ok := intArg(args, 0)
+		if !ok {
+			return nil, false
+		}
+		n, ok := intArg(args, 1)
+		gen := generator(g, version)
+		if !ok || n < 0 || gen == nil {
+			return nil, false
+		}
+		us := make([]uuid.UUID, n)
+		for i := range us {
+			u, err := gen()
+			if err != nil {
+				return nil, false
+			}
+			us[i] = u
+		}
+		return us, true
+	}
+	set("newBatch", func(args []js.Value) interface{} {
+		us, ok := batch(args)
+		if !ok {
+			return nil
+		}
+		strs := make([]interface{}, len(us))
+		for i, u := range us {
+			strs[i] = u.String()
+		}
+		return js.ValueOf(strs)
+	})
+	set("newBatchBytes", func(args []js.Value) interface{} {
+		us, ok := batch(args)
+		if !ok {
+			return nil
+		}
+		b := make([]byte, 0, len(us)*uuid.Size)
+		for _, u := range us {
+			b = append(b, u[:]...)
+		}
+		return bytesToJS(b)
+	})
+
+	set("parse", func(args []js.Value) interface{} {
+		u, err := parse(args, 0)
+		if err != nil {
+			return nil
+		}
+		return js.ValueOf(map[string]interface{}{
+			"uuid":    u.String(),
+			"version": int(u.Version()),
+			"variant": int(u.Variant()),
+			"bytes":   bytesToJS(u[:]),
+		})
+	})
+	set("parseError", func(args []js.Value) interface{} {
+		if _, err := parse(args, 0); err != nil {
+			return err.Error()
+		}
+		return nil
+	})
+
+	return func() {
+		for _, f := range funcs {
+			f.Release()
+		}
+	}
+}
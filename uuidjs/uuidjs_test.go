@@ -0,0 +1,118 @@
+//go:build js && wasm
+
+package uuidjs
+
+import (
+	"strconv"
+	"syscall/js"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// export returns a new object with the functions of this package.
+func export(t *testing.T, g uuid.Generator) js.Value {
+	obj := js.Global().Get("Object").New()
+	t.Cleanup(Export(obj, g))
+	return obj
+}
+
+// fromJS returns the UUID of the string v.
+func fromJS(t *testing.T, v js.Value) uuid.UUID {
+	t.Helper()
+	if v.Type() != js.TypeString {
+		t.Fatalf("got %v, want a string", v)
+	}
+	u, err := uuid.FromString(v.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func TestGenerate(t *testing.T) {
+	obj := export(t, nil)
+	for _, version := range []byte{1, 4, 6, 7} {
+		name := "newV" + strconv.Itoa(int(version))
+		if u := fromJS(t, obj.Call(name)); u.Version() != version {
+			t.Errorf("%s() = %v, of version %d", name, u, u.Version())
+		}
+	}
+	if u := fromJS(t, obj.Call("newV5", uuid.NamespaceDNS.String(), "example.com")); u != uuid.NewV5(uuid.NamespaceDNS, "example.com") {
+		t.Errorf("newV5() = %v", u)
+	}
+	if u := fromJS(t, obj.Call("newV3", "urn:uuid:"+uuid.NamespaceURL.String(), "a")); u != uuid.NewV3(uuid.NamespaceURL, "a") {
+		t.Errorf("newV3() = %v", u)
+	}
+	for _, args := range [][]interface{}{{"nope", "a"}, {uuid.NamespaceDNS.String()}, {}} {
+		if v := obj.Call("newV5", args...); !v.IsNull() {
+			t.Errorf("newV5(%v) = %v, want null", args, v)
+		}
+	}
+}
+
+func TestBatch(t *testing.T) {
+	obj := export(t, nil)
+	arr := obj.Call("newBatch", 7, 10)
+	if arr.Length() != 10 {
+		t.Fatalf("newBatch(7, 10) has %d UUIDs", arr.Length())
+	}
+	for i := 0; i < arr.Length(); i++ {
+		if u := fromJS(t, arr.Index(i)); u.Version() != uuid.V7 {
+			t.Errorf("newBatch(7, 10)[%d] = %v", i, u)
+		}
+	}
+
+	b := obj.Call("newBatchBytes", 4, 3)
+	if b.Get("length").Int() != 3*uuid.Size {
+		t.Fatalf("newBatchBytes(4, 3) has %d bytes", b.Get("length").Int())
+	}
+	buf := make([]byte, 3*uuid.Size)
+	js.CopyBytesToGo(buf, b)
+	for i := 0; i < 3; i++ {
+		if u := uuid.FromBytesOrNil(buf[i*uuid.Size : (i+1)*uuid.Size]); u.Version() != uuid.V4 {
+			t.Errorf("newBatchBytes(4, 3) UUID %d = %v", i, u)
+		}
+	}
+
+	if n := obj.Call("newBatch", 4, 0).Length(); n != 0 {
+		t.Errorf("newBatch(4, 0) has %d UUIDs", n)
+	}
+	for _, args := range [][]interface{}{{5, 1}, {7, -1}, {"7", 1}, {7}} {
+		if v := obj.Call("newBatch", args...); !v.IsNull() {
+			t.Errorf("newBatch(%v) = %v, want null", args, v)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	obj := export(t, nil)
+	u := uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+	v := obj.Call("parse", "{6BA7B810-9DAD-11D1-80B4-00C04FD430C8}")
+	if got := v.Get("uuid").String(); got != u.String() {
+		t.Errorf("parse().uuid = %q, want %q", got, u)
+	}
+	if got := v.Get("version").Int(); got != 1 {
+		t.Errorf("parse().version = %d, want 1", got)
+	}
+	if got := v.Get("variant").Int(); got != int(uuid.VariantRFC9562) {
+		t.Errorf("parse().variant = %d, want %d", got, uuid.VariantRFC9562)
+	}
+	var b [uuid.Size]byte
+	js.CopyBytesToGo(b[:], v.Get("bytes"))
+	if uuid.UUID(b) != u {
+		t.Errorf("parse().bytes = %x, want %x", b, u[:])
+	}
+	if err := obj.Call("parseError", u.String()); !err.IsNull() {
+		t.Errorf("parseError(%q) = %v, want null", u, err)
+	}
+
+	for _, s := range []interface{}{"nope", 42} {
+		if v := obj.Call("parse", s); !v.IsNull() {
+			t.Errorf("parse(%v) = %v, want null", s, v)
+		}
+		if err := obj.Call("parseError", s); err.Type() != js.TypeString {
+			t.Errorf("parseError(%v) = %v, want an error", s, err)
+		}
+	}
+}
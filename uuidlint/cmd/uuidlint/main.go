@@ -0,0 +1,17 @@
+// Command uuidlint reports misuses of github.com/gofrs/uuid/v5 with the
+// analyzer of github.com/gofrs/uuid/v5/uuidlint. It runs on its own, or as
+// the tool of go vet:
+//
+//	uuidlint ./...
+//	uuidlint -version=7 ./...
+//	go vet -vettool=$(which uuidlint) ./...
+package main
+
+import (
+	"github.com/gofrs/uuid/v5/uuidlint"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(uuidlint.Analyzer)
+}
@@ -0,0 +1,54 @@
+package a
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+var ns = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+var other, _ = uuid.FromString("6ba7b811-9dad-11d1-80b4-00c04fd430c8")
+
+const id = "6ba7b812-9dad-11d1-80b4-00c04fd430c8"
+
+func ignored(g uuid.Generator) {
+	uuid.NewV4()                     // want `error returned by uuid.NewV4 is ignored`
+	u, _ := uuid.NewV7()             // want `error returned by uuid.NewV7 is ignored`
+	var v, _ = uuid.NewV1()          // want `error returned by uuid.NewV1 is ignored`
+	_, _ = g.NewV7AtTime(time.Now()) // want `error returned by uuid.Generator.NewV7AtTime is ignored`
+	w, _ := (uuid.NewGen().NewV7())  // want `error returned by uuid.Gen.NewV7 is ignored`
+	_, _, _ = u, v, w
+	uuid.NewV3(ns, "a")
+}
+
+func handled() (uuid.UUID, error) {
+	u, err := uuid.NewV4()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return u, nil
+}
+
+func compared(a, b uuid.UUID, s string) bool {
+	if a.String() == b.String() { // want `comparison of UUID strings`
+		return true
+	}
+	if s != a.String() {
+		return true
+	}
+	if (a.String()) != b.String() { // want `comparison of UUID strings`
+		return true
+	}
+	return a == b || s == "" || a.String() < s
+}
+
+func parsed(s string) uuid.UUID {
+	u, _ := uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8") // want `uuid.FromString of the constant "6ba7b810-9dad-11d1-80b4-00c04fd430c8" parses it at every call`
+	f := func() uuid.UUID {
+		return uuid.FromStringOrNil(id) // want `uuid.FromStringOrNil of the constant "6ba7b812-9dad-11d1-80b4-00c04fd430c8"`
+	}
+	v, _ := uuid.FromString(s)
+	_, _ = u, f
+	return v
+}
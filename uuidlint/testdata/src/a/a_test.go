@@ -0,0 +1,14 @@
+package a
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestParse(t *testing.T) {
+	if _, err := uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"); err != nil {
+		t.Fatal(err)
+	}
+	uuid.NewV4() // want `error returned by uuid.NewV4 is ignored`
+}
@@ -0,0 +1,43 @@
+// Package uuid is a stub of github.com/gofrs/uuid/v5 for the tests of
+// uuidlint.
+package uuid
+
+import "time"
+
+type UUID [16]byte
+
+var Nil UUID
+
+func (u UUID) String() string { return "" }
+
+func Must(u UUID, err error) UUID { return u }
+
+func FromString(s string) (UUID, error) { return Nil, nil }
+
+func FromStringOrNil(s string) UUID { return Nil }
+
+func NewV1() (UUID, error) { return Nil, nil }
+
+func NewV3(ns UUID, name string) UUID { return Nil }
+
+func NewV4() (UUID, error) { return Nil, nil }
+
+func NewV6() (UUID, error) { return Nil, nil }
+
+func NewV7() (UUID, error) { return Nil, nil }
+
+type Generator interface {
+	NewV1() (UUID, error)
+	NewV7() (UUID, error)
+	NewV7AtTime(time.Time) (UUID, error)
+}
+
+type Gen struct{}
+
+func NewGen() *Gen { return &Gen{} }
+
+func (g *Gen) NewV1() (UUID, error) { return Nil, nil }
+
+func (g *Gen) NewV7() (UUID, error) { return Nil, nil }
+
+func (g *Gen) NewV7AtTime(time.Time) (UUID, error) { return Nil, nil }
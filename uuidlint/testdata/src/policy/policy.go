@@ -0,0 +1,23 @@
+package policy
+
+import "github.com/gofrs/uuid/v5"
+
+func generate(g uuid.Generator) error {
+	if _, err := uuid.NewV1(); err != nil { // want `uuid.NewV1 generates version 1 UUIDs, but the policy is version 7`
+		return err
+	}
+	if _, err := uuid.NewV4(); err != nil { // want `uuid.NewV4 generates version 4 UUIDs, but the policy is version 7`
+		return err
+	}
+	if _, err := g.NewV1(); err != nil { // want `uuid.Generator.NewV1 generates version 1 UUIDs, but the policy is version 7`
+		return err
+	}
+	if _, err := uuid.NewV7(); err != nil {
+		return err
+	}
+	if _, err := g.NewV7(); err != nil {
+		return err
+	}
+	uuid.NewV3(uuid.Nil, "a")
+	return nil
+}
@@ -0,0 +1,224 @@
+// Package uuidlint provides an analysis.Analyzer of golang.org/x/tools
+// reporting common misuses of github.com/gofrs/uuid/v5:
+//
+//   - ignoring the error returned when generating a UUID, such as that of
+//     uuid.NewV4, which leaves the UUID to uuid.Nil when the random source
+//     fails;
+//   - comparing the strings of UUIDs, such as a.String() == b.String(),
+//     instead of the UUIDs;
+//   - parsing a constant with uuid.FromString or uuid.FromStringOrNil in a
+//     function, at every call, rather than once in a package-level variable
+//     initialized with uuid.Must(uuid.FromString(...)), outside of tests;
+//   - with the -version flag, generating random or time-based UUIDs of
+//     another version than the one required by the policy of the project,
+//     such as version 1 UUIDs when the policy is version 7.
+//
+// The analyzer can be run by go vet with the command of the cmd/uuidlint
+// directory:
+//
+//	go vet -vettool=$(which uuidlint) ./...
+package uuidlint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// uuidPath is the import path of the uuid package.
+const uuidPath = "github.com/gofrs/uuid/v5"
+
+// Analyzer reports misuses of github.com/gofrs/uuid/v5.
+var Analyzer = &analysis.Analyzer{
+	Name:     "uuidlint",
+	Doc:      "report misuses of github.com/gofrs/uuid/v5\n\nThe analyzer reports ignored UUID generation errors, comparisons of UUID strings, constants parsed in functions and, with -version, UUIDs generated of another version than the policy.",
+	URL:      "https://pkg.go.dev/github.com/gofrs/uuid/v5/uuidlint",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// policyVersion is the value of the -version flag.
+var policyVersion int
+
+func init() {
+	Analyzer.Flags.IntVar(&policyVersion, "version", 0, "report the generation of random and time-based UUIDs of versions other than this one (1, 4, 6 or 7), or 0 to report none")
+}
+
+// generatorVersions are the versions of the UUIDs generated by the random
+// and time-based generation functions and methods, by name.
+var generatorVersions = map[string]int{
+	"NewV1":       1,
+	"NewV1AtTime": 1,
+	"NewV4":       4,
+	"NewV6":       6,
+	"NewV6AtTime": 6,
+	"NewV7":       7,
+	"NewV7AtTime": 7,
+}
+
+// isUUID reports whether t is the UUID type of the uuid package.
+func isUUID(t types.Type) bool {
+	n, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := n.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == uuidPath && obj.Name() == "UUID"
+}
+
+// uuidFunc returns the function or method of the uuid package called by
+// call, or nil if call does not call one.
+func uuidFunc(info *types.Info, call *ast.CallExpr) *types.Func {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != uuidPath {
+		return nil
+	}
+	return fn
+}
+
+// name returns the name of fn as written in code, with its receiver type
+// for methods.
+func name(fn *types.Func) string {
+	sig := fn.Type().(*types.Signature)
+	if recv := sig.Recv(); recv != nil {
+		t := recv.Type()
+		if p, ok := t.(*types.Pointer); ok {
+			t = p.Elem()
+		}
+		if n, ok := t.(*types.Named); ok {
+			return "uuid." + n.Obj().Name() + "." + fn.Name()
+		}
+	}
+	return "uuid." + fn.Name()
+}
+
+// generatesWithError reports whether fn is a function or method of the uuid
+// package generating a UUID and returning an error.
+func generatesWithError(fn *types.Func) bool {
+	if !strings.HasPrefix(fn.Name(), "New") {
+		return false
+	}
+	res := fn.Type().(*types.Signature).Results()
+	return res.Len() == 2 && isUUID(res.At(0).Type()) && types.Identical(res.At(1).Type(), types.Universe.Lookup("error").Type())
+}
+
+// isStringOfUUID reports whether e is a call to the String method of a UUID.
+func isStringOfUUID(info *types.Info, e ast.Expr) bool {
+	call, ok := ast.Unparen(e).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fn := uuidFunc(info, call)
+	if fn == nil || fn.Name() != "String" {
+		return false
+	}
+	recv := fn.Type().(*types.Signature).Recv()
+	return recv != nil && isUUID(recv.Type())
+}
+
+// isBlank reports whether e is the blank identifier.
+func isBlank(e ast.Expr) bool {
+	id, ok := e.(*ast.Ident)
+	return ok && id.Name == "_"
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	if pass.Pkg.Path() == uuidPath {
+		return nil, nil
+	}
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodes := []ast.Node{
+		(*ast.ExprStmt)(nil),
+		(*ast.AssignStmt)(nil),
+		(*ast.ValueSpec)(nil),
+		(*ast.BinaryExpr)(nil),
+		(*ast.CallExpr)(nil),
+	}
+	inspect.WithStack(nodes, func(n ast.Node, push bool, stack []ast.Node) bool {
+		if !push {
+			return true
+		}
+		switch n := n.(type) {
+		case *ast.ExprStmt:
+			checkIgnoredError(pass, n.X, nil)
+		case *ast.AssignStmt:
+			if len(n.Rhs) == 1 && len(n.Lhs) == 2 {
+				checkIgnoredError(pass, n.Rhs[0], n.Lhs[1])
+			}
+		case *ast.ValueSpec:
+			if len(n.Values) == 1 && len(n.Names) == 2 {
+				checkIgnoredError(pass, n.Values[0], n.Names[1])
+			}
+		case *ast.BinaryExpr:
+			if (n.Op == token.EQL || n.Op == token.NEQ) && isStringOfUUID(pass.TypesInfo, n.X) && isStringOfUUID(pass.TypesInfo, n.Y) {
+				pass.Reportf(n.OpPos, "comparison of UUID strings: compare the UUIDs instead")
+			}
+		case *ast.CallExpr:
+			checkConstantParse(pass, n, stack)
+			checkPolicy(pass, n)
+		}
+		return true
+	})
+	return nil, nil
+}
+
+// checkIgnoredError reports e if it is a call generating a UUID whose error
+// is assigned to errExpr, and errExpr is nil or blank.
+func checkIgnoredError(pass *analysis.Pass, e, errExpr ast.Expr) {
+	call, ok := ast.Unparen(e).(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	fn := uuidFunc(pass.TypesInfo, call)
+	if fn == nil || !generatesWithError(fn) || (errExpr != nil && !isBlank(errExpr)) {
+		return
+	}
+	pass.Reportf(call.Pos(), "error returned by %s is ignored: the UUID is uuid.Nil when it is not nil", name(fn))
+}
+
+// checkConstantParse reports call if it parses a constant in a function,
+// outside of tests, which commonly parse constants on purpose.
+func checkConstantParse(pass *analysis.Pass, call *ast.CallExpr, stack []ast.Node) {
+	if strings.HasSuffix(pass.Fset.File(call.Pos()).Name(), "_test.go") {
+		return
+	}
+	fn := uuidFunc(pass.TypesInfo, call)
+	if fn == nil || (fn.Name() != "FromString" && fn.Name() != "FromStringOrNil") ||
+		fn.Type().(*types.Signature).Recv() != nil || len(call.Args) != 1 {
+		return
+	}
+	tv, ok := pass.TypesInfo.Types[call.Args[0]]
+	if !ok || tv.Value == nil {
+		return
+	}
+	for _, n := range stack {
+		switch n.(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			pass.Reportf(call.Pos(), "%s of the constant %s parses it at every call: declare a package-level variable initialized with uuid.Must(uuid.FromString(%s))", name(fn), tv.Value, tv.Value)
+			return
+		}
+	}
+}
+
+// checkPolicy reports call if it generates UUIDs of another version than
+// policyVersion.
+func checkPolicy(pass *analysis.Pass, call *ast.CallExpr) {
+	if policyVersion == 0 {
+		return
+	}
+	fn := uuidFunc(pass.TypesInfo, call)
+	if fn == nil {
+		return
+	}
+	version, ok := generatorVersions[fn.Name()]
+	if !ok || version == policyVersion || !generatesWithError(fn) {
+		return
+	}
+	pass.Reportf(call.Pos(), "%s generates version %d UUIDs, but the policy is version %d", name(fn), version, policyVersion)
+}
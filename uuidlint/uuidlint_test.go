@@ -0,0 +1,19 @@
+package uuidlint
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+}
+
+func TestAnalyzerPolicy(t *testing.T) {
+	if err := Analyzer.Flags.Set("version", "7"); err != nil {
+		t.Fatal(err)
+	}
+	defer Analyzer.Flags.Set("version", "0")
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "policy")
+}
@@ -0,0 +1,98 @@
+// Package uuidlog adds the UUID types of github.com/gofrs/uuid/v5 to the
+// structured log entries of go.uber.org/zap and github.com/rs/zerolog, in
+// their canonical form.
+//
+// The helpers format UUIDs into buffers which the encoders copy, rather
+// than into intermediate strings, as zap.Stringer and zerolog.Event.Stringer
+// do:
+//
+//	logger.Info("order placed", uuidlog.Zap("order_id", id))
+//	uuidlog.Zerolog(log.Info(), "order_id", id).Msg("order placed")
+//
+// Array holds a []uuid.UUID logged as an array by both libraries.
+package uuidlog
+
+import (
+	"github.com/gofrs/uuid/v5"
+	"github.com/rs/zerolog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// canonicalLength is the length of the canonical string form of a UUID.
+const canonicalLength = 36
+
+// format returns the canonical form of u in an array.
+func format(u uuid.UUID) [canonicalLength]byte {
+	var buf [canonicalLength]byte
+	u.AppendFormat(buf[:0], uuid.FormatCanonical)
+	return buf
+}
+
+// zapField is the value of the field returned by Zap.
+type zapField struct {
+	key string
+	buf [canonicalLength]byte
+}
+
+// MarshalLogObject implements the zapcore.ObjectMarshaler interface, adding
+// the field to enc.
+func (f *zapField) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddByteString(f.key, f.buf[:])
+	return nil
+}
+
+// Zap returns a zap.Field with the given key and the canonical form of u,
+// with a single allocation holding both.
+func Zap(key string, u uuid.UUID) zap.Field {
+	return zap.Inline(&zapField{key: key, buf: format(u)})
+}
+
+// ZapArray returns a zap.Field with the given key and an array of the
+// canonical forms of us.
+func ZapArray(key string, us []uuid.UUID) zap.Field {
+	return zap.Array(key, Array(us))
+}
+
+// Zerolog adds the canonical form of u to e with the given key, and returns
+// e. The UUID is only formatted if e is enabled.
+func Zerolog(e *zerolog.Event, key string, u uuid.UUID) *zerolog.Event {
+	if !e.Enabled() {
+		return e
+	}
+	buf := format(u)
+	return e.Bytes(key, buf[:])
+}
+
+// ZerologContext adds the canonical form of u to the fields of c with the
+// given key, and returns c.
+func ZerologContext(c zerolog.Context, key string, u uuid.UUID) zerolog.Context {
+	buf := format(u)
+	return c.Bytes(key, buf[:])
+}
+
+// Array is a []uuid.UUID implementing zapcore.ArrayMarshaler and
+// zerolog.LogArrayMarshaler, which logs the canonical forms of the UUIDs as
+// an array:
+//
+//	logger.Info("batch", zap.Array("ids", uuidlog.Array(ids)))
+//	log.Info().Array("ids", uuidlog.Array(ids)).Msg("batch")
+type Array []uuid.UUID
+
+// MarshalLogArray implements the zapcore.ArrayMarshaler interface. It
+// formats all the UUIDs in a single buffer.
+func (a Array) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	buf := make([]byte, 0, canonicalLength)
+	for _, u := range a {
+		enc.AppendByteString(u.AppendFormat(buf, uuid.FormatCanonical))
+	}
+	return nil
+}
+
+// MarshalZerologArray implements the zerolog.LogArrayMarshaler interface.
+func (a Array) MarshalZerologArray(arr *zerolog.Array) {
+	for _, u := range a {
+		buf := format(u)
+		arr.Bytes(buf[:])
+	}
+}
@@ -0,0 +1,109 @@
+package uuidlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/rs/zerolog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var testUUID = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+var testUUIDs = []uuid.UUID{testUUID, uuid.Nil, uuid.Max}
+
+func decode(t *testing.T, data []byte) map[string]interface{} {
+	t.Helper()
+	var m map[string]interface{}
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("json.Unmarshal(%s) unexpected error: %v", data, err)
+	}
+	return m
+}
+
+func wantArray() []interface{} {
+	var want []interface{}
+	for _, u := range testUUIDs {
+		want = append(want, u.String())
+	}
+	return want
+}
+
+func checkEntry(t *testing.T, m map[string]interface{}) {
+	t.Helper()
+	if got := m["id"]; got != testUUID.String() {
+		t.Errorf("id = %v, want %q", got, testUUID.String())
+	}
+	ids, _ := m["ids"].([]interface{})
+	want := wantArray()
+	if len(ids) != len(want) {
+		t.Fatalf("ids = %v, want %v", m["ids"], want)
+	}
+	for i := range ids {
+		if ids[i] != want[i] {
+			t.Errorf("ids[%d] = %v, want %v", i, ids[i], want[i])
+		}
+	}
+}
+
+func TestZap(t *testing.T) {
+	var buf bytes.Buffer
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	logger := zap.New(zapcore.NewCore(enc, zapcore.AddSync(&buf), zapcore.InfoLevel))
+	logger.Info("test", Zap("id", testUUID), ZapArray("ids", testUUIDs))
+	checkEntry(t, decode(t, buf.Bytes()))
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	zap.New(core).Info("test", Zap("id", testUUID), zap.Array("ids", Array(testUUIDs)))
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("observed %d entries, want 1", len(entries))
+	}
+	if got := entries[0].ContextMap()["id"]; got != testUUID.String() {
+		t.Errorf("observed id = %v, want %q", got, testUUID.String())
+	}
+}
+
+func TestZapAllocs(t *testing.T) {
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	allocs := testing.AllocsPerRun(100, func() {
+		Zap("id", testUUID).AddTo(enc)
+	})
+	if allocs > 1 {
+		t.Errorf("encoding a Zap field allocated %v times, want 1", allocs)
+	}
+}
+
+func TestZerolog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	Zerolog(logger.Info(), "id", testUUID).Array("ids", Array(testUUIDs)).Msg("test")
+	checkEntry(t, decode(t, buf.Bytes()))
+
+	buf.Reset()
+	logger = ZerologContext(zerolog.New(&buf).With(), "id", testUUID).Logger()
+	logger.Info().Array("ids", Array(testUUIDs)).Msg("test")
+	checkEntry(t, decode(t, buf.Bytes()))
+
+	buf.Reset()
+	logger = zerolog.New(&buf).Level(zerolog.WarnLevel)
+	Zerolog(logger.Info(), "id", testUUID).Msg("test")
+	if buf.Len() != 0 {
+		t.Errorf("disabled event logged %s", buf.Bytes())
+	}
+}
+
+func TestZerologAllocs(t *testing.T) {
+	logger := zerolog.New(io.Discard)
+	allocs := testing.AllocsPerRun(100, func() {
+		Zerolog(logger.Info(), "id", testUUID).Msg("")
+	})
+	if allocs != 0 {
+		t.Errorf("logging with Zerolog allocated %v times, want 0", allocs)
+	}
+}
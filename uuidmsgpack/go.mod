@@ -0,0 +1,12 @@
+module github.com/gofrs/uuid/v5/uuidmsgpack
+
+go 1.19
+
+require (
+	github.com/gofrs/uuid/v5 v5.0.0
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+)
+
+require github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+
+replace github.com/gofrs/uuid/v5 => ../
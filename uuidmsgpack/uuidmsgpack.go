@@ -0,0 +1,112 @@
+// Package uuidmsgpack configures github.com/vmihailenco/msgpack/v5 to encode
+// the UUID types of github.com/gofrs/uuid/v5 compactly.
+//
+// Through its encoding.BinaryMarshaler implementation, msgpack already
+// encodes a uuid.UUID as 16 bytes of bin 8 data, but it encodes a
+// uuid.NullUUID as a two-field map and cannot decode UUIDs sent as strings.
+// Register fixes both, keeping the bin 8 encoding, while RegisterExt instead
+// encodes UUIDs as a fixext 16 extension type, as some other implementations
+// do. Both register global codecs, so they should be called once, during
+// program initialization; a later call replaces the codecs of an earlier one.
+package uuidmsgpack
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+// Register registers codecs encoding uuid.UUID values as bin 8 data of 16
+// bytes, and uuid.NullUUID values as either nil or a uuid.UUID. Decoding a
+// uuid.UUID accepts bin or str data holding either the binary or any text
+// form of a UUID, and nil, decoded as uuid.Nil.
+func Register() {
+	msgpack.Register(uuid.UUID{}, encodeUUID, decodeUUID)
+	registerNullUUID()
+}
+
+// RegisterExt registers codecs encoding uuid.UUID values as a fixext 16
+// extension type with the given ID, holding the 16 bytes of the UUID, and
+// uuid.NullUUID values as either nil or a uuid.UUID.
+func RegisterExt(extID int8) {
+	msgpack.RegisterExtEncoder(extID, uuid.UUID{}, func(e *msgpack.Encoder, v reflect.Value) ([]byte, error) {
+		u := v.Interface().(uuid.UUID)
+		return u[:], nil
+	})
+	msgpack.RegisterExtDecoder(extID, uuid.UUID{}, func(d *msgpack.Decoder, v reflect.Value, extLen int) error {
+		if extLen != uuid.Size {
+			return fmt.Errorf("uuidmsgpack: UUID extension has length %d, want %d", extLen, uuid.Size)
+		}
+		var u uuid.UUID
+		if err := d.ReadFull(u[:]); err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(u))
+		return nil
+	})
+	registerNullUUID()
+}
+
+func registerNullUUID() {
+	msgpack.Register(uuid.NullUUID{}, encodeNullUUID, decodeNullUUID)
+}
+
+func encodeUUID(e *msgpack.Encoder, v reflect.Value) error {
+	u := v.Interface().(uuid.UUID)
+	return e.EncodeBytes(u[:])
+}
+
+func decodeUUID(d *msgpack.Decoder, v reflect.Value) error {
+	n, err := d.DecodeBytesLen()
+	if err != nil {
+		return err
+	}
+	var u uuid.UUID
+	switch {
+	case n == -1:
+	case n == uuid.Size:
+		err = d.ReadFull(u[:])
+	case n <= 45:
+		var buf [45]byte
+		if err = d.ReadFull(buf[:n]); err == nil {
+			err = u.UnmarshalText(buf[:n])
+		}
+	default:
+		err = fmt.Errorf("uuidmsgpack: cannot decode %d bytes into a UUID", n)
+	}
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(u))
+	return nil
+}
+
+func encodeNullUUID(e *msgpack.Encoder, v reflect.Value) error {
+	nu := v.Interface().(uuid.NullUUID)
+	if !nu.Valid {
+		return e.EncodeNil()
+	}
+	return e.Encode(nu.UUID)
+}
+
+func decodeNullUUID(d *msgpack.Decoder, v reflect.Value) error {
+	c, err := d.PeekCode()
+	if err != nil {
+		return err
+	}
+	var nu uuid.NullUUID
+	if c == msgpcode.Nil {
+		err = d.DecodeNil()
+	} else {
+		err = d.Decode(&nu.UUID)
+		nu.Valid = err == nil
+	}
+	if err != nil {
+		return err
+	}
+	v.Set(reflect.ValueOf(nu))
+	return nil
+}
@@ -0,0 +1,127 @@
+package uuidmsgpack
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+var testUUID = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+type message struct {
+	ID  uuid.UUID
+	Ref uuid.NullUUID
+}
+
+// The tests below change the global msgpack codecs, so they must not run in
+// parallel.
+
+func TestRegister(t *testing.T) {
+	Register()
+
+	t.Run("Encode", func(t *testing.T) {
+		tests := []struct {
+			v    any
+			want []byte
+		}{
+			{testUUID, append([]byte{0xc4, 0x10}, testUUID[:]...)},
+			{uuid.NullUUID{UUID: testUUID, Valid: true}, append([]byte{0xc4, 0x10}, testUUID[:]...)},
+			{uuid.NullUUID{}, []byte{0xc0}},
+		}
+		for _, tt := range tests {
+			got, err := msgpack.Marshal(tt.v)
+			if err != nil {
+				t.Fatalf("Marshal(%v) unexpected error: %v", tt.v, err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("Marshal(%v) = %x, want %x", tt.v, got, tt.want)
+			}
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		for _, in := range []message{
+			{ID: testUUID, Ref: uuid.NullUUID{UUID: testUUID, Valid: true}},
+			{ID: testUUID},
+		} {
+			data, err := msgpack.Marshal(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out := message{Ref: uuid.NullUUID{UUID: testUUID, Valid: true}}
+			if err := msgpack.Unmarshal(data, &out); err != nil {
+				t.Fatalf("Unmarshal(%x) unexpected error: %v", data, err)
+			}
+			if out != in {
+				t.Errorf("Unmarshal(Marshal(%+v)) = %+v", in, out)
+			}
+		}
+	})
+
+	t.Run("DecodeString", func(t *testing.T) {
+		for _, s := range []string{testUUID.String(), "{" + testUUID.String() + "}", "urn:uuid:" + testUUID.String()} {
+			data, err := msgpack.Marshal(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got uuid.UUID
+			if err := msgpack.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal(%q) unexpected error: %v", s, err)
+			}
+			if got != testUUID {
+				t.Errorf("Unmarshal(%q) = %v, want %v", s, got, testUUID)
+			}
+		}
+	})
+
+	t.Run("DecodeInvalid", func(t *testing.T) {
+		for _, v := range []any{"not-a-uuid", make([]byte, 100), 42} {
+			data, err := msgpack.Marshal(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got uuid.UUID
+			if err := msgpack.Unmarshal(data, &got); err == nil {
+				t.Errorf("Unmarshal(%v) succeeded, want error", v)
+			}
+		}
+	})
+}
+
+func TestRegisterExt(t *testing.T) {
+	const extID = 2
+	RegisterExt(extID)
+	defer Register()
+	defer msgpack.UnregisterExt(extID)
+
+	data, err := msgpack.Marshal(testUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := append([]byte{0xd8, extID}, testUUID[:]...); !bytes.Equal(data, want) {
+		t.Errorf("Marshal(%v) = %x, want %x", testUUID, data, want)
+	}
+
+	in := message{ID: testUUID, Ref: uuid.NullUUID{UUID: testUUID, Valid: true}}
+	data, err = msgpack.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out message
+	if err := msgpack.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Unmarshal(%x) unexpected error: %v", data, err)
+	}
+	if out != in {
+		t.Errorf("Unmarshal(Marshal(%+v)) = %+v", in, out)
+	}
+
+	var v any
+	if err := msgpack.Unmarshal(append([]byte{0xd8, extID}, testUUID[:]...), &v); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := v.(uuid.UUID); !ok || got != testUUID {
+		t.Errorf("Unmarshal into interface = %#v, want %v", v, testUUID)
+	}
+}
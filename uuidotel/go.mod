@@ -0,0 +1,15 @@
+module github.com/gofrs/uuid/v5/uuidotel
+
+go 1.25.0
+
+require (
+	github.com/gofrs/uuid/v5 v5.0.0
+	go.opentelemetry.io/otel/trace v1.46.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	go.opentelemetry.io/otel v1.46.0 // indirect
+)
+
+replace github.com/gofrs/uuid/v5 => ../
@@ -0,0 +1,79 @@
+// Package uuidotel converts the trace and span IDs of
+// go.opentelemetry.io/otel/trace to and from the UUID type of
+// github.com/gofrs/uuid/v5, so that data keyed by trace or span can be
+// stored in UUID columns.
+//
+// A trace ID has the 16 bytes of a UUID, and FromTraceID keeps them as they
+// are. Trace IDs are random, and the UUIDs returned are not of any UUID
+// version or variant, but they are the UUIDs any other tool copying the
+// bytes will use.
+//
+// A span ID has only 8 bytes. FromSpanID stores them in the custom bits of
+// a version 8 UUID, leaving the other bits zero, and ToSpanID extracts them
+// from such UUIDs only.
+package uuidotel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// FromTraceID returns the UUID with the bytes of id.
+func FromTraceID(id trace.TraceID) uuid.UUID {
+	return uuid.UUID(id)
+}
+
+// ToTraceID returns the trace ID with the bytes of u. It returns an error if
+// u is uuid.Nil, which is not a valid trace ID.
+func ToTraceID(u uuid.UUID) (trace.TraceID, error) {
+	id := trace.TraceID(u)
+	if !id.IsValid() {
+		return trace.TraceID{}, fmt.Errorf("uuidotel: %s is not a valid trace ID", u)
+	}
+	return id, nil
+}
+
+// FromContext returns the UUID of the trace ID of the span context in ctx,
+// and false if ctx holds no valid span context.
+func FromContext(ctx context.Context) (uuid.UUID, bool) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return uuid.Nil, false
+	}
+	return FromTraceID(sc.TraceID()), true
+}
+
+// FromSpanID returns the version 8 UUID holding id. The 64 bits of id fill,
+// in order, the 48 bits of custom_a, the 12 bits of custom_b and the low 4
+// bits of the byte holding the variant; the other bits of custom_c are zero:
+//
+//	ssssssss-ssss-8sss-8s00-000000000000
+func FromSpanID(id trace.SpanID) uuid.UUID {
+	var u uuid.UUID
+	copy(u[:6], id[:6])
+	u[6] = 0x80 | id[6]>>4
+	u[7] = id[6]<<4 | id[7]>>4
+	u[8] = 0x80 | id[7]&0x0f
+	return u
+}
+
+// ToSpanID returns the span ID held by a UUID returned by FromSpanID. It
+// returns an error if u does not have the layout of such UUIDs, or if the
+// span ID is zero, which is not valid.
+func ToSpanID(u uuid.UUID) (trace.SpanID, error) {
+	if u.Version() != 8 || u[8]&0xf0 != 0x80 || u[9] != 0 || u[10] != 0 || u[11] != 0 ||
+		u[12] != 0 || u[13] != 0 || u[14] != 0 || u[15] != 0 {
+		return trace.SpanID{}, fmt.Errorf("uuidotel: %s does not hold a span ID", u)
+	}
+	var id trace.SpanID
+	copy(id[:6], u[:6])
+	id[6] = u[6]<<4 | u[7]>>4
+	id[7] = u[7]<<4 | u[8]&0x0f
+	if !id.IsValid() {
+		return trace.SpanID{}, fmt.Errorf("uuidotel: %s does not hold a valid span ID", u)
+	}
+	return id, nil
+}
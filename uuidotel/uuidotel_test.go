@@ -0,0 +1,84 @@
+package uuidotel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var testUUID = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+func TestTraceID(t *testing.T) {
+	id, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := FromTraceID(id)
+	if want := "4bf92f35-77b3-4da6-a3ce-929d0e0e4736"; u.String() != want {
+		t.Errorf("FromTraceID(%v) = %v, want %s", id, u, want)
+	}
+	got, err := ToTraceID(u)
+	if err != nil || got != id {
+		t.Errorf("ToTraceID(%v) = %v, %v, want %v", u, got, err, id)
+	}
+	if got, err := ToTraceID(testUUID); err != nil || got.String() != "6ba7b8109dad11d180b400c04fd430c8" {
+		t.Errorf("ToTraceID(%v) = %v, %v", testUUID, got, err)
+	}
+	if _, err := ToTraceID(uuid.Nil); err == nil {
+		t.Errorf("ToTraceID(%v) succeeded, want error", uuid.Nil)
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Error("FromContext(context.Background()) = true, want false")
+	}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID(testUUID),
+		SpanID:  trace.SpanID{1, 2, 3, 4, 5, 6, 7, 8},
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	if got, ok := FromContext(ctx); !ok || got != testUUID {
+		t.Errorf("FromContext() = %v, %t, want %v", got, ok, testUUID)
+	}
+}
+
+func TestSpanID(t *testing.T) {
+	tests := []struct {
+		id   trace.SpanID
+		want string
+	}{
+		{trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7}, "00f067aa-0ba9-802b-8700-000000000000"},
+		{trace.SpanID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, "ffffffff-ffff-8fff-8f00-000000000000"},
+		{trace.SpanID{0, 0, 0, 0, 0, 0, 0, 1}, "00000000-0000-8000-8100-000000000000"},
+	}
+	for _, tt := range tests {
+		u := FromSpanID(tt.id)
+		if u.String() != tt.want {
+			t.Errorf("FromSpanID(%v) = %v, want %s", tt.id, u, tt.want)
+		}
+		if u.Version() != 8 || u.Variant() != uuid.VariantRFC9562 {
+			t.Errorf("FromSpanID(%v) has version %d and variant %d, want version 8 of the RFC 9562 variant", tt.id, u.Version(), u.Variant())
+		}
+		got, err := ToSpanID(u)
+		if err != nil || got != tt.id {
+			t.Errorf("ToSpanID(%v) = %v, %v, want %v", u, got, err, tt.id)
+		}
+	}
+
+	for _, u := range []uuid.UUID{
+		testUUID,
+		uuid.Nil,
+		uuid.Must(uuid.FromString("00000000-0000-8000-8000-000000000000")),
+		uuid.Must(uuid.FromString("00f067aa-0ba9-802b-8700-000000000001")),
+		uuid.Must(uuid.FromString("00f067aa-0ba9-802b-8700-010000000000")),
+		uuid.Must(uuid.FromString("00f067aa-0ba9-802b-b700-000000000000")),
+		uuid.Must(uuid.FromString("00f067aa-0ba9-802b-c700-000000000000")),
+	} {
+		if id, err := ToSpanID(u); err == nil {
+			t.Errorf("ToSpanID(%v) = %v, want error", u, id)
+		}
+	}
+}
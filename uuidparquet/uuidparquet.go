@@ -0,0 +1,115 @@
+// Package uuidparquet stores the UUID types of github.com/gofrs/uuid/v5 in
+// columns of Parquet's UUID logical type, a FIXED_LEN_BYTE_ARRAY(16), with
+// github.com/parquet-go/parquet-go. Such columns take 16 bytes per UUID,
+// against 36 for the canonical string form.
+//
+// The uuid.UUID fields of structs written and read by parquet-go use the
+// UUID logical type when tagged with the uuid option. With the optional
+// option, uuid.Nil is written as null, and null is read as uuid.Nil:
+//
+//	type Event struct {
+//		ID     uuid.UUID `parquet:"id,uuid"`
+//		Parent uuid.UUID `parquet:"parent,optional,uuid"`
+//	}
+//
+// The functions of this package convert UUIDs to and from parquet.Value for
+// the lower-level APIs of parquet-go, and read the column statistics of UUID
+// columns. Parquet orders UUIDs by their bytes, as uuid.UUID.Compare does,
+// so the statistics of columns of time-ordered UUIDs, such as version 7
+// UUIDs, give narrow bounds for each page, which readers use to skip pages.
+// Sorting rows by a UUID column, with a parquet.SortingWriter configured by
+// SortingWriterConfig, gives narrow bounds for any version.
+package uuidparquet
+
+import (
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/parquet-go/parquet-go"
+)
+
+// Node returns a required leaf node of the UUID logical type.
+func Node() parquet.Node {
+	return parquet.UUID()
+}
+
+// OptionalNode returns an optional leaf node of the UUID logical type.
+func OptionalNode() parquet.Node {
+	return parquet.Optional(parquet.UUID())
+}
+
+// Value returns u as a FIXED_LEN_BYTE_ARRAY value.
+func Value(u uuid.UUID) parquet.Value {
+	return parquet.FixedLenByteArrayValue(u[:])
+}
+
+// NullValue returns nu.UUID as a FIXED_LEN_BYTE_ARRAY value if nu is valid,
+// and the null value otherwise.
+func NullValue(nu uuid.NullUUID) parquet.Value {
+	if !nu.Valid {
+		return parquet.NullValue()
+	}
+	return Value(nu.UUID)
+}
+
+// FromValue returns the UUID held by v, which is invalid if v is null. It
+// accepts the 16 bytes of a FIXED_LEN_BYTE_ARRAY value, and, to read columns
+// which stored UUIDs as strings, any text form of a UUID in a BYTE_ARRAY
+// value.
+func FromValue(v parquet.Value) (uuid.NullUUID, error) {
+	if v.IsNull() {
+		return uuid.NullUUID{}, nil
+	}
+	var u uuid.UUID
+	var err error
+	switch k := v.Kind(); k {
+	case parquet.FixedLenByteArray:
+		u, err = uuid.FromBytes(v.ByteArray())
+	case parquet.ByteArray:
+		err = u.UnmarshalText(v.ByteArray())
+	default:
+		err = fmt.Errorf("uuidparquet: cannot convert a Parquet %v value to a UUID", k)
+	}
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+	return uuid.NullUUID{UUID: u, Valid: true}, nil
+}
+
+// SortingWriterConfig returns an option making a parquet.SortingWriter sort
+// the rows of each row group in ascending order of the UUID column at the
+// given path, so that the bounds of its pages do not overlap.
+func SortingWriterConfig(path ...string) parquet.WriterOption {
+	return parquet.SortingWriterConfig(parquet.SortingColumns(parquet.Ascending(path...)))
+}
+
+// Bounds returns the minimum and maximum UUIDs of the page at the given index
+// of a UUID column. It returns false if the page holds only nulls or its
+// bounds are not UUIDs.
+func Bounds(idx parquet.ColumnIndex, page int) (lo, hi uuid.UUID, ok bool) {
+	if idx.NullPage(page) {
+		return uuid.Nil, uuid.Nil, false
+	}
+	minValue, errMin := FromValue(idx.MinValue(page))
+	maxValue, errMax := FromValue(idx.MaxValue(page))
+	if errMin != nil || errMax != nil || !minValue.Valid || !maxValue.Valid {
+		return uuid.Nil, uuid.Nil, false
+	}
+	return minValue.UUID, maxValue.UUID, true
+}
+
+// Pages returns the indexes of the pages of a UUID column which may hold u,
+// which are those whose bounds are unknown or include u.
+func Pages(idx parquet.ColumnIndex, u uuid.UUID) []int {
+	var pages []int
+	for i, n := 0, idx.NumPages(); i < n; i++ {
+		if idx.NullPage(i) {
+			continue
+		}
+		lo, hi, ok := Bounds(idx, i)
+		if !ok || (lo.Compare(u) <= 0 && u.Compare(hi) <= 0) {
+			pages = append(pages, i)
+		}
+	}
+	return pages
+}
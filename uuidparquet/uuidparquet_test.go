@@ -0,0 +1,179 @@
+package uuidparquet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+var testUUID = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+func isUUID(lt *format.LogicalType) bool {
+	if lt == nil {
+		return false
+	}
+	_, ok := lt.Value.(*format.UUIDType)
+	return ok
+}
+
+type event struct {
+	ID     uuid.UUID `parquet:"id,uuid"`
+	Parent uuid.UUID `parquet:"parent,optional,uuid"`
+}
+
+func TestStructTags(t *testing.T) {
+	schema := parquet.SchemaOf(event{})
+	for _, name := range []string{"id", "parent"} {
+		col, ok := schema.Lookup(name)
+		if !ok {
+			t.Fatalf("column %q not found in %v", name, schema)
+		}
+		typ := col.Node.Type()
+		if lt := typ.LogicalType(); !isUUID(lt) {
+			t.Errorf("column %q has logical type %v, want UUID", name, lt)
+		}
+		if typ.Kind() != parquet.FixedLenByteArray || typ.Length() != uuid.Size {
+			t.Errorf("column %q has type %v, want FIXED_LEN_BYTE_ARRAY(16)", name, typ)
+		}
+	}
+	if col, _ := schema.Lookup("parent"); !col.Node.Optional() {
+		t.Error("column \"parent\" is not optional")
+	}
+
+	var buf bytes.Buffer
+	in := []event{{ID: testUUID, Parent: testUUID}, {ID: uuid.Must(uuid.NewV7())}}
+	if err := parquet.Write(&buf, in); err != nil {
+		t.Fatal(err)
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx, err := f.RowGroups()[0].ColumnChunks()[1].ColumnIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := idx.NullCount(0); n != 1 {
+		t.Errorf("NullCount(0) of column \"parent\" = %d, want 1", n)
+	}
+	out, err := parquet.Read[event](bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != len(in) {
+		t.Fatalf("Read() returned %d rows, want %d", len(out), len(in))
+	}
+	for i := range in {
+		if out[i] != in[i] {
+			t.Errorf("Read()[%d] = %+v, want %+v", i, out[i], in[i])
+		}
+	}
+}
+
+func TestNodes(t *testing.T) {
+	for _, n := range []parquet.Node{Node(), OptionalNode()} {
+		if lt := n.Type().LogicalType(); !isUUID(lt) {
+			t.Errorf("node %v has logical type %v, want UUID", n, lt)
+		}
+	}
+	if Node().Optional() || !OptionalNode().Optional() {
+		t.Error("Node() and OptionalNode() have the wrong repetition")
+	}
+}
+
+func TestValue(t *testing.T) {
+	tests := []struct {
+		v    parquet.Value
+		want uuid.NullUUID
+	}{
+		{Value(testUUID), uuid.NullUUID{UUID: testUUID, Valid: true}},
+		{NullValue(uuid.NullUUID{UUID: testUUID, Valid: true}), uuid.NullUUID{UUID: testUUID, Valid: true}},
+		{NullValue(uuid.NullUUID{}), uuid.NullUUID{}},
+		{parquet.ByteArrayValue([]byte(testUUID.String())), uuid.NullUUID{UUID: testUUID, Valid: true}},
+		{parquet.ByteArrayValue([]byte("urn:uuid:" + testUUID.String())), uuid.NullUUID{UUID: testUUID, Valid: true}},
+	}
+	for _, tt := range tests {
+		got, err := FromValue(tt.v)
+		if err != nil {
+			t.Errorf("FromValue(%v) unexpected error: %v", tt.v, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("FromValue(%v) = %+v, want %+v", tt.v, got, tt.want)
+		}
+	}
+	if v := Value(testUUID); v.Kind() != parquet.FixedLenByteArray || !bytes.Equal(v.ByteArray(), testUUID[:]) {
+		t.Errorf("Value(%v) = %v, want FIXED_LEN_BYTE_ARRAY %x", testUUID, v, testUUID[:])
+	}
+
+	for _, v := range []parquet.Value{
+		parquet.Int64Value(42),
+		parquet.FixedLenByteArrayValue(testUUID[:8]),
+		parquet.ByteArrayValue([]byte("not-a-uuid")),
+	} {
+		if _, err := FromValue(v); err == nil {
+			t.Errorf("FromValue(%v) succeeded, want error", v)
+		}
+	}
+}
+
+func TestBoundsAndPages(t *testing.T) {
+	type row struct {
+		ID uuid.UUID `parquet:"id,uuid"`
+	}
+	rows := make([]row, 1000)
+	for i := range rows {
+		rows[i].ID = uuid.Must(uuid.NewV4())
+	}
+
+	var buf bytes.Buffer
+	w := parquet.NewSortingWriter[row](&buf, int64(len(rows)), parquet.PageBufferSize(1024), SortingWriterConfig("id"))
+	if _, err := w.Write(rows); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	idx, err := f.RowGroups()[0].ColumnChunks()[0].ColumnIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idx.NumPages() < 2 {
+		t.Fatalf("NumPages() = %d, want several pages", idx.NumPages())
+	}
+
+	var prev uuid.UUID
+	for i := 0; i < idx.NumPages(); i++ {
+		lo, hi, ok := Bounds(idx, i)
+		if !ok {
+			t.Fatalf("Bounds(%d) not found", i)
+		}
+		if lo.Compare(hi) > 0 || (i > 0 && prev.Compare(lo) >= 0) {
+			t.Errorf("Bounds(%d) = %v, %v, overlapping or out of order after %v", i, lo, hi, prev)
+		}
+		prev = hi
+	}
+
+	for _, r := range rows[:10] {
+		pages := Pages(idx, r.ID)
+		if len(pages) != 1 {
+			t.Errorf("Pages(%v) = %v, want a single page", r.ID, pages)
+			continue
+		}
+		lo, hi, _ := Bounds(idx, pages[0])
+		if lo.Compare(r.ID) > 0 || r.ID.Compare(hi) > 0 {
+			t.Errorf("Pages(%v) = %v, whose bounds are %v, %v", r.ID, pages, lo, hi)
+		}
+	}
+	if pages := Pages(idx, uuid.Max); len(pages) != 0 {
+		t.Errorf("Pages(%v) = %v, want none", uuid.Max, pages)
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/gofrs/uuid/v5/uuidpb
+
+go 1.23
+
+require (
+	github.com/gofrs/uuid/v5 v5.0.0
+	google.golang.org/protobuf v1.36.12
+)
+
+replace github.com/gofrs/uuid/v5 => ../
@@ -0,0 +1,76 @@
+package uuidpb
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/gofrs/uuid/v5"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// NewFromMessage returns a name-based UUID of the content of m, so that
+// services computing the ID of an event from the same message agree on it.
+// It is the version 8 UUID of the SHA-256 hash of the namespace UUID and a
+// canonical encoding of m, as computed by uuid.NewV8SHA256.
+//
+// The canonical encoding is the deterministic marshaling of m, with the
+// fields of m and of its nested messages in the order of their numbers.
+// Fields unknown to the schema of m, such as the fields added by a newer
+// version of the schema, are kept: as they are ordered with the known
+// fields, a service with an older version of the schema computes the same
+// UUID as one knowing all the fields of the message.
+func NewFromMessage(ns uuid.UUID, m proto.Message) (uuid.UUID, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(m)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	b, err = canonicalize(b, m.ProtoReflect().Descriptor())
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.NewV8SHA256FromReader(ns, bytes.NewReader(b))
+}
+
+// canonicalize returns the encoded message b of type md, which is nil for
+// messages unknown to the schema, with its fields in the order of their
+// numbers. The message fields known to md are canonicalized recursively.
+func canonicalize(b []byte, md protoreflect.MessageDescriptor) ([]byte, error) {
+	type field struct {
+		num protoreflect.FieldNumber
+		raw []byte
+	}
+	var fields []field
+	size := len(b)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		m := protowire.ConsumeFieldValue(num, typ, b[n:])
+		if m < 0 {
+			return nil, protowire.ParseError(m)
+		}
+		raw := b[:n+m]
+		if typ == protowire.BytesType && md != nil {
+			if fd := md.Fields().ByNumber(num); fd != nil && fd.Message() != nil {
+				v, _ := protowire.ConsumeBytes(b[n:])
+				nested, err := canonicalize(v, fd.Message())
+				if err != nil {
+					return nil, err
+				}
+				raw = protowire.AppendBytes(protowire.AppendTag(nil, num, typ), nested)
+			}
+		}
+		fields = append(fields, field{num, raw})
+		b = b[n+m:]
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool { return fields[i].num < fields[j].num })
+	out := make([]byte, 0, size)
+	for _, f := range fields {
+		out = append(out, f.raw...)
+	}
+	return out, nil
+}
@@ -0,0 +1,99 @@
+package uuidpb
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestNewFromMessage(t *testing.T) {
+	ns := uuid.NamespaceURL
+	ts := &timestamppb.Timestamp{Seconds: 1700000000, Nanos: 123}
+	u, err := NewFromMessage(ns, ts)
+	if err != nil {
+		t.Fatalf("NewFromMessage(%v) unexpected error: %v", ts, err)
+	}
+	data, err := proto.Marshal(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := uuid.NewV8SHA256(ns, string(data)); u != want {
+		t.Errorf("NewFromMessage(%v) = %v, want %v", ts, u, want)
+	}
+	if got, err := NewFromMessage(uuid.NamespaceDNS, ts); err != nil || got == u {
+		t.Errorf("NewFromMessage returned %v, %v for different namespaces", got, err)
+	}
+	if got, err := NewFromMessage(ns, &timestamppb.Timestamp{Seconds: 1700000000}); err != nil || got == u {
+		t.Errorf("NewFromMessage returned %v, %v for different messages", got, err)
+	}
+
+	t.Run("Map", func(t *testing.T) {
+		a, err := structpb.NewStruct(map[string]interface{}{"a": 1, "b": "x", "c": true, "d": []interface{}{1, "y"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := NewFromMessage(ns, a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 10; i++ {
+			if got, err := NewFromMessage(ns, proto.Clone(a)); err != nil || got != want {
+				t.Fatalf("NewFromMessage(%v) = %v, %v, want %v", a, got, err, want)
+			}
+		}
+	})
+
+	t.Run("UnknownFields", func(t *testing.T) {
+		// The seconds of ts unknown to an older schema, and marshaled after
+		// its known nanos.
+		older := &timestamppb.Timestamp{Nanos: ts.Nanos}
+		older.ProtoReflect().SetUnknown(protowire.AppendVarint(protowire.AppendTag(nil, 1, protowire.VarintType), uint64(ts.Seconds)))
+		if got, err := NewFromMessage(ns, older); err != nil || got != u {
+			t.Errorf("NewFromMessage(%v) = %v, %v, want %v", older, got, err, u)
+		}
+		// All the fields of ts unknown, in reverse order.
+		empty := &emptypb.Empty{}
+		var raw []byte
+		raw = protowire.AppendVarint(protowire.AppendTag(raw, 2, protowire.VarintType), uint64(ts.Nanos))
+		raw = protowire.AppendVarint(protowire.AppendTag(raw, 1, protowire.VarintType), uint64(ts.Seconds))
+		empty.ProtoReflect().SetUnknown(raw)
+		if got, err := NewFromMessage(ns, empty); err != nil || got != u {
+			t.Errorf("NewFromMessage(%v) = %v, %v, want %v", empty, got, err, u)
+		}
+	})
+
+	t.Run("NestedUnknownFields", func(t *testing.T) {
+		str := protowire.AppendString(protowire.AppendTag(nil, 3, protowire.BytesType), "x")
+		boolean := protowire.AppendVarint(protowire.AppendTag(nil, 4, protowire.VarintType), 1)
+
+		// A value of a list holding a known bool, marshaled before the
+		// unknown string.
+		known := structpb.NewBoolValue(true)
+		known.ProtoReflect().SetUnknown(str)
+		a := structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{known}})
+
+		// The same value with both fields unknown, in the order of their
+		// numbers.
+		unknown := &structpb.Value{}
+		unknown.ProtoReflect().SetUnknown(append(append([]byte(nil), str...), boolean...))
+		b := structpb.NewListValue(&structpb.ListValue{Values: []*structpb.Value{unknown}})
+
+		da, _ := proto.Marshal(a)
+		db, _ := proto.Marshal(b)
+		if string(da) == string(db) {
+			t.Fatalf("proto.Marshal(%v) = proto.Marshal(%v) = %x", a, b, da)
+		}
+		ua, err := NewFromMessage(ns, a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ub, err := NewFromMessage(ns, b); err != nil || ub != ua {
+			t.Errorf("NewFromMessage(%v) = %v, %v, want %v", b, ub, err, ua)
+		}
+	})
+}
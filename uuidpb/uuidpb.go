@@ -0,0 +1,86 @@
+// Package uuidpb defines the gofrs.uuid.v1.UUID protocol buffers message,
+// which holds a UUID in a single bytes field, and converts it to and from
+// the UUID types of github.com/gofrs/uuid/v5.
+//
+// Services exchanging UUIDs should import uuid.proto, next to this file, and
+// use the message for UUID fields:
+//
+//	import "uuidpb/uuid.proto";
+//
+//	message Order {
+//	  gofrs.uuid.v1.UUID id = 1;
+//	  gofrs.uuid.v1.UUID parent_id = 2;
+//	}
+//
+// An absent message, or one with an empty value, is the absence of a UUID,
+// so that optional UUID fields need no wrapper; any length other than 0 or
+// 16 bytes is invalid.
+//
+// NewFromMessage derives a name-based UUID from the content of any message.
+package uuidpb
+
+//go:generate protoc -I.. --go_out=.. --go_opt=paths=source_relative uuidpb/uuid.proto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// ErrMissing is returned when a UUID is required, but the message is absent
+// or its value is empty.
+var ErrMissing = errors.New("uuidpb: missing UUID")
+
+// ToProto returns a message holding u.
+func ToProto(u uuid.UUID) *UUID {
+	return &UUID{Value: u.Bytes()}
+}
+
+// FromProto returns the UUID held by x. It returns ErrMissing if x is nil or
+// its value is empty, and an error if its value is not 16 bytes long.
+func FromProto(x *UUID) (uuid.UUID, error) {
+	if err := x.Validate(); err != nil {
+		return uuid.Nil, err
+	}
+	var u uuid.UUID
+	copy(u[:], x.Value)
+	return u, nil
+}
+
+// NullToProto returns a message holding nu.UUID if nu is valid, and nil
+// otherwise.
+func NullToProto(nu uuid.NullUUID) *UUID {
+	if !nu.Valid {
+		return nil
+	}
+	return ToProto(nu.UUID)
+}
+
+// NullFromProto returns the UUID held by x, which is invalid if x is nil or
+// its value is empty. It returns an error if the value of x is neither empty
+// nor 16 bytes long.
+func NullFromProto(x *UUID) (uuid.NullUUID, error) {
+	u, err := FromProto(x)
+	if err != nil {
+		if errors.Is(err, ErrMissing) {
+			err = nil
+		}
+		return uuid.NullUUID{}, err
+	}
+	return uuid.NullUUID{UUID: u, Valid: true}, nil
+}
+
+// Validate returns nil if x holds a UUID. It returns ErrMissing if x is nil
+// or its value is empty, and an error wrapping uuid.ErrIncorrectByteLength
+// if its value has another length than 16 bytes.
+func (x *UUID) Validate() error {
+	switch n := len(x.GetValue()); n {
+	case 0:
+		return ErrMissing
+	case uuid.Size:
+		return nil
+	default:
+		return fmt.Errorf("%w, got %d bytes", uuid.ErrIncorrectByteLength, n)
+	}
+}
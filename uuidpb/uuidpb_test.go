@@ -0,0 +1,108 @@
+package uuidpb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var testUUID = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+func TestToProto(t *testing.T) {
+	x := ToProto(testUUID)
+	if !bytes.Equal(x.GetValue(), testUUID[:]) {
+		t.Errorf("ToProto(%v).Value = %x, want %x", testUUID, x.GetValue(), testUUID[:])
+	}
+	if x := ToProto(uuid.Nil); len(x.GetValue()) != uuid.Size {
+		t.Errorf("ToProto(%v).Value has length %d, want %d", uuid.Nil, len(x.GetValue()), uuid.Size)
+	}
+
+	data, err := proto.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := append([]byte{0x0a, uuid.Size}, testUUID[:]...); !bytes.Equal(data, want) {
+		t.Errorf("proto.Marshal(ToProto(%v)) = %x, want %x", testUUID, data, want)
+	}
+	var out UUID
+	if err := proto.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := FromProto(&out); err != nil || got != testUUID {
+		t.Errorf("FromProto(Unmarshal(Marshal(%v))) = %v, %v", testUUID, got, err)
+	}
+
+	js, err := protojson.Marshal(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields struct{ Value []byte }
+	if err := json.Unmarshal(js, &fields); err != nil || !bytes.Equal(fields.Value, testUUID[:]) {
+		t.Errorf("protojson.Marshal(ToProto(%v)) = %s, want base64 value %x", testUUID, js, testUUID[:])
+	}
+}
+
+func TestFromProto(t *testing.T) {
+	tests := []struct {
+		x       *UUID
+		want    uuid.UUID
+		wantErr error
+	}{
+		{ToProto(testUUID), testUUID, nil},
+		{&UUID{Value: make([]byte, uuid.Size)}, uuid.Nil, nil},
+		{nil, uuid.Nil, ErrMissing},
+		{&UUID{}, uuid.Nil, ErrMissing},
+		{&UUID{Value: testUUID[:8]}, uuid.Nil, uuid.ErrIncorrectByteLength},
+		{&UUID{Value: []byte(testUUID.String())}, uuid.Nil, uuid.ErrIncorrectByteLength},
+	}
+	for _, tt := range tests {
+		got, err := FromProto(tt.x)
+		if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+			t.Errorf("FromProto(%v) error = %v, want %v", tt.x, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("FromProto(%v) = %v, want %v", tt.x, got, tt.want)
+		}
+		if err := tt.x.Validate(); !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+			t.Errorf("%v.Validate() = %v, want %v", tt.x, err, tt.wantErr)
+		}
+	}
+}
+
+func TestNull(t *testing.T) {
+	if x := NullToProto(uuid.NullUUID{}); x != nil {
+		t.Errorf("NullToProto(invalid) = %v, want nil", x)
+	}
+	valid := uuid.NullUUID{UUID: testUUID, Valid: true}
+	x := NullToProto(valid)
+	if !bytes.Equal(x.GetValue(), testUUID[:]) {
+		t.Errorf("NullToProto(%v).Value = %x, want %x", valid, x.GetValue(), testUUID[:])
+	}
+
+	tests := []struct {
+		x    *UUID
+		want uuid.NullUUID
+	}{
+		{x, valid},
+		{nil, uuid.NullUUID{}},
+		{&UUID{}, uuid.NullUUID{}},
+	}
+	for _, tt := range tests {
+		got, err := NullFromProto(tt.x)
+		if err != nil {
+			t.Errorf("NullFromProto(%v) unexpected error: %v", tt.x, err)
+		}
+		if got != tt.want {
+			t.Errorf("NullFromProto(%v) = %+v, want %+v", tt.x, got, tt.want)
+		}
+	}
+
+	if _, err := NullFromProto(&UUID{Value: testUUID[:8]}); !errors.Is(err, uuid.ErrIncorrectByteLength) {
+		t.Errorf("NullFromProto(8 bytes) error = %v, want %v", err, uuid.ErrIncorrectByteLength)
+	}
+}
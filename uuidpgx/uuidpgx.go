@@ -0,0 +1,161 @@
+// Package uuidpgx registers the UUID types of github.com/gofrs/uuid/v5 with
+// pgx, so that uuid.UUID and uuid.NullUUID values are encoded and decoded
+// directly with PostgreSQL's uuid wire formats, rather than through their
+// database/sql driver.Valuer and sql.Scanner implementations.
+//
+// Register the types on every new connection, for example from a
+// pgxpool.Config's AfterConnect hook:
+//
+//	cfg.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		uuidpgx.RegisterTypes(conn)
+//		return nil
+//	}
+package uuidpgx
+
+import (
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// UUID is a uuid.UUID implementing pgtype.UUIDScanner and
+// pgtype.UUIDValuer.
+type UUID uuid.UUID
+
+// ScanUUID implements the pgtype.UUIDScanner interface. It will return an
+// error if v is NULL; scan into a NullUUID for nullable columns.
+func (u *UUID) ScanUUID(v pgtype.UUID) error {
+	if !v.Valid {
+		return fmt.Errorf("uuidpgx: cannot scan NULL into *uuid.UUID")
+	}
+	*u = v.Bytes
+	return nil
+}
+
+// UUIDValue implements the pgtype.UUIDValuer interface.
+func (u UUID) UUIDValue() (pgtype.UUID, error) {
+	return pgtype.UUID{Bytes: u, Valid: true}, nil
+}
+
+// NullUUID is a uuid.NullUUID implementing pgtype.UUIDScanner and
+// pgtype.UUIDValuer.
+type NullUUID uuid.NullUUID
+
+// ScanUUID implements the pgtype.UUIDScanner interface.
+func (u *NullUUID) ScanUUID(v pgtype.UUID) error {
+	*u = NullUUID{UUID: v.Bytes, Valid: v.Valid}
+	return nil
+}
+
+// UUIDValue implements the pgtype.UUIDValuer interface.
+func (u NullUUID) UUIDValue() (pgtype.UUID, error) {
+	return pgtype.UUID{Bytes: u.UUID, Valid: u.Valid}, nil
+}
+
+// TryWrapEncodePlan is a pgtype.TryWrapEncodePlanFunc encoding uuid.UUID and
+// uuid.NullUUID values as UUID and NullUUID.
+func TryWrapEncodePlan(value any) (plan pgtype.WrappedEncodePlanNextSetter, nextValue any, ok bool) {
+	switch value := value.(type) {
+	case uuid.UUID:
+		return &wrapUUIDEncodePlan{}, UUID(value), true
+	case uuid.NullUUID:
+		return &wrapNullUUIDEncodePlan{}, NullUUID(value), true
+	}
+	return nil, nil, false
+}
+
+type wrapUUIDEncodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (plan *wrapUUIDEncodePlan) SetNext(next pgtype.EncodePlan) { plan.next = next }
+
+func (plan *wrapUUIDEncodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	return plan.next.Encode(UUID(value.(uuid.UUID)), buf)
+}
+
+type wrapNullUUIDEncodePlan struct {
+	next pgtype.EncodePlan
+}
+
+func (plan *wrapNullUUIDEncodePlan) SetNext(next pgtype.EncodePlan) { plan.next = next }
+
+func (plan *wrapNullUUIDEncodePlan) Encode(value any, buf []byte) ([]byte, error) {
+	return plan.next.Encode(NullUUID(value.(uuid.NullUUID)), buf)
+}
+
+type wrapUUIDScanPlan struct {
+	next pgtype.ScanPlan
+}
+
+func (plan *wrapUUIDScanPlan) SetNext(next pgtype.ScanPlan) { plan.next = next }
+
+func (plan *wrapUUIDScanPlan) Scan(src []byte, dst any) error {
+	return plan.next.Scan(src, (*UUID)(dst.(*uuid.UUID)))
+}
+
+type wrapNullUUIDScanPlan struct {
+	next pgtype.ScanPlan
+}
+
+func (plan *wrapNullUUIDScanPlan) SetNext(next pgtype.ScanPlan) { plan.next = next }
+
+func (plan *wrapNullUUIDScanPlan) Scan(src []byte, dst any) error {
+	return plan.next.Scan(src, (*NullUUID)(dst.(*uuid.NullUUID)))
+}
+
+// Codec is the pgtype.UUIDCodec, extended to scan directly into *uuid.UUID
+// and *uuid.NullUUID targets. Without it, pgx scans these through their
+// sql.Scanner implementations, which round-trips every value through its
+// text form. DecodeValue returns uuid.UUID values rather than [16]byte, for
+// example from pgx.Rows.Values.
+type Codec struct {
+	pgtype.UUIDCodec
+}
+
+// PlanScan implements the pgtype.Codec interface.
+func (c Codec) PlanScan(m *pgtype.Map, oid uint32, format int16, target any) pgtype.ScanPlan {
+	var wrapper pgtype.WrappedScanPlanNextSetter
+	var next any
+	switch target.(type) {
+	case *uuid.UUID:
+		wrapper, next = &wrapUUIDScanPlan{}, (*UUID)(nil)
+	case *uuid.NullUUID:
+		wrapper, next = &wrapNullUUIDScanPlan{}, (*NullUUID)(nil)
+	default:
+		return c.UUIDCodec.PlanScan(m, oid, format, target)
+	}
+	plan := c.UUIDCodec.PlanScan(m, oid, format, next)
+	if plan == nil {
+		return nil
+	}
+	wrapper.SetNext(plan)
+	return wrapper
+}
+
+// DecodeValue implements the pgtype.Codec interface.
+func (Codec) DecodeValue(m *pgtype.Map, oid uint32, format int16, src []byte) (any, error) {
+	if src == nil {
+		return nil, nil
+	}
+	var u uuid.UUID
+	if err := m.Scan(oid, format, src, &u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// Register registers the UUID types with m.
+func Register(m *pgtype.Map) {
+	m.TryWrapEncodePlanFuncs = append([]pgtype.TryWrapEncodePlanFunc{TryWrapEncodePlan}, m.TryWrapEncodePlanFuncs...)
+	m.RegisterType(&pgtype.Type{Name: "uuid", OID: pgtype.UUIDOID, Codec: Codec{}})
+}
+
+// RegisterTypes registers the UUID types with conn's type map. It must be
+// called on each connection, before the connection is used to send or
+// receive UUIDs.
+func RegisterTypes(conn *pgx.Conn) {
+	Register(conn.TypeMap())
+}
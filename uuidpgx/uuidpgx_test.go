@@ -0,0 +1,125 @@
+package uuidpgx
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var testUUID = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+func newMap() *pgtype.Map {
+	m := pgtype.NewMap()
+	Register(m)
+	return m
+}
+
+func TestEncode(t *testing.T) {
+	m := newMap()
+	tests := []struct {
+		name   string
+		format int16
+		value  any
+		want   []byte
+	}{
+		{"BinaryUUID", pgtype.BinaryFormatCode, testUUID, testUUID.Bytes()},
+		{"TextUUID", pgtype.TextFormatCode, testUUID, []byte(testUUID.String())},
+		{"BinaryNullUUID", pgtype.BinaryFormatCode, uuid.NullUUID{UUID: testUUID, Valid: true}, testUUID.Bytes()},
+		{"BinaryNull", pgtype.BinaryFormatCode, uuid.NullUUID{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := m.Encode(pgtype.UUIDOID, tt.format, tt.value, nil)
+			if err != nil {
+				t.Fatalf("Encode(%v) unexpected error: %v", tt.value, err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("Encode(%v) = %x, want %x", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScan(t *testing.T) {
+	m := newMap()
+	t.Run("UUID", func(t *testing.T) {
+		for _, format := range []int16{pgtype.BinaryFormatCode, pgtype.TextFormatCode} {
+			src := testUUID.Bytes()
+			if format == pgtype.TextFormatCode {
+				src = []byte(testUUID.String())
+			}
+			var got uuid.UUID
+			if err := m.Scan(pgtype.UUIDOID, format, src, &got); err != nil {
+				t.Fatalf("Scan(%q) unexpected error: %v", src, err)
+			}
+			if got != testUUID {
+				t.Errorf("Scan(%q) = %v, want %v", src, got, testUUID)
+			}
+		}
+	})
+	t.Run("NullUUID", func(t *testing.T) {
+		got := uuid.NullUUID{UUID: testUUID, Valid: true}
+		if err := m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, nil, &got); err != nil {
+			t.Fatalf("Scan(NULL) unexpected error: %v", err)
+		}
+		if got.Valid || got.UUID != uuid.Nil {
+			t.Errorf("Scan(NULL) = %#v, want the zero NullUUID", got)
+		}
+		if err := m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, testUUID.Bytes(), &got); err != nil {
+			t.Fatalf("Scan(%x) unexpected error: %v", testUUID.Bytes(), err)
+		}
+		if want := (uuid.NullUUID{UUID: testUUID, Valid: true}); got != want {
+			t.Errorf("Scan(%x) = %#v, want %#v", testUUID.Bytes(), got, want)
+		}
+	})
+	t.Run("NullIntoUUID", func(t *testing.T) {
+		var got uuid.UUID
+		if err := m.Scan(pgtype.UUIDOID, pgtype.BinaryFormatCode, nil, &got); err == nil {
+			t.Error("Scan(NULL) into *uuid.UUID succeeded, want error")
+		}
+	})
+	t.Run("Allocs", func(t *testing.T) {
+		// Rows cache their scan plans, so only the plan's Scan is measured.
+		src := testUUID.Bytes()
+		var got uuid.UUID
+		plan := m.PlanScan(pgtype.UUIDOID, pgtype.BinaryFormatCode, &got)
+		allocs := testing.AllocsPerRun(100, func() {
+			plan.Scan(src, &got)
+		})
+		if allocs > 0 {
+			t.Errorf("Scan allocated %v times, want 0", allocs)
+		}
+	})
+}
+
+func TestDecodeValue(t *testing.T) {
+	m := newMap()
+	typ, ok := m.TypeForOID(pgtype.UUIDOID)
+	if !ok {
+		t.Fatal("uuid type is not registered")
+	}
+	got, err := typ.Codec.DecodeValue(m, pgtype.UUIDOID, pgtype.BinaryFormatCode, testUUID.Bytes())
+	if err != nil {
+		t.Fatalf("DecodeValue() unexpected error: %v", err)
+	}
+	if got != testUUID {
+		t.Errorf("DecodeValue() = %#v, want %v", got, testUUID)
+	}
+	got, err = typ.Codec.DecodeValue(m, pgtype.UUIDOID, pgtype.BinaryFormatCode, nil)
+	if err != nil || got != nil {
+		t.Errorf("DecodeValue(NULL) = %v, %v, want <nil>, <nil>", got, err)
+	}
+}
+
+func BenchmarkScan(b *testing.B) {
+	m := newMap()
+	src := testUUID.Bytes()
+	var u uuid.UUID
+	plan := m.PlanScan(pgtype.UUIDOID, pgtype.BinaryFormatCode, &u)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		plan.Scan(src, &u)
+	}
+}
@@ -0,0 +1,10 @@
+module github.com/gofrs/uuid/v5/uuidrapid
+
+go 1.25.0
+
+require (
+	github.com/gofrs/uuid/v5 v5.0.0
+	pgregory.net/rapid v1.3.0
+)
+
+replace github.com/gofrs/uuid/v5 => ../
@@ -0,0 +1,53 @@
+// Package uuidrapid provides generators of UUIDs for the property-based
+// tests of pgregory.net/rapid, built on the generators of
+// github.com/gofrs/uuid/v5/uuidtest for testing/quick:
+//
+//	rapid.Check(t, func(t *rapid.T) {
+//		u := uuidrapid.UUID(uuid.V7).Draw(t, "id")
+//		...
+//	})
+//
+// The generators draw their UUIDs from rapid's own generators, so that rapid
+// shrinks failing UUIDs towards the smallest UUID of the first version given,
+// and failing near-miss strings towards the first near-miss of that UUID.
+package uuidrapid
+
+import (
+	"github.com/gofrs/uuid/v5"
+	"github.com/gofrs/uuid/v5/uuidtest"
+	"pgregory.net/rapid"
+)
+
+// UUID returns a generator of UUIDs of the RFC 9562 variant and one of the
+// given versions, or of uuidtest.Versions if none is given.
+func UUID(versions ...byte) *rapid.Generator[uuid.UUID] {
+	if len(versions) == 0 {
+		versions = uuidtest.Versions
+	}
+	versions = append([]byte(nil), versions...)
+	return rapid.Custom(func(t *rapid.T) uuid.UUID {
+		version := rapid.SampledFrom(versions).Draw(t, "version")
+		var u uuid.UUID
+		copy(u[:], rapid.SliceOfN(rapid.Byte(), uuid.Size, uuid.Size).Draw(t, "bytes"))
+		return uuidtest.WithVersion(u, version)
+	})
+}
+
+// EdgeCase returns a generator of the UUIDs of uuidtest.EdgeCases.
+func EdgeCase() *rapid.Generator[uuid.UUID] {
+	return rapid.SampledFrom(uuidtest.EdgeCases())
+}
+
+// Any returns a generator of the UUIDs of UUID and EdgeCase.
+func Any() *rapid.Generator[uuid.UUID] {
+	return rapid.OneOf(UUID(), EdgeCase())
+}
+
+// NearMiss returns a generator of the strings of uuidtest.NearMisses of the
+// UUIDs of UUID, which are not valid UUIDs.
+func NearMiss() *rapid.Generator[string] {
+	return rapid.Custom(func(t *rapid.T) string {
+		misses := uuidtest.NearMisses(UUID().Draw(t, "uuid"))
+		return misses[rapid.IntRange(0, len(misses)-1).Draw(t, "miss")]
+	})
+}
@@ -0,0 +1,49 @@
+package uuidrapid
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"pgregory.net/rapid"
+)
+
+func TestUUID(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		u := UUID().Draw(t, "u")
+		if u.Variant() != uuid.VariantRFC9562 || u.Version() < 1 || u.Version() > 8 || u.Version() == 2 {
+			t.Fatalf("UUID() generated %v, of variant %d and version %d", u, u.Variant(), u.Version())
+		}
+	})
+	rapid.Check(t, func(t *rapid.T) {
+		if u := UUID(uuid.V4, uuid.V7).Draw(t, "u"); u.Version() != uuid.V4 && u.Version() != uuid.V7 {
+			t.Fatalf("UUID(4, 7) generated the version %d UUID %v", u.Version(), u)
+		}
+	})
+}
+
+func TestEdgeCase(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		u := EdgeCase().Draw(t, "u")
+		if u != uuid.Nil && u != uuid.Max && u.Variant() != uuid.VariantRFC9562 {
+			t.Fatalf("EdgeCase() generated %v", u)
+		}
+	})
+}
+
+func TestAny(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		u := Any().Draw(t, "u")
+		if u != uuid.Nil && u != uuid.Max && u.Variant() != uuid.VariantRFC9562 {
+			t.Fatalf("Any() generated %v", u)
+		}
+	})
+}
+
+func TestNearMiss(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := NearMiss().Draw(t, "s")
+		if _, err := uuid.FromString(s); err == nil {
+			t.Fatalf("NearMiss() generated the valid UUID %q", s)
+		}
+	})
+}
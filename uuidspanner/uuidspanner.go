@@ -0,0 +1,175 @@
+// Package uuidspanner stores the UUID types of github.com/gofrs/uuid/v5 in
+// Cloud Spanner STRING(36) or BYTES(16) columns with the Go client,
+// cloud.google.com/go/spanner.
+//
+// The String, Bytes, NullString and NullBytes types implement the
+// spanner.Encoder and spanner.Decoder interfaces, and can be used in
+// mutations, as query parameters and as row column destinations, choosing
+// the column representation per field:
+//
+//	type Event struct {
+//		ID     uuidspanner.Bytes     `spanner:"Id"`     // BYTES(16) NOT NULL
+//		Parent uuidspanner.NullBytes `spanner:"Parent"` // BYTES(16)
+//	}
+//
+// The interfaces are satisfied structurally, so this package does not
+// import the Spanner client. Whatever the type, decoding accepts both the
+// STRING and the BYTES representation, which the client passes to the
+// decoder as a base64 string, so that a column can be migrated from one to
+// the other without changing the code reading it.
+package uuidspanner
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// EncodeString returns u in the canonical form, for a STRING(36) column.
+func EncodeString(u uuid.UUID) string {
+	return u.String()
+}
+
+// EncodeBytes returns the 16 bytes of u, for a BYTES(16) column.
+func EncodeBytes(u uuid.UUID) []byte {
+	return u.Bytes()
+}
+
+// Decode returns the UUID held by a value decoded by the Spanner client from
+// a STRING or BYTES column, which is invalid if the value is nil. The client
+// passes the values of both columns to spanner.Decoder as strings, BYTES
+// encoded in base64, and NULL as a nil *string: a string may hold any text
+// form of a UUID or the 24-character base64 encoding of its 16 bytes. A
+// []byte, as returned by other Spanner APIs, may hold either the 16 bytes of
+// a UUID or a text form.
+func Decode(v interface{}) (uuid.NullUUID, error) {
+	var u uuid.UUID
+	var err error
+	switch v := v.(type) {
+	case nil:
+		return uuid.NullUUID{}, nil
+	case string:
+		err = decodeString(&u, v)
+	case *string:
+		if v == nil {
+			return uuid.NullUUID{}, nil
+		}
+		err = decodeString(&u, *v)
+	case []byte:
+		if v == nil {
+			return uuid.NullUUID{}, nil
+		}
+		if len(v) == uuid.Size {
+			err = u.UnmarshalBinary(v)
+		} else {
+			err = u.UnmarshalText(v)
+		}
+	default:
+		err = fmt.Errorf("%w %T to UUID", uuid.ErrTypeConvertError, v)
+	}
+	if err != nil {
+		return uuid.NullUUID{}, err
+	}
+	return uuid.NullUUID{UUID: u, Valid: true}, nil
+}
+
+// base64Size is the length of the base64 encoding of the 16 bytes of a UUID,
+// which is not the length of any text form.
+var base64Size = base64.StdEncoding.EncodedLen(uuid.Size)
+
+// decodeString decodes s, a text form of a UUID or the base64 encoding of a
+// BYTES column, into u.
+func decodeString(u *uuid.UUID, s string) error {
+	if len(s) != base64Size {
+		return u.UnmarshalText([]byte(s))
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("%w: %q is neither a UUID nor base64: %v", uuid.ErrInvalidFormat, s, err)
+	}
+	return u.UnmarshalBinary(b)
+}
+
+// String is a uuid.UUID stored in a STRING(36) column.
+type String uuid.UUID
+
+// EncodeSpanner implements the spanner.Encoder interface.
+func (u String) EncodeSpanner() (interface{}, error) {
+	return EncodeString(uuid.UUID(u)), nil
+}
+
+// DecodeSpanner implements the spanner.Decoder interface. NULL is decoded as
+// uuid.Nil.
+func (u *String) DecodeSpanner(input interface{}) error {
+	nu, err := Decode(input)
+	if err != nil {
+		return err
+	}
+	*u = String(nu.UUID)
+	return nil
+}
+
+// Bytes is a uuid.UUID stored in a BYTES(16) column.
+type Bytes uuid.UUID
+
+// EncodeSpanner implements the spanner.Encoder interface.
+func (u Bytes) EncodeSpanner() (interface{}, error) {
+	return EncodeBytes(uuid.UUID(u)), nil
+}
+
+// DecodeSpanner implements the spanner.Decoder interface. NULL is decoded as
+// uuid.Nil.
+func (u *Bytes) DecodeSpanner(input interface{}) error {
+	nu, err := Decode(input)
+	if err != nil {
+		return err
+	}
+	*u = Bytes(nu.UUID)
+	return nil
+}
+
+// NullString is a uuid.NullUUID stored in a nullable STRING(36) column. An
+// invalid NullString is stored as NULL.
+type NullString uuid.NullUUID
+
+// EncodeSpanner implements the spanner.Encoder interface.
+func (nu NullString) EncodeSpanner() (interface{}, error) {
+	if !nu.Valid {
+		return (*string)(nil), nil
+	}
+	s := EncodeString(nu.UUID)
+	return &s, nil
+}
+
+// DecodeSpanner implements the spanner.Decoder interface.
+func (nu *NullString) DecodeSpanner(input interface{}) error {
+	v, err := Decode(input)
+	if err != nil {
+		return err
+	}
+	*nu = NullString(v)
+	return nil
+}
+
+// NullBytes is a uuid.NullUUID stored in a nullable BYTES(16) column. An
+// invalid NullBytes is stored as NULL.
+type NullBytes uuid.NullUUID
+
+// EncodeSpanner implements the spanner.Encoder interface.
+func (nu NullBytes) EncodeSpanner() (interface{}, error) {
+	if !nu.Valid {
+		return []byte(nil), nil
+	}
+	return EncodeBytes(nu.UUID), nil
+}
+
+// DecodeSpanner implements the spanner.Decoder interface.
+func (nu *NullBytes) DecodeSpanner(input interface{}) error {
+	v, err := Decode(input)
+	if err != nil {
+		return err
+	}
+	*nu = NullBytes(v)
+	return nil
+}
@@ -0,0 +1,160 @@
+package uuidspanner
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+var testUUID = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+// encoder and decoder are the spanner.Encoder and spanner.Decoder
+// interfaces.
+type encoder interface {
+	EncodeSpanner() (interface{}, error)
+}
+
+type decoder interface {
+	DecodeSpanner(input interface{}) error
+}
+
+var (
+	_ encoder = String{}
+	_ encoder = Bytes{}
+	_ encoder = NullString{}
+	_ encoder = NullBytes{}
+	_ decoder = (*String)(nil)
+	_ decoder = (*Bytes)(nil)
+	_ decoder = (*NullString)(nil)
+	_ decoder = (*NullBytes)(nil)
+)
+
+func TestDecode(t *testing.T) {
+	s := testUUID.String()
+	// The Spanner client passes BYTES values to decoders in base64.
+	b64 := base64.StdEncoding.EncodeToString(testUUID.Bytes())
+	valid := uuid.NullUUID{UUID: testUUID, Valid: true}
+	tests := []struct {
+		in   interface{}
+		want uuid.NullUUID
+	}{
+		{s, valid},
+		{"{" + s + "}", valid},
+		{&s, valid},
+		{b64, valid},
+		{&b64, valid},
+		{testUUID.Bytes(), valid},
+		{[]byte(s), valid},
+		{nil, uuid.NullUUID{}},
+		{(*string)(nil), uuid.NullUUID{}},
+		{[]byte(nil), uuid.NullUUID{}},
+	}
+	for _, tt := range tests {
+		got, err := Decode(tt.in)
+		if err != nil {
+			t.Errorf("Decode(%#v) unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Decode(%#v) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []interface{}{"not-a-uuid", "not-base64-but-24-chars!", testUUID[:8], int64(42)} {
+		if _, err := Decode(in); err == nil {
+			t.Errorf("Decode(%#v) succeeded, want error", in)
+		}
+	}
+	if _, err := Decode(int64(42)); !errors.Is(err, uuid.ErrTypeConvertError) {
+		t.Errorf("Decode(int64) error = %v, want %v", err, uuid.ErrTypeConvertError)
+	}
+}
+
+func TestEncode(t *testing.T) {
+	if got := EncodeString(testUUID); got != testUUID.String() {
+		t.Errorf("EncodeString(%v) = %q", testUUID, got)
+	}
+	if got := EncodeBytes(testUUID); !bytes.Equal(got, testUUID[:]) {
+		t.Errorf("EncodeBytes(%v) = %x", testUUID, got)
+	}
+
+	valid := uuid.NullUUID{UUID: testUUID, Valid: true}
+	tests := []struct {
+		enc  encoder
+		want interface{}
+	}{
+		{String(testUUID), testUUID.String()},
+		{Bytes(testUUID), testUUID.Bytes()},
+		{NullString(valid), testUUID.String()},
+		{NullBytes(valid), testUUID.Bytes()},
+	}
+	for _, tt := range tests {
+		got, err := tt.enc.EncodeSpanner()
+		if err != nil {
+			t.Fatalf("%T.EncodeSpanner() unexpected error: %v", tt.enc, err)
+		}
+		if p, ok := got.(*string); ok {
+			got = *p
+		}
+		switch want := tt.want.(type) {
+		case string:
+			if got != want {
+				t.Errorf("%T.EncodeSpanner() = %#v, want %q", tt.enc, got, want)
+			}
+		case []byte:
+			if b, ok := got.([]byte); !ok || !bytes.Equal(b, want) {
+				t.Errorf("%T.EncodeSpanner() = %#v, want %x", tt.enc, got, want)
+			}
+		}
+	}
+
+	if got, err := (NullString{}).EncodeSpanner(); err != nil || got.(*string) != nil {
+		t.Errorf("NullString{}.EncodeSpanner() = %#v, %v, want a nil *string", got, err)
+	}
+	if got, err := (NullBytes{}).EncodeSpanner(); err != nil || got.([]byte) != nil {
+		t.Errorf("NullBytes{}.EncodeSpanner() = %#v, %v, want a nil []byte", got, err)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	// Each type decodes both representations, and BYTES as passed by the
+	// Spanner client, in base64.
+	inputs := []interface{}{EncodeString(testUUID), EncodeBytes(testUUID), base64.StdEncoding.EncodeToString(EncodeBytes(testUUID))}
+	for _, in := range inputs {
+		var s String
+		var b Bytes
+		var ns NullString
+		var nb NullBytes
+		for _, d := range []decoder{&s, &b, &ns, &nb} {
+			if err := d.DecodeSpanner(in); err != nil {
+				t.Fatalf("%T.DecodeSpanner(%#v) unexpected error: %v", d, in, err)
+			}
+		}
+		if uuid.UUID(s) != testUUID || uuid.UUID(b) != testUUID {
+			t.Errorf("DecodeSpanner(%#v) = %v, %v, want %v", in, uuid.UUID(s), uuid.UUID(b), testUUID)
+		}
+		if want := (uuid.NullUUID{UUID: testUUID, Valid: true}); uuid.NullUUID(ns) != want || uuid.NullUUID(nb) != want {
+			t.Errorf("DecodeSpanner(%#v) = %+v, %+v, want %+v", in, ns, nb, want)
+		}
+	}
+
+	s := String(testUUID)
+	ns := NullString{UUID: testUUID, Valid: true}
+	if err := s.DecodeSpanner(nil); err != nil || uuid.UUID(s) != uuid.Nil {
+		t.Errorf("String.DecodeSpanner(nil) = %v, %v, want %v", uuid.UUID(s), err, uuid.Nil)
+	}
+	if err := ns.DecodeSpanner(nil); err != nil || ns.Valid {
+		t.Errorf("NullString.DecodeSpanner(nil) = %+v, %v, want invalid", ns, err)
+	}
+	b := Bytes(testUUID)
+	if err := b.DecodeSpanner("not-a-uuid"); err == nil {
+		t.Error("Bytes.DecodeSpanner(\"not-a-uuid\") succeeded, want error")
+	}
+	nb := NullBytes{UUID: testUUID, Valid: true}
+	if err := nb.DecodeSpanner(testUUID[:8]); err == nil {
+		t.Error("NullBytes.DecodeSpanner(8 bytes) succeeded, want error")
+	}
+}
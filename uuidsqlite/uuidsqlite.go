@@ -0,0 +1,71 @@
+// Package uuidsqlite stores the UUID types of github.com/gofrs/uuid/v5 in
+// SQLite as 16-byte BLOBs, with any database/sql driver, such as
+// github.com/mattn/go-sqlite3 or modernc.org/sqlite.
+//
+// SQLite compares BLOBs with memcmp, so that BLOB UUIDs sort in the byte
+// order of uuid.UUID.Compare, and version 7 UUIDs in the order they were
+// generated. Columns are dynamically typed, however, and SQLite sorts every
+// TEXT value before every BLOB: a column holding UUIDs written as strings by
+// some code and as bytes by other code cannot be scanned by range. Check
+// returns a constraint rejecting anything but 16-byte BLOBs, and ConvertText
+// a statement converting the TEXT values of an existing column:
+//
+//	CREATE TABLE events (
+//		id BLOB PRIMARY KEY CHECK (typeof(id) = 'blob' AND length(id) = 16)
+//	)
+//
+// Use uuid.BinaryUUID and NullBlob as query arguments and scan
+// destinations. They scan both BLOB and TEXT values, since drivers return
+// either depending on how the rows were written, and V7Range returns the
+// arguments of a range scan over version 7 UUIDs.
+package uuidsqlite
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Check returns a CHECK constraint allowing only 16-byte BLOBs in column.
+// As with any CHECK constraint, NULL passes it.
+func Check(column string) string {
+	return fmt.Sprintf("CHECK (typeof(%[1]s) = 'blob' AND length(%[1]s) = 16)", column)
+}
+
+// ConvertText returns an UPDATE statement converting the UUIDs stored as
+// TEXT in the canonical form in column of table to 16-byte BLOBs. It
+// requires SQLite 3.41.0 or later, for the unhex function.
+func ConvertText(table, column string) string {
+	return fmt.Sprintf("UPDATE %[1]s SET %[2]s = unhex(replace(%[2]s, '-', '')) WHERE typeof(%[2]s) = 'text'", table, column)
+}
+
+// V7Range returns the arguments of the query
+//
+//	WHERE id >= ? AND id < ?
+//
+// selecting the version 7 UUIDs created in the time window [from, to) from
+// a column of 16-byte BLOBs. See uuid.V7RangeForTime.
+func V7Range(from, to time.Time) (lo, hi uuid.BinaryUUID) {
+	l, h := uuid.V7RangeForTime(from, to)
+	return uuid.BinaryUUID(l), uuid.BinaryUUID(h)
+}
+
+// NullBlob is a uuid.NullUUID whose Value method returns the 16 bytes of a
+// valid UUID, and nil otherwise. It scans the same values as
+// uuid.NullUUID.
+type NullBlob uuid.NullUUID
+
+// Value implements the driver.Valuer interface.
+func (u NullBlob) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.UUID.Bytes(), nil
+}
+
+// Scan implements the sql.Scanner interface.
+func (u *NullBlob) Scan(src interface{}) error {
+	return (*uuid.NullUUID)(u).Scan(src)
+}
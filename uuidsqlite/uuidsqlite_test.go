@@ -0,0 +1,100 @@
+package uuidsqlite
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+var testUUID = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+func TestCheck(t *testing.T) {
+	want := "CHECK (typeof(id) = 'blob' AND length(id) = 16)"
+	if got := Check("id"); got != want {
+		t.Errorf("Check(id) = %q, want %q", got, want)
+	}
+}
+
+func TestConvertText(t *testing.T) {
+	want := "UPDATE events SET id = unhex(replace(id, '-', '')) WHERE typeof(id) = 'text'"
+	if got := ConvertText("events", "id"); got != want {
+		t.Errorf("ConvertText(events, id) = %q, want %q", got, want)
+	}
+}
+
+// blob returns the driver value of v, failing the test if it is not a
+// []byte.
+func blob(t *testing.T, v driver.Valuer) []byte {
+	t.Helper()
+	dv, err := v.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, ok := dv.([]byte)
+	if !ok {
+		t.Fatalf("Value() = %T, want []byte", dv)
+	}
+	return b
+}
+
+func TestV7Range(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	var blobs [][]byte
+	for i := -2; i < 4; i++ {
+		u, err := uuid.NewGenWithOptions(uuid.WithEpochFunc(func() time.Time {
+			return at.Add(time.Duration(i) * time.Minute)
+		})).NewV7()
+		if err != nil {
+			t.Fatal(err)
+		}
+		blobs = append(blobs, blob(t, uuid.BinaryUUID(u)))
+	}
+	// SQLite compares BLOBs with memcmp.
+	sorted := append([][]byte(nil), blobs...)
+	sort.Slice(sorted, func(i, j int) bool { return bytes.Compare(sorted[i], sorted[j]) < 0 })
+	for i := range blobs {
+		if !bytes.Equal(sorted[i], blobs[i]) {
+			t.Fatalf("BLOBs of version 7 UUIDs do not sort in generation order")
+		}
+	}
+
+	lo, hi := V7Range(at, at.Add(2*time.Minute))
+	l, h := blob(t, lo), blob(t, hi)
+	var in int
+	for _, b := range blobs {
+		if bytes.Compare(b, l) >= 0 && bytes.Compare(b, h) < 0 {
+			in++
+		}
+	}
+	if in != 2 {
+		t.Errorf("V7Range selected %d UUIDs, want 2", in)
+	}
+}
+
+func TestNullBlob(t *testing.T) {
+	valid := NullBlob{UUID: testUUID, Valid: true}
+	if got := blob(t, valid); !bytes.Equal(got, testUUID.Bytes()) {
+		t.Errorf("Value() = %x, want %x", got, testUUID.Bytes())
+	}
+	if v, err := (NullBlob{}).Value(); v != nil || err != nil {
+		t.Errorf("NullBlob{}.Value() = %v, %v, want nil", v, err)
+	}
+
+	for _, src := range []interface{}{testUUID.Bytes(), testUUID.String(), []byte(testUUID.String())} {
+		var got NullBlob
+		if err := got.Scan(src); err != nil || got != valid {
+			t.Errorf("Scan(%v) = %+v, %v, want %+v", src, got, err, valid)
+		}
+	}
+	got := valid
+	if err := got.Scan(nil); err != nil || got.Valid {
+		t.Errorf("Scan(nil) = %+v, %v, want an invalid NullBlob", got, err)
+	}
+	if err := got.Scan(42); err == nil {
+		t.Error("Scan(42) succeeded, want error")
+	}
+}
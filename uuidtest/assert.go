@@ -0,0 +1,82 @@
+package uuidtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// AssertVersion reports an error through t unless u is a UUID of the RFC
+// 9562 variant and the given version, and returns whether it is:
+//
+//	uuidtest.AssertVersion(t, order.ID, uuid.V7)
+func AssertVersion(t testing.TB, u uuid.UUID, version byte) bool {
+	t.Helper()
+	if u.Variant() != uuid.VariantRFC9562 {
+		t.Errorf("UUID %s has variant %s, want the RFC 9562 variant of version %d", u, variantName(u.Variant()), version)
+		return false
+	}
+	if u.Version() != version {
+		t.Errorf("UUID %s is version %d, want version %d", u, u.Version(), version)
+		return false
+	}
+	return true
+}
+
+// variantName returns the name of a variant returned by UUID.Variant.
+func variantName(variant byte) string {
+	switch variant {
+	case uuid.VariantNCS:
+		return "NCS"
+	case uuid.VariantMicrosoft:
+		return "Microsoft"
+	case uuid.VariantFuture:
+		return "future"
+	default:
+		return "RFC 9562"
+	}
+}
+
+// AssertMonotonic reports an error through t unless every UUID of us sorts
+// strictly after the previous one, in the byte order of UUID.Compare which
+// is also the order of their timestamps for version 6 and 7 UUIDs. It
+// reports the first UUID out of order, and returns whether all are in
+// order.
+func AssertMonotonic(t testing.TB, us []uuid.UUID) bool {
+	t.Helper()
+	for i := 1; i < len(us); i++ {
+		if us[i].Compare(us[i-1]) <= 0 {
+			rel := "before"
+			if us[i] == us[i-1] {
+				rel = "equal to"
+			}
+			t.Errorf("UUID %d of %d, %s, is %s UUID %d, %s", i, len(us), us[i], rel, i-1, us[i-1])
+			return false
+		}
+	}
+	return true
+}
+
+// AssertWithinTime reports an error through t unless u is a version 1, 6 or
+// 7 UUID whose embedded time is within window of the current time, before
+// or after it, and returns whether it is. The window should allow for the
+// millisecond precision of version 7 UUIDs:
+//
+//	u, _ := uuid.NewV7()
+//	uuidtest.AssertWithinTime(t, u, time.Second)
+func AssertWithinTime(t testing.TB, u uuid.UUID, window time.Duration) bool {
+	t.Helper()
+	ts, err := u.Time()
+	if err != nil {
+		t.Errorf("UUID %s has no time: %v", u, err)
+		return false
+	}
+	now := time.Now()
+	if d := ts.Sub(now); d > window || d < -window {
+		t.Errorf("UUID %s has time %s, %s from the current time %s, want within %s",
+			u, ts.Format(time.RFC3339Nano), d, now.Format(time.RFC3339Nano), window)
+		return false
+	}
+	return true
+}
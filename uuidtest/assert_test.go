@@ -0,0 +1,98 @@
+package uuidtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// recorder is a testing.TB recording the errors reported.
+type recorder struct {
+	testing.TB
+	errs []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errs = append(r.errs, fmt.Sprintf(format, args...))
+}
+
+// check checks that the assertion returned ok and reported an error
+// containing want, or none if want is empty.
+func check(t *testing.T, r *recorder, ok bool, want string) {
+	t.Helper()
+	switch {
+	case want == "":
+		if !ok || len(r.errs) != 0 {
+			t.Errorf("assertion = %v, reported %q, want no error", ok, r.errs)
+		}
+	case ok || len(r.errs) != 1 || !strings.Contains(r.errs[0], want):
+		t.Errorf("assertion = %v, reported %q, want an error containing %q", ok, r.errs, want)
+	}
+}
+
+func TestAssertVersion(t *testing.T) {
+	v4 := uuid.Must(uuid.FromString("f3a1a4d6-6c1e-4b8e-9b3a-1c2d3e4f5a6b"))
+	tests := []struct {
+		u       uuid.UUID
+		version byte
+		want    string
+	}{
+		{v4, uuid.V4, ""},
+		{v4, uuid.V7, "is version 4, want version 7"},
+		{uuid.Nil, uuid.V4, "has variant NCS"},
+		{uuid.Max, uuid.V4, "has variant future"},
+	}
+	for _, tt := range tests {
+		r := &recorder{TB: t}
+		check(t, r, AssertVersion(r, tt.u, tt.version), tt.want)
+	}
+}
+
+func TestAssertMonotonic(t *testing.T) {
+	a := uuid.Must(uuid.FromString("01890a5d-ac96-774b-bcce-b302099a8057"))
+	b := uuid.Must(uuid.FromString("01890a5d-ac96-774b-bcce-b302099a8058"))
+	c := uuid.Must(uuid.FromString("01890a5d-ac97-7000-8000-000000000000"))
+	tests := []struct {
+		us   []uuid.UUID
+		want string
+	}{
+		{nil, ""},
+		{[]uuid.UUID{a}, ""},
+		{[]uuid.UUID{a, b, c}, ""},
+		{[]uuid.UUID{a, c, b}, "UUID 2 of 3, " + b.String() + ", is before UUID 1"},
+		{[]uuid.UUID{a, b, b}, "is equal to UUID 1"},
+	}
+	for _, tt := range tests {
+		r := &recorder{TB: t}
+		check(t, r, AssertMonotonic(r, tt.us), tt.want)
+	}
+}
+
+func TestAssertWithinTime(t *testing.T) {
+	g := uuid.NewGenWithOptions(uuid.WithEpochFunc(NewClock(time.Now().Add(-time.Hour)).Now))
+	old, err := g.NewV7()
+	if err != nil {
+		t.Fatal(err)
+	}
+	now, err := uuid.NewV7()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		u    uuid.UUID
+		want string
+	}{
+		{now, ""},
+		{old, "want within 1m0s"},
+		{uuid.Must(uuid.NewV4()), "has no time"},
+	}
+	for _, tt := range tests {
+		r := &recorder{TB: t}
+		check(t, r, AssertWithinTime(r, tt.u, time.Minute), tt.want)
+	}
+}
@@ -0,0 +1,70 @@
+package uuidtest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Clock is a fake clock for the time-based UUIDs of a uuid.Gen. Its Now
+// method is a uuid.EpochFunc returning the time set on the clock, so that
+// tests of the same-tick counters and clock rollbacks of version 1, 6 and 7
+// UUIDs are deterministic and do not sleep:
+//
+//	clock := uuidtest.NewClock(time.Unix(1700000000, 0))
+//	g := uuid.NewGenWithOptions(uuid.WithEpochFunc(clock.Now))
+//	a, _ := g.NewV7()
+//	clock.Advance(time.Millisecond)
+//	b, _ := g.NewV7()
+//
+// By default the time only changes when Set or Advance is called. With
+// AutoAdvance, every call to Now also advances the clock.
+//
+// Clock is safe for concurrent use.
+type Clock struct {
+	mu   sync.Mutex
+	now  time.Time
+	step time.Duration
+}
+
+var _ uuid.EpochFunc = (*Clock)(nil).Now
+
+// NewClock returns a Clock set to start.
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+// Now returns the time of c, and then advances c by the step set with
+// AutoAdvance, if any.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := c.now
+	c.now = c.now.Add(c.step)
+	return t
+}
+
+// Set sets the time of c to t, which may be before its current time to
+// simulate a clock rollback.
+func (c *Clock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+// Advance advances the time of c by d, which may be negative.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// AutoAdvance makes every call to Now advance c by step, after returning the
+// current time. A step of zero stops the auto-advance. It returns c.
+func (c *Clock) AutoAdvance(step time.Duration) *Clock {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.step = step
+	return c
+}
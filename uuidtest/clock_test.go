@@ -0,0 +1,92 @@
+package uuidtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+var clockStart = time.Unix(1700000000, 0).UTC()
+
+func TestClock(t *testing.T) {
+	c := NewClock(clockStart)
+	for i := 0; i < 2; i++ {
+		if got := c.Now(); !got.Equal(clockStart) {
+			t.Fatalf("Now() = %v, want %v", got, clockStart)
+		}
+	}
+	c.Advance(time.Second)
+	if got, want := c.Now(), clockStart.Add(time.Second); !got.Equal(want) {
+		t.Errorf("Now() after Advance = %v, want %v", got, want)
+	}
+	c.Set(clockStart.Add(-time.Hour))
+	if got, want := c.Now(), clockStart.Add(-time.Hour); !got.Equal(want) {
+		t.Errorf("Now() after Set = %v, want %v", got, want)
+	}
+
+	c = NewClock(clockStart).AutoAdvance(time.Millisecond)
+	for i := 0; i < 3; i++ {
+		if got, want := c.Now(), clockStart.Add(time.Duration(i)*time.Millisecond); !got.Equal(want) {
+			t.Errorf("auto-advanced Now() #%d = %v, want %v", i, got, want)
+		}
+	}
+	c.AutoAdvance(0)
+	if a, b := c.Now(), c.Now(); !a.Equal(b) {
+		t.Errorf("Now() = %v then %v after auto-advance stopped", a, b)
+	}
+}
+
+func TestClockV7(t *testing.T) {
+	c := NewClock(clockStart)
+	g := uuid.NewGenWithOptions(uuid.WithEpochFunc(c.Now))
+	a, err := g.NewV7()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := g.NewV7()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ta, _ := uuid.TimestampFromV7(a)
+	tb, _ := uuid.TimestampFromV7(b)
+	if ta != tb {
+		t.Errorf("UUIDs of the same tick have timestamps %d and %d", ta, tb)
+	}
+	if a == b {
+		t.Errorf("UUIDs of the same tick are both %v", a)
+	}
+
+	c.Advance(time.Millisecond)
+	u, err := g.NewV7()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tu, _ := uuid.TimestampFromV7(u)
+	if got, err := tu.Time(); err != nil || !got.Equal(clockStart.Add(time.Millisecond)) {
+		t.Errorf("timestamp of %v = %v, %v, want %v", u, got, err, clockStart.Add(time.Millisecond))
+	}
+}
+
+func TestClockV1Rollback(t *testing.T) {
+	c := NewClock(clockStart)
+	g := uuid.NewGenWithOptions(uuid.WithEpochFunc(c.Now))
+	a, err := g.NewV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Set(clockStart.Add(-time.Second))
+	b, err := g.NewV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sa, _ := a.ClockSequence()
+	sb, _ := b.ClockSequence()
+	if sa == sb {
+		t.Errorf("clock sequence %d unchanged after a clock rollback", sa)
+	}
+	tb, _ := uuid.TimestampFromV1(b)
+	if got, _ := tb.Time(); !got.Equal(clockStart.Add(-time.Second)) {
+		t.Errorf("timestamp after rollback = %v, want %v", got, clockStart.Add(-time.Second))
+	}
+}
@@ -0,0 +1,143 @@
+package uuidtest
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// ErrInjected is the error returned by a Failing generator failing a call
+// for which no other error was given.
+var ErrInjected = errors.New("uuidtest: injected error")
+
+// Failing is a uuid.Generator wrapping another generator and failing some of
+// the calls made to it, so that the handling of the errors of NewV4, NewV7 and
+// the other methods returning errors can be tested:
+//
+//	g := uuidtest.FailNth(uuid.DefaultGenerator, 2, nil)
+//	g.NewV4() // returns a UUID of uuid.DefaultGenerator
+//	g.NewV4() // returns uuid.Nil and uuidtest.ErrInjected
+//	g.NewV4() // returns a UUID of uuid.DefaultGenerator
+//
+// Calls are counted across all the methods returning errors. NewV3 and NewV5
+// cannot fail, and are passed to the wrapped generator without being
+// counted.
+//
+// Failing is safe for concurrent use.
+type Failing struct {
+	g     uuid.Generator
+	fail  func(call int) error
+	mu    sync.Mutex
+	calls int
+}
+
+var _ uuid.Generator = (*Failing)(nil)
+
+// NewFailing returns a Failing generator wrapping g, which calls fail with
+// the number of every call, starting at 1, and fails the call with the
+// error returned, if not nil.
+func NewFailing(g uuid.Generator, fail func(call int) error) *Failing {
+	return &Failing{g: g, fail: fail}
+}
+
+// FailNth returns a Failing generator wrapping g which fails the nth call
+// only, with err, or ErrInjected if err is nil.
+func FailNth(g uuid.Generator, n int, err error) *Failing {
+	if err == nil {
+		err = ErrInjected
+	}
+	return NewFailing(g, func(call int) error {
+		if call == n {
+			return err
+		}
+		return nil
+	})
+}
+
+// FailPattern returns a Failing generator wrapping g whose nth call fails
+// with the nth error of errs, or succeeds if it is nil. The pattern repeats
+// once all the errors have been used, so that FailPattern(g, nil, ErrInjected)
+// fails every other call. With no errors, no call fails.
+func FailPattern(g uuid.Generator, errs ...error) *Failing {
+	errs = append([]error(nil), errs...)
+	return NewFailing(g, func(call int) error {
+		if len(errs) == 0 {
+			return nil
+		}
+		return errs[(call-1)%len(errs)]
+	})
+}
+
+// Calls returns the number of calls counted by f.
+func (f *Failing) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+// generate counts a call, and returns the error for it or the result of gen
+// with the wrapped generator.
+func (f *Failing) generate(gen func(uuid.Generator) (uuid.UUID, error)) (uuid.UUID, error) {
+	f.mu.Lock()
+	f.calls++
+	call := f.calls
+	f.mu.Unlock()
+	if err := f.fail(call); err != nil {
+		return uuid.Nil, err
+	}
+	return gen(f.g)
+}
+
+// NewV1 returns a version 1 UUID of the wrapped generator, or an injected
+// error.
+func (f *Failing) NewV1() (uuid.UUID, error) {
+	return f.generate(uuid.Generator.NewV1)
+}
+
+// NewV1AtTime returns a version 1 UUID of the wrapped generator, or an
+// injected error.
+func (f *Failing) NewV1AtTime(t time.Time) (uuid.UUID, error) {
+	return f.generate(func(g uuid.Generator) (uuid.UUID, error) { return g.NewV1AtTime(t) })
+}
+
+// NewV3 returns the version 3 UUID of the wrapped generator.
+func (f *Failing) NewV3(ns uuid.UUID, name string) uuid.UUID {
+	return f.g.NewV3(ns, name)
+}
+
+// NewV4 returns a version 4 UUID of the wrapped generator, or an injected
+// error.
+func (f *Failing) NewV4() (uuid.UUID, error) {
+	return f.generate(uuid.Generator.NewV4)
+}
+
+// NewV5 returns the version 5 UUID of the wrapped generator.
+func (f *Failing) NewV5(ns uuid.UUID, name string) uuid.UUID {
+	return f.g.NewV5(ns, name)
+}
+
+// NewV6 returns a version 6 UUID of the wrapped generator, or an injected
+// error.
+func (f *Failing) NewV6() (uuid.UUID, error) {
+	return f.generate(uuid.Generator.NewV6)
+}
+
+// NewV6AtTime returns a version 6 UUID of the wrapped generator, or an
+// injected error.
+func (f *Failing) NewV6AtTime(t time.Time) (uuid.UUID, error) {
+	return f.generate(func(g uuid.Generator) (uuid.UUID, error) { return g.NewV6AtTime(t) })
+}
+
+// NewV7 returns a version 7 UUID of the wrapped generator, or an injected
+// error.
+func (f *Failing) NewV7() (uuid.UUID, error) {
+	return f.generate(uuid.Generator.NewV7)
+}
+
+// NewV7AtTime returns a version 7 UUID of the wrapped generator, or an
+// injected error.
+func (f *Failing) NewV7AtTime(t time.Time) (uuid.UUID, error) {
+	return f.generate(func(g uuid.Generator) (uuid.UUID, error) { return g.NewV7AtTime(t) })
+}
@@ -0,0 +1,88 @@
+package uuidtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestFailNth(t *testing.T) {
+	if uuid.FIPS {
+		t.Skip("NewV3 and NewV5 are disabled by the uuid_fips build tag")
+	}
+	for i := range all(NewFailing(nil, nil)) {
+		f := FailNth(NewSequential(uuid.Nil), 2, nil)
+		for call := 1; call <= 3; call++ {
+			u, err := all(f)[i]()
+			switch {
+			case i == 2 || i == 4: // NewV3 and NewV5
+				if err != nil {
+					t.Errorf("method %d call %d unexpected error: %v", i, call, err)
+				}
+			case call == 2:
+				if !errors.Is(err, ErrInjected) || u != uuid.Nil {
+					t.Errorf("method %d call %d = %v, %v, want %v", i, call, u, err, ErrInjected)
+				}
+			case err != nil || u == uuid.Nil:
+				t.Errorf("method %d call %d = %v, %v, want a UUID", i, call, u, err)
+			}
+		}
+	}
+
+	errBoom := errors.New("boom")
+	f := FailNth(NewSequential(uuid.Nil), 1, errBoom)
+	if _, err := f.NewV7(); err != errBoom {
+		t.Errorf("NewV7() error = %v, want %v", err, errBoom)
+	}
+	f.NewV5(uuid.NamespaceDNS, "a")
+	if f.Calls() != 1 {
+		t.Errorf("Calls() = %d, want 1", f.Calls())
+	}
+}
+
+func TestFailPattern(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	f := FailPattern(NewSequential(uuid.Nil), nil, errA, errB)
+	want := []error{nil, errA, errB, nil, errA, errB, nil}
+	for call, wantErr := range want {
+		if _, err := f.NewV4(); err != wantErr {
+			t.Errorf("call %d error = %v, want %v", call+1, err, wantErr)
+		}
+	}
+	if f.Calls() != len(want) {
+		t.Errorf("Calls() = %d, want %d", f.Calls(), len(want))
+	}
+
+	f = FailPattern(NewSequential(uuid.Nil))
+	for call := 1; call <= 3; call++ {
+		if _, err := f.NewV7(); err != nil {
+			t.Errorf("call %d unexpected error: %v", call, err)
+		}
+	}
+}
+
+func TestNewFailing(t *testing.T) {
+	var calls []int
+	f := NewFailing(NewSequential(uuid.Nil), func(call int) error {
+		calls = append(calls, call)
+		if call%2 == 0 {
+			return ErrInjected
+		}
+		return nil
+	})
+	u, err := f.NewV4()
+	if err != nil || u.String() != "00000000-0000-4000-8000-000000000000" {
+		t.Errorf("NewV4() = %v, %v", u, err)
+	}
+	if _, err := f.NewV6(); !errors.Is(err, ErrInjected) {
+		t.Errorf("NewV6() error = %v, want %v", err, ErrInjected)
+	}
+	// The failed call did not consume the wrapped generator.
+	if u, _ := f.NewV4(); u.String() != "00000000-0000-4000-8000-000000000001" {
+		t.Errorf("NewV4() after a failure = %v", u)
+	}
+	if len(calls) != 3 || calls[0] != 1 || calls[2] != 3 {
+		t.Errorf("fail called with %v, want [1 2 3]", calls)
+	}
+}
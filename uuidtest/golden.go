@@ -0,0 +1,28 @@
+package uuidtest
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Namespace is the namespace of the UUIDs returned by ForName, the version 5
+// UUID of the URL "https://github.com/gofrs/uuid/uuidtest". It is a literal
+// so that importing the package does not panic with the uuid_fips build tag.
+var Namespace = uuid.Must(uuid.FromString("ec3634dc-06cf-52b7-a5b5-d3e0f545eb85"))
+
+// ForName returns the version 5 UUID, in Namespace, of the name of t and
+// name joined by a slash, such as "TestOrder/customer". The UUID is the same
+// in every run and every package, so that fixtures and golden files can
+// refer to it without hardcoding it:
+//
+//	customerID := uuidtest.ForName(t, "customer")
+//
+// Subtests have their own UUIDs for the same name. UUIDs shared by all the
+// tests are those of uuid.NewV5(uuidtest.Namespace, name). Like uuid.NewV5,
+// it panics with uuid.ErrHashDisabled if the package is built with the
+// uuid_fips build tag.
+func ForName(t testing.TB, name string) uuid.UUID {
+	t.Helper()
+	return uuid.NewV5(Namespace, t.Name()+"/"+name)
+}
@@ -0,0 +1,32 @@
+package uuidtest
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestForName(t *testing.T) {
+	if uuid.FIPS {
+		t.Skip("NewV5 is disabled by the uuid_fips build tag")
+	}
+	if want := uuid.NewV5(uuid.NamespaceURL, "https://github.com/gofrs/uuid/uuidtest"); Namespace != want {
+		t.Errorf("Namespace = %v, want %v", Namespace, want)
+	}
+	u := ForName(t, "customer")
+	if want := uuid.NewV5(Namespace, "TestForName/customer"); u != want {
+		t.Errorf("ForName(t, %q) = %v, want %v", "customer", u, want)
+	}
+	AssertVersion(t, u, uuid.V5)
+	if ForName(t, "customer") != u {
+		t.Error("ForName is not stable")
+	}
+	if ForName(t, "order") == u {
+		t.Error("ForName returned the same UUID for different names")
+	}
+	t.Run("sub", func(t *testing.T) {
+		if ForName(t, "customer") == u {
+			t.Error("ForName returned the same UUID in a subtest")
+		}
+	})
+}
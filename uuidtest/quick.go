@@ -0,0 +1,133 @@
+package uuidtest
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing/quick"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Versions are the UUID versions generated by Random when no version is
+// given: the versions of RFC 9562, except version 2.
+var Versions = []byte{uuid.V1, uuid.V3, uuid.V4, uuid.V5, uuid.V6, uuid.V7, 8}
+
+// WithVersion returns u with the version bits set to version and the
+// variant bits set to the RFC 9562 variant.
+func WithVersion(u uuid.UUID, version byte) uuid.UUID {
+	u.SetVersion(version)
+	u.SetVariant(uuid.VariantRFC9562)
+	return u
+}
+
+// Random returns a UUID of the RFC 9562 variant and one of the given
+// versions, or of Versions if none is given, with bits read from r. One in
+// eight of the UUIDs returned are instead the smallest or largest UUID of
+// the version, which hold the boundary timestamps of versions 1, 6 and 7.
+func Random(r *rand.Rand, versions ...byte) uuid.UUID {
+	if len(versions) == 0 {
+		versions = Versions
+	}
+	version := versions[r.Intn(len(versions))]
+	var u uuid.UUID
+	switch r.Intn(16) {
+	case 0:
+	case 1:
+		u = uuid.Max
+	default:
+		r.Read(u[:])
+	}
+	return WithVersion(u, version)
+}
+
+// EdgeCases returns the edge cases of UUIDs: uuid.Nil, uuid.Max, and the
+// smallest and largest UUIDs of the RFC 9562 variant of each of Versions,
+// which for versions 1, 6 and 7 hold the smallest and largest timestamps.
+func EdgeCases() []uuid.UUID {
+	us := []uuid.UUID{uuid.Nil, uuid.Max}
+	for _, version := range Versions {
+		us = append(us, WithVersion(uuid.Nil, version), WithVersion(uuid.Max, version))
+	}
+	return us
+}
+
+// NearMisses returns strings close to the text forms of u which are not
+// valid UUIDs: forms one character too short or too long, with misplaced or
+// missing hyphens, characters which are not hexadecimal digits, unbalanced
+// braces or a misspelled URN prefix. uuid.FromString returns an error for
+// all of them.
+func NearMisses(u uuid.UUID) []string {
+	s := u.String()
+	hash := u.HashString()
+	replace := func(s string, i int, c byte) string {
+		return s[:i] + string(c) + s[i+1:]
+	}
+	return []string{
+		"",
+		s[:35],
+		s + s[35:],
+		s[:35] + "-",
+		replace(s, 8, s[9]),
+		replace(s, 13, '0'),
+		replace(s, 18, '_'),
+		replace(s, 23, ':'),
+		s[:8] + s[9:10] + "-" + s[10:],
+		strings.Replace(s, "-", "", 1),
+		replace(s, 0, 'g'),
+		replace(s, 17, 'G'),
+		replace(s, 35, ' '),
+		replace(s, 30, 'x'),
+		"{" + s,
+		s + "}",
+		"(" + s + ")",
+		"{" + s + "]",
+		"urn:uid:" + s,
+		"urn-uuid:" + s,
+		"uuid:" + s,
+		hash[:31],
+		hash + "0",
+		replace(hash, 15, 'z'),
+		"{" + hash,
+		"0x" + hash,
+	}
+}
+
+// Valid is a UUID implementing quick.Generator, generating UUIDs with Random
+// for the functions checked by quick.Check:
+//
+//	quick.Check(func(v uuidtest.Valid) bool {
+//		u := uuid.UUID(v)
+//		...
+//	}, nil)
+type Valid uuid.UUID
+
+// Generate implements the quick.Generator interface.
+func (Valid) Generate(r *rand.Rand, size int) reflect.Value {
+	return reflect.ValueOf(Valid(Random(r)))
+}
+
+// Edge is a UUID implementing quick.Generator, generating one of EdgeCases.
+type Edge uuid.UUID
+
+// Generate implements the quick.Generator interface.
+func (Edge) Generate(r *rand.Rand, size int) reflect.Value {
+	edges := EdgeCases()
+	return reflect.ValueOf(Edge(edges[r.Intn(len(edges))]))
+}
+
+// NearMiss is a string implementing quick.Generator, generating one of the
+// NearMisses of a random UUID.
+type NearMiss string
+
+// Generate implements the quick.Generator interface.
+func (NearMiss) Generate(r *rand.Rand, size int) reflect.Value {
+	misses := NearMisses(Random(r))
+	return reflect.ValueOf(NearMiss(misses[r.Intn(len(misses))]))
+}
+
+var (
+	_ quick.Generator = Valid{}
+	_ quick.Generator = Edge{}
+	_ quick.Generator = NearMiss("")
+)
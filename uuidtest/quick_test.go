@@ -0,0 +1,81 @@
+package uuidtest
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	seen := make(map[byte]bool)
+	for i := 0; i < 1000; i++ {
+		u := Random(r)
+		if !AssertVersion(t, u, u.Version()) {
+			break
+		}
+		seen[u.Version()] = true
+	}
+	for _, version := range Versions {
+		if !seen[version] {
+			t.Errorf("Random() never returned a version %d UUID", version)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		if !AssertVersion(t, Random(r, uuid.V7), uuid.V7) {
+			break
+		}
+	}
+}
+
+func TestEdgeCases(t *testing.T) {
+	edges := EdgeCases()
+	if len(edges) != 2+2*len(Versions) || edges[0] != uuid.Nil || edges[1] != uuid.Max {
+		t.Fatalf("EdgeCases() = %v", edges)
+	}
+	want := map[string]bool{
+		"00000000-0000-7000-8000-000000000000": true,
+		"ffffffff-ffff-7fff-bfff-ffffffffffff": true,
+		"00000000-0000-1000-8000-000000000000": true,
+		"ffffffff-ffff-1fff-bfff-ffffffffffff": true,
+	}
+	for _, u := range edges {
+		delete(want, u.String())
+	}
+	if len(want) != 0 {
+		t.Errorf("EdgeCases() is missing %v", want)
+	}
+}
+
+func TestNearMisses(t *testing.T) {
+	for _, u := range append(EdgeCases(), Random(rand.New(rand.NewSource(1)))) {
+		for _, s := range NearMisses(u) {
+			if _, err := uuid.FromString(s); err == nil {
+				t.Errorf("NearMisses(%v) returned the valid UUID %q", u, s)
+			}
+		}
+	}
+}
+
+func TestQuick(t *testing.T) {
+	if err := quick.Check(func(v Valid) bool {
+		u := uuid.UUID(v)
+		return u.Variant() == uuid.VariantRFC9562 && u.Version() != 2 && u.Version() >= 1 && u.Version() <= 8
+	}, nil); err != nil {
+		t.Error(err)
+	}
+	if err := quick.Check(func(e Edge) bool {
+		u := uuid.UUID(e)
+		return u == uuid.Nil || u == uuid.Max || u.Variant() == uuid.VariantRFC9562
+	}, nil); err != nil {
+		t.Error(err)
+	}
+	if err := quick.Check(func(s NearMiss) bool {
+		_, err := uuid.FromString(string(s))
+		return err != nil
+	}, nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,178 @@
+package uuidtest
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Record is a UUID issued by a Recorder.
+type Record struct {
+	// UUID is the UUID issued.
+	UUID uuid.UUID
+	// Version is the version requested, which is also the version of UUID
+	// if the wrapped generator is a uuid.Gen.
+	Version byte
+	// Time is the time of the call.
+	Time time.Time
+	// Caller is the file:line of the call.
+	Caller string
+}
+
+// String returns the UUID, version, time and caller of r.
+func (r Record) String() string {
+	return fmt.Sprintf("%s (version %d) at %s by %s", r.UUID, r.Version, r.Time.Format(time.RFC3339Nano), r.Caller)
+}
+
+// Recorder is a uuid.Generator wrapping another generator and recording
+// every UUID it issues, so that tests can find out which call produced a
+// given UUID:
+//
+//	rec := uuidtest.NewRecorder(uuid.DefaultGenerator)
+//	svc := NewService(rec)
+//	...
+//	if r, ok := rec.Lookup(id); ok {
+//		t.Logf("%s was generated by %s", id, r.Caller)
+//	}
+//
+// Failed calls are not recorded.
+//
+// Recorder is safe for concurrent use.
+type Recorder struct {
+	g       uuid.Generator
+	mu      sync.Mutex
+	records []Record
+}
+
+var _ uuid.Generator = (*Recorder)(nil)
+
+// NewRecorder returns a Recorder wrapping g.
+func NewRecorder(g uuid.Generator) *Recorder {
+	return &Recorder{g: g}
+}
+
+// Records returns the records of r, in the order of the calls.
+func (r *Recorder) Records() []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Record(nil), r.records...)
+}
+
+// Len returns the number of records of r.
+func (r *Recorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.records)
+}
+
+// UUIDs returns the UUIDs issued by r, in the order of the calls.
+func (r *Recorder) UUIDs() []uuid.UUID {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	us := make([]uuid.UUID, len(r.records))
+	for i, rec := range r.records {
+		us[i] = rec.UUID
+	}
+	return us
+}
+
+// Lookup returns the first record of u, and false if r did not issue u.
+func (r *Recorder) Lookup(u uuid.UUID) (Record, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, rec := range r.records {
+		if rec.UUID == u {
+			return rec, true
+		}
+	}
+	return Record{}, false
+}
+
+// ByVersion returns the records of the calls requesting the given version.
+func (r *Recorder) ByVersion(version byte) []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var recs []Record
+	for _, rec := range r.records {
+		if rec.Version == version {
+			recs = append(recs, rec)
+		}
+	}
+	return recs
+}
+
+// Reset deletes the records of r.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = nil
+}
+
+// generate returns the result of gen with the wrapped generator, recording
+// the UUID returned. It must be called directly by the methods of the
+// Generator interface, for the caller to be that of the method.
+func (r *Recorder) generate(version byte, gen func(uuid.Generator) (uuid.UUID, error)) (uuid.UUID, error) {
+	rec := Record{Version: version, Time: time.Now(), Caller: "unknown"}
+	if _, file, line, ok := runtime.Caller(2); ok {
+		rec.Caller = fmt.Sprintf("%s:%d", file, line)
+	}
+	u, err := gen(r.g)
+	if err != nil {
+		return u, err
+	}
+	rec.UUID = u
+	r.mu.Lock()
+	r.records = append(r.records, rec)
+	r.mu.Unlock()
+	return u, nil
+}
+
+// NewV1 returns and records a version 1 UUID of the wrapped generator.
+func (r *Recorder) NewV1() (uuid.UUID, error) {
+	return r.generate(uuid.V1, uuid.Generator.NewV1)
+}
+
+// NewV1AtTime returns and records a version 1 UUID of the wrapped generator.
+func (r *Recorder) NewV1AtTime(t time.Time) (uuid.UUID, error) {
+	return r.generate(uuid.V1, func(g uuid.Generator) (uuid.UUID, error) { return g.NewV1AtTime(t) })
+}
+
+// NewV3 returns and records the version 3 UUID of the wrapped generator.
+func (r *Recorder) NewV3(ns uuid.UUID, name string) uuid.UUID {
+	u, _ := r.generate(uuid.V3, func(g uuid.Generator) (uuid.UUID, error) { return g.NewV3(ns, name), nil })
+	return u
+}
+
+// NewV4 returns and records a version 4 UUID of the wrapped generator.
+func (r *Recorder) NewV4() (uuid.UUID, error) {
+	return r.generate(uuid.V4, uuid.Generator.NewV4)
+}
+
+// NewV5 returns and records the version 5 UUID of the wrapped generator.
+func (r *Recorder) NewV5(ns uuid.UUID, name string) uuid.UUID {
+	u, _ := r.generate(uuid.V5, func(g uuid.Generator) (uuid.UUID, error) { return g.NewV5(ns, name), nil })
+	return u
+}
+
+// NewV6 returns and records a version 6 UUID of the wrapped generator.
+func (r *Recorder) NewV6() (uuid.UUID, error) {
+	return r.generate(uuid.V6, uuid.Generator.NewV6)
+}
+
+// NewV6AtTime returns and records a version 6 UUID of the wrapped generator.
+func (r *Recorder) NewV6AtTime(t time.Time) (uuid.UUID, error) {
+	return r.generate(uuid.V6, func(g uuid.Generator) (uuid.UUID, error) { return g.NewV6AtTime(t) })
+}
+
+// NewV7 returns and records a version 7 UUID of the wrapped generator.
+func (r *Recorder) NewV7() (uuid.UUID, error) {
+	return r.generate(uuid.V7, uuid.Generator.NewV7)
+}
+
+// NewV7AtTime returns and records a version 7 UUID of the wrapped generator.
+func (r *Recorder) NewV7AtTime(t time.Time) (uuid.UUID, error) {
+	return r.generate(uuid.V7, func(g uuid.Generator) (uuid.UUID, error) { return g.NewV7AtTime(t) })
+}
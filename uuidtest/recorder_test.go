@@ -0,0 +1,76 @@
+package uuidtest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestRecorder(t *testing.T) {
+	if uuid.FIPS {
+		t.Skip("NewV3 and NewV5 are disabled by the uuid_fips build tag")
+	}
+	r := NewRecorder(NewSequential(uuid.Nil))
+	before := time.Now()
+	var issued []uuid.UUID
+	for _, gen := range all(r) {
+		u, err := gen()
+		if err != nil {
+			t.Fatal(err)
+		}
+		issued = append(issued, u)
+	}
+	if r.Len() != len(issued) {
+		t.Fatalf("Len() = %d, want %d", r.Len(), len(issued))
+	}
+	versions := []byte{uuid.V1, uuid.V1, uuid.V3, uuid.V4, uuid.V5, uuid.V6, uuid.V6, uuid.V7, uuid.V7}
+	for i, rec := range r.Records() {
+		if rec.UUID != issued[i] || rec.Version != versions[i] {
+			t.Errorf("record %d = %v, want %v version %d", i, rec, issued[i], versions[i])
+		}
+		if rec.Time.Before(before) || rec.Time.After(time.Now()) {
+			t.Errorf("record %d time = %v", i, rec.Time)
+		}
+		if !strings.Contains(rec.Caller, "_test.go:") {
+			t.Errorf("record %d caller = %q, want a caller in a test file", i, rec.Caller)
+		}
+	}
+	for i, u := range r.UUIDs() {
+		if u != issued[i] {
+			t.Errorf("UUIDs()[%d] = %v, want %v", i, u, issued[i])
+		}
+	}
+
+	if rec, ok := r.Lookup(issued[3]); !ok || rec.Version != uuid.V4 {
+		t.Errorf("Lookup(%v) = %v, %v", issued[3], rec, ok)
+	}
+	if _, ok := r.Lookup(uuid.Max); ok {
+		t.Errorf("Lookup(%v) found a record of a UUID not issued", uuid.Max)
+	}
+	if recs := r.ByVersion(uuid.V7); len(recs) != 2 || recs[0].UUID != issued[7] || recs[1].UUID != issued[8] {
+		t.Errorf("ByVersion(7) = %v", recs)
+	}
+
+	u, _ := r.NewV4()
+	if rec, _ := r.Lookup(u); !strings.Contains(rec.Caller, "recorder_test.go:") {
+		t.Errorf("caller = %q, want the caller in recorder_test.go", rec.Caller)
+	}
+
+	r.Reset()
+	if r.Len() != 0 || len(r.Records()) != 0 {
+		t.Errorf("Len() = %d after Reset()", r.Len())
+	}
+}
+
+func TestRecorderError(t *testing.T) {
+	r := NewRecorder(FailNth(NewSequential(uuid.Nil), 1, nil))
+	if _, err := r.NewV7(); !errors.Is(err, ErrInjected) {
+		t.Errorf("NewV7() error = %v, want %v", err, ErrInjected)
+	}
+	if r.Len() != 0 {
+		t.Errorf("failed call recorded: %v", r.Records())
+	}
+}
@@ -0,0 +1,182 @@
+package uuidtest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// ErrExhausted is returned by a Scripted generator asked for a UUID once it
+// has returned all its UUIDs.
+var ErrExhausted = errors.New("uuidtest: scripted UUIDs exhausted")
+
+// exhaustedMode is the behavior of a Scripted generator once exhausted.
+type exhaustedMode int
+
+const (
+	exhaustedError exhaustedMode = iota
+	exhaustedPanic
+	exhaustedCycle
+	exhaustedFallBack
+)
+
+// Scripted is a uuid.Generator returning given UUIDs, in order, whatever the
+// version requested, so that tests can assert which UUID was assigned to
+// which entity.
+//
+// Once all the UUIDs have been returned, a Scripted generator returns
+// ErrExhausted by default; NewV3 and NewV5, which cannot return an error,
+// panic with an error wrapping it. The PanicWhenExhausted,
+// CycleWhenExhausted and FallBackTo methods change this behavior.
+//
+// Scripted is safe for concurrent use.
+type Scripted struct {
+	mu       sync.Mutex
+	uuids    []uuid.UUID
+	next     int
+	mode     exhaustedMode
+	fallBack uuid.Generator
+}
+
+var _ uuid.Generator = (*Scripted)(nil)
+
+// NewScripted returns a Scripted generator returning uuids.
+func NewScripted(uuids ...uuid.UUID) *Scripted {
+	return &Scripted{uuids: append([]uuid.UUID(nil), uuids...)}
+}
+
+// PanicWhenExhausted makes s panic with ErrExhausted once exhausted, so that
+// a test generating more UUIDs than expected fails even if the code under
+// test ignores errors. It returns s.
+func (s *Scripted) PanicWhenExhausted() *Scripted {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mode = exhaustedPanic
+	return s
+}
+
+// CycleWhenExhausted makes s start over with its first UUID once exhausted.
+// It returns s.
+func (s *Scripted) CycleWhenExhausted() *Scripted {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mode = exhaustedCycle
+	return s
+}
+
+// FallBackTo makes s generate UUIDs with g once exhausted. A nil g restores
+// the default behavior, returning ErrExhausted. It returns s.
+func (s *Scripted) FallBackTo(g uuid.Generator) *Scripted {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mode = exhaustedFallBack
+	if g == nil {
+		s.mode = exhaustedError
+	}
+	s.fallBack = g
+	return s
+}
+
+// Remaining returns the number of UUIDs s has yet to return before being
+// exhausted.
+func (s *Scripted) Remaining() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.uuids) - s.next
+}
+
+// take returns the next UUID of s. If s is exhausted and falls back to
+// another generator, it returns the generator instead.
+func (s *Scripted) take() (uuid.UUID, uuid.Generator, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.next == len(s.uuids) {
+		switch s.mode {
+		case exhaustedPanic:
+			panic(ErrExhausted)
+		case exhaustedCycle:
+			if len(s.uuids) > 0 {
+				s.next = 0
+				break
+			}
+			return uuid.Nil, nil, ErrExhausted
+		case exhaustedFallBack:
+			return uuid.Nil, s.fallBack, nil
+		default:
+			return uuid.Nil, nil, ErrExhausted
+		}
+	}
+	u := s.uuids[s.next]
+	s.next++
+	return u, nil, nil
+}
+
+// generate returns the next UUID of s, or one generated by gen with the
+// fall-back generator.
+func (s *Scripted) generate(gen func(uuid.Generator) (uuid.UUID, error)) (uuid.UUID, error) {
+	u, fallBack, err := s.take()
+	if fallBack != nil {
+		return gen(fallBack)
+	}
+	return u, err
+}
+
+// generateName is generate for the name-based versions, which panic on
+// errors.
+func (s *Scripted) generateName(gen func(uuid.Generator) uuid.UUID) uuid.UUID {
+	u, err := s.generate(func(g uuid.Generator) (uuid.UUID, error) {
+		return gen(g), nil
+	})
+	if err != nil {
+		panic(fmt.Errorf("uuidtest: name-based UUID: %w", err))
+	}
+	return u
+}
+
+// NewV1 returns the next UUID of s.
+func (s *Scripted) NewV1() (uuid.UUID, error) {
+	return s.generate(uuid.Generator.NewV1)
+}
+
+// NewV1AtTime returns the next UUID of s.
+func (s *Scripted) NewV1AtTime(t time.Time) (uuid.UUID, error) {
+	return s.generate(func(g uuid.Generator) (uuid.UUID, error) { return g.NewV1AtTime(t) })
+}
+
+// NewV3 returns the next UUID of s.
+func (s *Scripted) NewV3(ns uuid.UUID, name string) uuid.UUID {
+	return s.generateName(func(g uuid.Generator) uuid.UUID { return g.NewV3(ns, name) })
+}
+
+// NewV4 returns the next UUID of s.
+func (s *Scripted) NewV4() (uuid.UUID, error) {
+	return s.generate(uuid.Generator.NewV4)
+}
+
+// NewV5 returns the next UUID of s.
+func (s *Scripted) NewV5(ns uuid.UUID, name string) uuid.UUID {
+	return s.generateName(func(g uuid.Generator) uuid.UUID { return g.NewV5(ns, name) })
+}
+
+// NewV6 returns the next UUID of s.
+func (s *Scripted) NewV6() (uuid.UUID, error) {
+	return s.generate(uuid.Generator.NewV6)
+}
+
+// NewV6AtTime returns the next UUID of s.
+func (s *Scripted) NewV6AtTime(t time.Time) (uuid.UUID, error) {
+	return s.generate(func(g uuid.Generator) (uuid.UUID, error) { return g.NewV6AtTime(t) })
+}
+
+// NewV7 returns the next UUID of s.
+func (s *Scripted) NewV7() (uuid.UUID, error) {
+	return s.generate(uuid.Generator.NewV7)
+}
+
+// NewV7AtTime returns the next UUID of s.
+func (s *Scripted) NewV7AtTime(t time.Time) (uuid.UUID, error) {
+	return s.generate(func(g uuid.Generator) (uuid.UUID, error) { return g.NewV7AtTime(t) })
+}
@@ -0,0 +1,108 @@
+package uuidtest
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+var scriptedUUIDs = []uuid.UUID{
+	uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8")),
+	uuid.Must(uuid.FromString("01890a5d-ac96-774b-bcce-b302099a8057")),
+	uuid.Max,
+}
+
+// all returns a function calling each method of g in turn.
+func all(g uuid.Generator) []func() (uuid.UUID, error) {
+	return []func() (uuid.UUID, error){
+		g.NewV1,
+		func() (uuid.UUID, error) { return g.NewV1AtTime(time.Now()) },
+		func() (uuid.UUID, error) { return g.NewV3(uuid.NamespaceDNS, "a"), nil },
+		g.NewV4,
+		func() (uuid.UUID, error) { return g.NewV5(uuid.NamespaceDNS, "a"), nil },
+		g.NewV6,
+		func() (uuid.UUID, error) { return g.NewV6AtTime(time.Now()) },
+		g.NewV7,
+		func() (uuid.UUID, error) { return g.NewV7AtTime(time.Now()) },
+	}
+}
+
+func TestScripted(t *testing.T) {
+	for i := range all(NewScripted()) {
+		s := NewScripted(scriptedUUIDs...)
+		for j, want := range scriptedUUIDs {
+			if s.Remaining() != len(scriptedUUIDs)-j {
+				t.Errorf("Remaining() = %d, want %d", s.Remaining(), len(scriptedUUIDs)-j)
+			}
+			u, err := all(s)[i]()
+			if err != nil || u != want {
+				t.Errorf("method %d call %d = %v, %v, want %v", i, j, u, err, want)
+			}
+		}
+		if s.Remaining() != 0 {
+			t.Errorf("Remaining() = %d, want 0", s.Remaining())
+		}
+	}
+}
+
+func TestScriptedExhausted(t *testing.T) {
+	if uuid.FIPS {
+		t.Skip("NewV3 and NewV5 are disabled by the uuid_fips build tag")
+	}
+	s := NewScripted(scriptedUUIDs[0])
+	s.NewV4()
+	if u, err := s.NewV7(); !errors.Is(err, ErrExhausted) || u != uuid.Nil {
+		t.Errorf("NewV7() = %v, %v, want %v", u, err, ErrExhausted)
+	}
+	func() {
+		defer func() {
+			err, _ := recover().(error)
+			if !errors.Is(err, ErrExhausted) {
+				t.Errorf("NewV5() panicked with %v, want %v", err, ErrExhausted)
+			}
+		}()
+		s.NewV5(uuid.NamespaceDNS, "a")
+	}()
+
+	s = NewScripted(scriptedUUIDs[0]).PanicWhenExhausted()
+	s.NewV4()
+	func() {
+		defer func() {
+			if r := recover(); r != ErrExhausted {
+				t.Errorf("NewV4() panicked with %v, want %v", r, ErrExhausted)
+			}
+		}()
+		s.NewV4()
+	}()
+
+	s = NewScripted(scriptedUUIDs...).CycleWhenExhausted()
+	for i := 0; i < 2*len(scriptedUUIDs); i++ {
+		if u, err := s.NewV4(); err != nil || u != scriptedUUIDs[i%len(scriptedUUIDs)] {
+			t.Errorf("NewV4() call %d = %v, %v, want %v", i, u, err, scriptedUUIDs[i%len(scriptedUUIDs)])
+		}
+	}
+	if _, err := NewScripted().CycleWhenExhausted().NewV4(); !errors.Is(err, ErrExhausted) {
+		t.Errorf("empty cycling NewV4() error = %v, want %v", err, ErrExhausted)
+	}
+	if u, err := NewScripted().FallBackTo(nil).NewV4(); !errors.Is(err, ErrExhausted) {
+		t.Errorf("NewV4() falling back to nil = %v, %v, want %v", u, err, ErrExhausted)
+	}
+
+	seq := NewSequential(uuid.Nil)
+	s = NewScripted(scriptedUUIDs[0]).FallBackTo(seq)
+	s.NewV4()
+	for _, gen := range all(s) {
+		if _, err := gen(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The 7 UUIDs not name-based were generated by the fall-back generator.
+	if u, _ := seq.NewV4(); u.String() != "00000000-0000-4000-8000-000000000007" {
+		t.Errorf("fall-back generator returned %v after the scripted generator was exhausted", u)
+	}
+	if got, want := s.NewV5(uuid.NamespaceDNS, "a"), uuid.NewV5(uuid.NamespaceDNS, "a"); got != want {
+		t.Errorf("fall-back NewV5() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,102 @@
+// Package uuidtest provides UUID generators for tests of code using the
+// uuid.Generator interface of github.com/gofrs/uuid/v5, which produce
+// predictable UUIDs, so that tests can compare results with golden files or
+// expected values, and assertions on the UUIDs generated by the code under
+// test.
+package uuidtest
+
+import (
+	"math/bits"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Sequential is a uuid.Generator returning consecutive UUIDs.
+//
+// Every UUID generated, of any version, is the next integer value of a
+// single sequence, with the version and variant bits of the requested
+// version set: with a start of uuid.Nil, NewV4 and then NewV7 return
+//
+//	00000000-0000-4000-8000-000000000000
+//	00000000-0000-7000-8000-000000000001
+//
+// The times of time-based UUIDs are ignored, and their timestamps not
+// meaningful. Name-based UUIDs are already deterministic, and NewV3 and NewV5
+// return the UUIDs of the namespace and name without consuming the sequence.
+//
+// Sequential is safe for concurrent use, although the order in which
+// concurrent calls receive UUIDs is not predictable.
+type Sequential struct {
+	mu     sync.Mutex
+	hi, lo uint64
+}
+
+var _ uuid.Generator = (*Sequential)(nil)
+
+// NewSequential returns a Sequential generator whose sequence starts at
+// start.
+func NewSequential(start uuid.UUID) *Sequential {
+	hi, lo := start.Uint64Pair()
+	return &Sequential{hi: hi, lo: lo}
+}
+
+// next returns the next UUID of the sequence with the given version.
+func (s *Sequential) next(version byte) uuid.UUID {
+	s.mu.Lock()
+	u := uuid.FromUint64Pair(s.hi, s.lo)
+	var carry uint64
+	s.lo, carry = bits.Add64(s.lo, 1, 0)
+	s.hi += carry
+	s.mu.Unlock()
+
+	u.SetVersion(version)
+	u.SetVariant(uuid.VariantRFC9562)
+	return u
+}
+
+// NewV1 returns the next UUID of the sequence, as a version 1 UUID.
+func (s *Sequential) NewV1() (uuid.UUID, error) {
+	return s.next(uuid.V1), nil
+}
+
+// NewV1AtTime returns the next UUID of the sequence, as a version 1 UUID.
+func (s *Sequential) NewV1AtTime(time.Time) (uuid.UUID, error) {
+	return s.next(uuid.V1), nil
+}
+
+// NewV3 returns the version 3 UUID of ns and name.
+func (s *Sequential) NewV3(ns uuid.UUID, name string) uuid.UUID {
+	return uuid.NewV3(ns, name)
+}
+
+// NewV4 returns the next UUID of the sequence, as a version 4 UUID.
+func (s *Sequential) NewV4() (uuid.UUID, error) {
+	return s.next(uuid.V4), nil
+}
+
+// NewV5 returns the version 5 UUID of ns and name.
+func (s *Sequential) NewV5(ns uuid.UUID, name string) uuid.UUID {
+	return uuid.NewV5(ns, name)
+}
+
+// NewV6 returns the next UUID of the sequence, as a version 6 UUID.
+func (s *Sequential) NewV6() (uuid.UUID, error) {
+	return s.next(uuid.V6), nil
+}
+
+// NewV6AtTime returns the next UUID of the sequence, as a version 6 UUID.
+func (s *Sequential) NewV6AtTime(time.Time) (uuid.UUID, error) {
+	return s.next(uuid.V6), nil
+}
+
+// NewV7 returns the next UUID of the sequence, as a version 7 UUID.
+func (s *Sequential) NewV7() (uuid.UUID, error) {
+	return s.next(uuid.V7), nil
+}
+
+// NewV7AtTime returns the next UUID of the sequence, as a version 7 UUID.
+func (s *Sequential) NewV7AtTime(time.Time) (uuid.UUID, error) {
+	return s.next(uuid.V7), nil
+}
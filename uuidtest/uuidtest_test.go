@@ -0,0 +1,93 @@
+package uuidtest
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+func TestSequential(t *testing.T) {
+	if uuid.FIPS {
+		t.Skip("NewV3 and NewV5 are disabled by the uuid_fips build tag")
+	}
+	s := NewSequential(uuid.Nil)
+	gens := []func() (uuid.UUID, error){
+		s.NewV4,
+		s.NewV7,
+		s.NewV1,
+		s.NewV6,
+		func() (uuid.UUID, error) { return s.NewV1AtTime(time.Now()) },
+		func() (uuid.UUID, error) { return s.NewV6AtTime(time.Now()) },
+		func() (uuid.UUID, error) { return s.NewV7AtTime(time.Now()) },
+	}
+	want := []string{
+		"00000000-0000-4000-8000-000000000000",
+		"00000000-0000-7000-8000-000000000001",
+		"00000000-0000-1000-8000-000000000002",
+		"00000000-0000-6000-8000-000000000003",
+		"00000000-0000-1000-8000-000000000004",
+		"00000000-0000-6000-8000-000000000005",
+		"00000000-0000-7000-8000-000000000006",
+	}
+	for i, gen := range gens {
+		u, err := gen()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.String() != want[i] {
+			t.Errorf("UUID %d = %v, want %s", i, u, want[i])
+		}
+	}
+
+	if got, want := s.NewV5(uuid.NamespaceDNS, "a"), uuid.NewV5(uuid.NamespaceDNS, "a"); got != want {
+		t.Errorf("NewV5() = %v, want %v", got, want)
+	}
+	if got, want := s.NewV3(uuid.NamespaceDNS, "a"), uuid.NewV3(uuid.NamespaceDNS, "a"); got != want {
+		t.Errorf("NewV3() = %v, want %v", got, want)
+	}
+	if u, _ := s.NewV4(); u.String() != "00000000-0000-4000-8000-000000000007" {
+		t.Errorf("NewV4() after name-based UUIDs = %v", u)
+	}
+}
+
+func TestSequentialCarry(t *testing.T) {
+	s := NewSequential(uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-ffffffffffff")))
+	for _, want := range []string{
+		"6ba7b810-9dad-41d1-80b4-ffffffffffff",
+		"6ba7b810-9dad-41d1-80b5-000000000000",
+	} {
+		if u, _ := s.NewV4(); u.String() != want {
+			t.Errorf("NewV4() = %v, want %s", u, want)
+		}
+	}
+
+	s = NewSequential(uuid.Max)
+	s.NewV4()
+	if u, _ := s.NewV4(); u.String() != "00000000-0000-4000-8000-000000000000" {
+		t.Errorf("NewV4() after Max = %v, want the sequence to wrap", u)
+	}
+}
+
+func TestSequentialConcurrent(t *testing.T) {
+	s := NewSequential(uuid.Nil)
+	const n = 100
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	seen := make(map[uuid.UUID]bool)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			u, _ := s.NewV7()
+			mu.Lock()
+			seen[u] = true
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	if len(seen) != n {
+		t.Errorf("generated %d distinct UUIDs, want %d", len(seen), n)
+	}
+}
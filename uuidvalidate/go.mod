@@ -0,0 +1,20 @@
+module github.com/gofrs/uuid/v5/uuidvalidate
+
+go 1.26.0
+
+require (
+	github.com/go-playground/validator/v10 v10.30.5
+	github.com/gofrs/uuid/v5 v5.0.0
+)
+
+require (
+	github.com/gabriel-vasile/mimetype v1.4.15 // indirect
+	github.com/go-playground/locales v0.14.1 // indirect
+	github.com/go-playground/universal-translator v0.18.1 // indirect
+	github.com/leodido/go-urn v1.5.0 // indirect
+	golang.org/x/crypto v0.57.0 // indirect
+	golang.org/x/sys v0.48.0 // indirect
+	golang.org/x/text v0.42.0 // indirect
+)
+
+replace github.com/gofrs/uuid/v5 => ../
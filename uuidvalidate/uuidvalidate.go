@@ -0,0 +1,117 @@
+// Package uuidvalidate validates UUIDs with
+// github.com/go-playground/validator/v10, using the parser of
+// github.com/gofrs/uuid/v5, so that the values accepted by validation are
+// those later accepted by uuid.FromString.
+//
+// Register replaces the validator's own uuid tags, which match the
+// canonical form with regular expressions, with the following tags:
+//
+//	uuid      any text form accepted by uuid.FromString
+//	uuid_rfc  a UUID of the RFC 9562 variant
+//	uuid1 to uuid8
+//	          a UUID of the RFC 9562 variant and the given version
+//
+// The tags apply to string, []byte, uuid.UUID and uuid.NullUUID fields, and
+// to pointers to them. Register also makes the validator validate the UUID
+// of a valid uuid.NullUUID, and treat an invalid one as nil, so that it can
+// be combined with omitempty:
+//
+//	type Request struct {
+//		OrderID  string        `validate:"uuid7"`
+//		ParentID uuid.NullUUID `validate:"omitempty,uuid_rfc"`
+//	}
+package uuidvalidate
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofrs/uuid/v5"
+)
+
+// Tag names registered by Register.
+const (
+	TagUUID = "uuid"
+	TagRFC  = "uuid_rfc"
+)
+
+// VersionTag returns the tag name registered by Register for the given
+// version, such as "uuid7".
+func VersionTag(version byte) string {
+	return fmt.Sprintf("uuid%d", version)
+}
+
+var tUUID = reflect.TypeOf(uuid.UUID{})
+
+// fieldUUID returns the UUID held by a field, and false if the field does
+// not hold a UUID.
+func fieldUUID(field reflect.Value) (uuid.UUID, bool) {
+	if !field.IsValid() {
+		return uuid.Nil, false
+	}
+	if field.Type() == tUUID {
+		return field.Interface().(uuid.UUID), true
+	}
+	var u uuid.UUID
+	var err error
+	switch field.Kind() {
+	case reflect.String:
+		err = u.UnmarshalText([]byte(field.String()))
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.Uint8 {
+			return uuid.Nil, false
+		}
+		err = u.UnmarshalText(field.Bytes())
+	default:
+		return uuid.Nil, false
+	}
+	return u, err == nil
+}
+
+// IsUUID is the validator.Func of the uuid tag.
+func IsUUID(fl validator.FieldLevel) bool {
+	_, ok := fieldUUID(fl.Field())
+	return ok
+}
+
+// IsRFC is the validator.Func of the uuid_rfc tag.
+func IsRFC(fl validator.FieldLevel) bool {
+	u, ok := fieldUUID(fl.Field())
+	return ok && u.Variant() == uuid.VariantRFC9562
+}
+
+// IsVersion returns the validator.Func of the tag of the given version.
+func IsVersion(version byte) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		u, ok := fieldUUID(fl.Field())
+		return ok && u.Variant() == uuid.VariantRFC9562 && u.Version() == version
+	}
+}
+
+// nullUUIDValue is the validator.CustomTypeFunc of uuid.NullUUID.
+func nullUUIDValue(field reflect.Value) interface{} {
+	nu := field.Interface().(uuid.NullUUID)
+	if !nu.Valid {
+		return nil
+	}
+	return nu.UUID
+}
+
+// Register registers the tags of this package, and a custom type function
+// for uuid.NullUUID, with v.
+func Register(v *validator.Validate) error {
+	v.RegisterCustomTypeFunc(nullUUIDValue, uuid.NullUUID{})
+	if err := v.RegisterValidation(TagUUID, IsUUID); err != nil {
+		return err
+	}
+	if err := v.RegisterValidation(TagRFC, IsRFC); err != nil {
+		return err
+	}
+	for version := byte(1); version <= 8; version++ {
+		if err := v.RegisterValidation(VersionTag(version), IsVersion(version)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
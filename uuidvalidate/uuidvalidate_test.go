@@ -0,0 +1,112 @@
+package uuidvalidate
+
+import (
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofrs/uuid/v5"
+)
+
+var testUUID = uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+
+func newValidate(t *testing.T) *validator.Validate {
+	t.Helper()
+	v := validator.New()
+	if err := Register(v); err != nil {
+		t.Fatalf("Register() unexpected error: %v", err)
+	}
+	return v
+}
+
+func TestVar(t *testing.T) {
+	v := newValidate(t)
+	v4 := uuid.Must(uuid.NewV4())
+	v7 := uuid.Must(uuid.NewV7())
+	ncs := uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-00b4-00c04fd430c8"))
+
+	tests := []struct {
+		field interface{}
+		tag   string
+		want  bool
+	}{
+		{testUUID.String(), "uuid", true},
+		{"{" + testUUID.String() + "}", "uuid", true},
+		{"urn:uuid:" + testUUID.String(), "uuid", true},
+		{"6ba7b8109dad11d180b400c04fd430c8", "uuid", true},
+		{[]byte(testUUID.String()), "uuid", true},
+		{testUUID, "uuid", true},
+		{"not-a-uuid", "uuid", false},
+		{"", "uuid", false},
+		{42, "uuid", false},
+
+		{testUUID.String(), "uuid_rfc", true},
+		{ncs.String(), "uuid_rfc", false},
+		{ncs.String(), "uuid", true},
+
+		{testUUID.String(), "uuid1", true},
+		{testUUID.String(), "uuid4", false},
+		{v4.String(), "uuid4", true},
+		{v4, "uuid4", true},
+		{v4, "uuid7", false},
+		{v7.String(), "uuid7", true},
+		{v7, "uuid7", true},
+		{&v7, "uuid7", true},
+		{"not-a-uuid", "uuid7", false},
+		{uuid.Nil.String(), "uuid", true},
+		{uuid.Nil.String(), "uuid_rfc", false},
+		{uuid.Max, "uuid7", false},
+
+		{uuid.NullUUID{UUID: v7, Valid: true}, "uuid7", true},
+		{uuid.NullUUID{UUID: v4, Valid: true}, "uuid7", false},
+		{uuid.NullUUID{UUID: v7}, "uuid7", false},
+		{uuid.NullUUID{UUID: v7}, "omitempty,uuid7", true},
+		{"", "omitempty,uuid7", true},
+	}
+	for _, tt := range tests {
+		err := v.Var(tt.field, tt.tag)
+		if got := err == nil; got != tt.want {
+			t.Errorf("Var(%#v, %q) = %v, want valid %t", tt.field, tt.tag, err, tt.want)
+		}
+	}
+}
+
+func TestStruct(t *testing.T) {
+	type request struct {
+		OrderID  string        `validate:"uuid7"`
+		ParentID uuid.NullUUID `validate:"omitempty,uuid_rfc"`
+		TraceID  *uuid.UUID    `validate:"omitempty,uuid4"`
+	}
+	v := newValidate(t)
+
+	v4 := uuid.Must(uuid.NewV4())
+	valid := request{
+		OrderID:  uuid.Must(uuid.NewV7()).String(),
+		ParentID: uuid.NullUUID{UUID: testUUID, Valid: true},
+		TraceID:  &v4,
+	}
+	if err := v.Struct(valid); err != nil {
+		t.Errorf("Struct(%+v) unexpected error: %v", valid, err)
+	}
+	if err := v.Struct(request{OrderID: valid.OrderID}); err != nil {
+		t.Errorf("Struct() with empty optional fields unexpected error: %v", err)
+	}
+
+	invalid := request{OrderID: v4.String(), TraceID: &testUUID}
+	err := v.Struct(invalid)
+	errs, ok := err.(validator.ValidationErrors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("Struct(%+v) = %v, want 2 validation errors", invalid, err)
+	}
+	if errs[0].Field() != "OrderID" || errs[0].Tag() != "uuid7" {
+		t.Errorf("first error = %v, want OrderID failing uuid7", errs[0])
+	}
+	if errs[1].Field() != "TraceID" || errs[1].Tag() != "uuid4" {
+		t.Errorf("second error = %v, want TraceID failing uuid4", errs[1])
+	}
+}
+
+func TestVersionTag(t *testing.T) {
+	if got := VersionTag(uuid.V7); got != "uuid7" {
+		t.Errorf("VersionTag(7) = %q, want %q", got, "uuid7")
+	}
+}
@@ -0,0 +1,152 @@
+package uuid
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// v7ListMagic starts every stream written by V7ListEncoder. The last byte
+// is the format version.
+var v7ListMagic = [5]byte{'U', 'V', '7', 'L', 1}
+
+// V7ListEncoder writes a sorted list of version 7 UUIDs in a compact binary
+// form: each UUID is stored as the difference between its 48-bit millisecond
+// timestamp and the previous UUID's, as an unsigned varint, followed by its
+// remaining 10 bytes. UUIDs generated close together therefore take about 11
+// bytes instead of 16 (or 36 as text). Any UUID can be encoded, but the
+// timestamps must be non-decreasing, as they are in a sorted list.
+//
+// The encoder buffers its output; Close must be called to flush it.
+type V7ListEncoder struct {
+	w       *bufio.Writer
+	started bool
+	last    uint64
+	buf     [binary.MaxVarintLen64 + v7SuffixSize]byte
+}
+
+// NewV7ListEncoder returns an encoder writing to w.
+func NewV7ListEncoder(w io.Writer) *V7ListEncoder {
+	return &V7ListEncoder{w: bufio.NewWriter(w)}
+}
+
+// Encode writes u to the stream. It will return an error if u's timestamp is
+// before the timestamp of the previously encoded UUID.
+func (e *V7ListEncoder) Encode(u UUID) error {
+	if !e.started {
+		if _, err := e.w.Write(v7ListMagic[:]); err != nil {
+			return err
+		}
+		e.started = true
+	}
+	ms, suffix := v7SetKey(u)
+	if ms < e.last {
+		return fmt.Errorf("%w: %s is out of order in a V7 list", ErrInvalidArgument, u)
+	}
+	n := binary.PutUvarint(e.buf[:], ms-e.last)
+	n += copy(e.buf[n:], suffix)
+	if _, err := e.w.Write(e.buf[:n]); err != nil {
+		return err
+	}
+	e.last = ms
+	return nil
+}
+
+// Close writes the stream header if no UUIDs were encoded and flushes any
+// buffered data to the underlying writer. It does not close that writer.
+func (e *V7ListEncoder) Close() error {
+	if !e.started {
+		if _, err := e.w.Write(v7ListMagic[:]); err != nil {
+			return err
+		}
+		e.started = true
+	}
+	return e.w.Flush()
+}
+
+// V7ListDecoder reads a list of UUIDs written by V7ListEncoder.
+type V7ListDecoder struct {
+	r       *bufio.Reader
+	started bool
+	last    uint64
+}
+
+// NewV7ListDecoder returns a decoder reading from r.
+func NewV7ListDecoder(r io.Reader) *V7ListDecoder {
+	return &V7ListDecoder{r: bufio.NewReader(r)}
+}
+
+// Decode returns the next UUID in the stream. It returns io.EOF when the
+// stream ends cleanly, and io.ErrUnexpectedEOF if it ends within a UUID.
+func (d *V7ListDecoder) Decode() (UUID, error) {
+	if !d.started {
+		var magic [len(v7ListMagic)]byte
+		if _, err := io.ReadFull(d.r, magic[:]); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return Nil, err
+		}
+		if magic != v7ListMagic {
+			return Nil, fmt.Errorf("%w: unsupported V7 list encoding", ErrInvalidFormat)
+		}
+		d.started = true
+	}
+	delta, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		if err != io.EOF && !errors.Is(err, io.ErrUnexpectedEOF) {
+			err = fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+		}
+		return Nil, err
+	}
+	ms := d.last + delta
+	if ms < d.last || ms > maxV7Millis {
+		return Nil, fmt.Errorf("%w: V7 list timestamp out of range", ErrInvalidFormat)
+	}
+	var u UUID
+	putV7Millis(&u, ms)
+	if _, err := io.ReadFull(d.r, u[6:]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return Nil, err
+	}
+	d.last = ms
+	return u, nil
+}
+
+// EncodeV7List returns the V7ListEncoder encoding of uuids, which must be
+// in non-decreasing timestamp order.
+func EncodeV7List(uuids []UUID) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.Grow(len(v7ListMagic) + len(uuids)*(1+v7SuffixSize))
+	e := NewV7ListEncoder(&buf)
+	for _, u := range uuids {
+		if err := e.Encode(u); err != nil {
+			return nil, err
+		}
+	}
+	if err := e.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeV7List decodes all UUIDs from data written by V7ListEncoder.
+func DecodeV7List(data []byte) ([]UUID, error) {
+	d := NewV7ListDecoder(bytes.NewReader(data))
+	var uuids []UUID
+	for {
+		u, err := d.Decode()
+		if err == io.EOF {
+			return uuids, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		uuids = append(uuids, u)
+	}
+}
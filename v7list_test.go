@@ -0,0 +1,128 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestV7List(t testing.TB, n int) []UUID {
+	t.Helper()
+	g := NewGenWithOptions(WithCustomPRNG(1))
+	start := time.UnixMilli(1700000000000)
+	uuids := make([]UUID, n)
+	for i := range uuids {
+		u, err := g.NewV7AtTime(start.Add(time.Duration(i/3) * time.Millisecond))
+		if err != nil {
+			t.Fatal(err)
+		}
+		uuids[i] = u
+	}
+	Sort(uuids)
+	return uuids
+}
+
+func TestV7List(t *testing.T) {
+	uuids := newTestV7List(t, 1000)
+	data, err := EncodeV7List(uuids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The first timestamp is stored in full, the others as 1-byte deltas.
+	if max := len(v7ListMagic) + binary.MaxVarintLen64 + len(uuids)*(1+v7SuffixSize); len(data) > max {
+		t.Errorf("EncodeV7List encoded %d UUIDs in %d bytes, want at most %d", len(uuids), len(data), max)
+	}
+	got, err := DecodeV7List(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, uuids) {
+		t.Errorf("DecodeV7List(EncodeV7List(uuids)) differs from uuids")
+	}
+}
+
+func TestV7ListEmpty(t *testing.T) {
+	data, err := EncodeV7List(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, v7ListMagic[:]) {
+		t.Errorf("EncodeV7List(nil) = %x, want %x", data, v7ListMagic)
+	}
+	got, err := DecodeV7List(data)
+	if err != nil || len(got) != 0 {
+		t.Errorf("DecodeV7List(%x) = %v, %v, want empty", data, got, err)
+	}
+}
+
+func TestV7ListStreaming(t *testing.T) {
+	uuids := newTestV7List(t, 100)
+	uuids = append([]UUID{Nil}, append(uuids, Max)...)
+	var buf bytes.Buffer
+	e := NewV7ListEncoder(&buf)
+	for _, u := range uuids {
+		if err := e.Encode(u); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := e.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewV7ListDecoder(&buf)
+	for i, want := range uuids {
+		got, err := d.Decode()
+		if err != nil {
+			t.Fatalf("Decode() #%d: %v", i, err)
+		}
+		if got != want {
+			t.Errorf("Decode() #%d = %v, want %v", i, got, want)
+		}
+	}
+	if _, err := d.Decode(); err != io.EOF {
+		t.Errorf("Decode() at end of stream error = %v, want io.EOF", err)
+	}
+}
+
+func TestV7ListErrors(t *testing.T) {
+	uuids := newTestV7List(t, 4)
+	if _, err := EncodeV7List([]UUID{uuids[3], uuids[0]}); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("EncodeV7List(unsorted) error = %v, want %v", err, ErrInvalidArgument)
+	}
+
+	data, err := EncodeV7List(uuids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{name: "Empty", data: nil, want: io.ErrUnexpectedEOF},
+		{name: "Magic", data: append([]byte("UV7X\x01"), data[5:]...), want: ErrInvalidFormat},
+		{name: "Truncated", data: data[:len(data)-1], want: io.ErrUnexpectedEOF},
+		{name: "Overflow", data: append(append([]byte(nil), v7ListMagic[:]...), 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01), want: ErrInvalidFormat},
+	}
+	for _, tt := range tests {
+		if _, err := DecodeV7List(tt.data); !errors.Is(err, tt.want) {
+			t.Errorf("%s: DecodeV7List() error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func BenchmarkV7ListEncoder(b *testing.B) {
+	uuids := newTestV7List(b, 10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		e := NewV7ListEncoder(io.Discard)
+		for _, u := range uuids {
+			e.Encode(u)
+		}
+		e.Close()
+	}
+}
@@ -0,0 +1,64 @@
+package uuid
+
+import "time"
+
+// maxV7Millis is the largest Unix millisecond timestamp representable in the
+// 48-bit timestamp of a version 7 UUID.
+const maxV7Millis = 1<<48 - 1
+
+// v7Millis returns the Unix millisecond timestamp of t, clamped to the range
+// representable in a version 7 UUID.
+func v7Millis(t time.Time) uint64 {
+	ms := t.UnixMilli()
+	switch {
+	case ms < 0:
+		return 0
+	case ms > maxV7Millis:
+		return maxV7Millis
+	}
+	return uint64(ms)
+}
+
+// putV7Millis sets the 48-bit timestamp of a version 7 UUID.
+func putV7Millis(u *UUID, ms uint64) {
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+}
+
+// MinV7AtTime returns the smallest version 7 UUID, in byte order, whose
+// timestamp is the millisecond containing t. Times before the Unix epoch or
+// beyond the 48-bit timestamp range are clamped to it.
+func MinV7AtTime(t time.Time) UUID {
+	var u UUID
+	putV7Millis(&u, v7Millis(t))
+	u.SetVersion(V7)
+	u.SetVariant(VariantRFC9562)
+	return u
+}
+
+// MaxV7AtTime returns the largest version 7 UUID, in byte order, whose
+// timestamp is the millisecond containing t. Times before the Unix epoch or
+// beyond the 48-bit timestamp range are clamped to it.
+func MaxV7AtTime(t time.Time) UUID {
+	u := Max
+	putV7Millis(&u, v7Millis(t))
+	u.SetVersion(V7)
+	u.SetVariant(VariantRFC9562)
+	return u
+}
+
+// V7RangeForTime returns bounds for selecting the version 7 UUIDs created in
+// the time window [from, to), at millisecond precision: a UUID u was created
+// in the window if lo <= u < hi in byte order. This lets queries such as
+//
+//	WHERE id >= lo AND id < hi
+//
+// use an index on a UUID primary key instead of a separate timestamp column.
+// The window is empty if to is not after from.
+func V7RangeForTime(from, to time.Time) (lo, hi UUID) {
+	return MinV7AtTime(from), MinV7AtTime(to)
+}
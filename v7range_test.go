@@ -0,0 +1,68 @@
+package uuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMinMaxV7AtTime(t *testing.T) {
+	at := time.UnixMilli(1700000000123).Add(456 * time.Microsecond)
+	min, max := MinV7AtTime(at), MaxV7AtTime(at)
+	if want := Must(FromString("018bcfe5-687b-7000-8000-000000000000")); min != want {
+		t.Errorf("MinV7AtTime(%v) = %v, want %v", at, min, want)
+	}
+	if want := Must(FromString("018bcfe5-687b-7fff-bfff-ffffffffffff")); max != want {
+		t.Errorf("MaxV7AtTime(%v) = %v, want %v", at, max, want)
+	}
+	for _, u := range []UUID{min, max} {
+		if u.Version() != V7 || u.Variant() != VariantRFC9562 {
+			t.Errorf("%v is not an RFC 9562 version 7 UUID", u)
+		}
+	}
+
+	g := NewGen()
+	for i := 0; i < 100; i++ {
+		u := Must(g.NewV7AtTime(at))
+		if u.Compare(min) < 0 || u.Compare(max) > 0 {
+			t.Errorf("NewV7AtTime(%v) = %v, outside [%v, %v]", at, u, min, max)
+		}
+	}
+}
+
+func TestMinMaxV7AtTimeClamp(t *testing.T) {
+	before := time.Unix(-1, 0)
+	if got, want := MinV7AtTime(before), MinV7AtTime(time.UnixMilli(0)); got != want {
+		t.Errorf("MinV7AtTime(%v) = %v, want %v", before, got, want)
+	}
+	after := time.UnixMilli(maxV7Millis + 1)
+	if got, want := MaxV7AtTime(after), MaxV7AtTime(time.UnixMilli(maxV7Millis)); got != want {
+		t.Errorf("MaxV7AtTime(%v) = %v, want %v", after, got, want)
+	}
+}
+
+func TestV7RangeForTime(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(time.Hour)
+	lo, hi := V7RangeForTime(from, to)
+
+	g := NewGen()
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{at: from.Add(-time.Millisecond), want: false},
+		{at: from, want: true},
+		{at: from.Add(30 * time.Minute), want: true},
+		{at: to.Add(-time.Millisecond), want: true},
+		{at: to, want: false},
+		{at: to.Add(time.Millisecond), want: false},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 10; i++ {
+			u := Must(g.NewV7AtTime(tt.at))
+			if got := u.Compare(lo) >= 0 && u.Compare(hi) < 0; got != tt.want {
+				t.Errorf("V7 UUID at %v in [%v, %v) = %t, want %t", tt.at, lo, hi, got, tt.want)
+			}
+		}
+	}
+}
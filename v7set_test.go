@@ -0,0 +1,255 @@
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func newTestV7Set(t testing.TB, n int) (*V7Set, []UUID) {
+	t.Helper()
+	g := NewGenWithOptions(WithCustomPRNG(1))
+	start := time.UnixMilli(1700000000000)
+	s := NewV7Set()
+	uuids := make([]UUID, n)
+	for i := range uuids {
+		u, err := g.NewV7AtTime(start.Add(time.Duration(i/4) * time.Millisecond))
+		if err != nil {
+			t.Fatal(err)
+		}
+		uuids[i] = u
+		if !s.Add(u) {
+			t.Fatalf("Add(%v) = false for a new member", u)
+		}
+	}
+	return s, uuids
+}
+
+func TestV7Set(t *testing.T) {
+	t.Run("AddContains", testV7SetAddContains)
+	t.Run("Remove", testV7SetRemove)
+	t.Run("RemoveBefore", testV7SetRemoveBefore)
+	t.Run("Range", testV7SetRange)
+	t.Run("Memory", testV7SetMemory)
+	t.Run("Binary", testV7SetBinary)
+	t.Run("BinaryErrors", testV7SetBinaryErrors)
+}
+
+func testV7SetAddContains(t *testing.T) {
+	s, uuids := newTestV7Set(t, 100)
+	if s.Len() != len(uuids) {
+		t.Errorf("Len() = %d, want %d", s.Len(), len(uuids))
+	}
+	for _, u := range uuids {
+		if !s.Contains(u) {
+			t.Errorf("Contains(%v) = false, want true", u)
+		}
+		if s.Add(u) {
+			t.Errorf("Add(%v) = true for an existing member", u)
+		}
+	}
+	for _, u := range []UUID{Nil, Max, Must(NewV7())} {
+		if s.Contains(u) {
+			t.Errorf("Contains(%v) = true, want false", u)
+		}
+	}
+	if s.Len() != len(uuids) {
+		t.Errorf("Len() = %d after re-adding members, want %d", s.Len(), len(uuids))
+	}
+}
+
+func testV7SetRemove(t *testing.T) {
+	s, uuids := newTestV7Set(t, 20)
+	for i, u := range uuids {
+		if !s.Remove(u) {
+			t.Errorf("Remove(%v) = false, want true", u)
+		}
+		if s.Remove(u) {
+			t.Errorf("second Remove(%v) = true, want false", u)
+		}
+		if s.Contains(u) {
+			t.Errorf("Contains(%v) = true after Remove", u)
+		}
+		if want := len(uuids) - i - 1; s.Len() != want {
+			t.Errorf("Len() = %d, want %d", s.Len(), want)
+		}
+	}
+	if len(s.chunks) != 0 {
+		t.Errorf("%d chunks left after removing every member", len(s.chunks))
+	}
+}
+
+func testV7SetRemoveBefore(t *testing.T) {
+	s, uuids := newTestV7Set(t, 40)
+	preEpoch := time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC)
+	if got := s.RemoveBefore(preEpoch); got != 0 || s.Len() != 40 {
+		t.Errorf("RemoveBefore(%v) = %d, Len() = %d, want 0, 40", preEpoch, got, s.Len())
+	}
+	cutoff := time.UnixMilli(1700000000000 + 5)
+	if got := s.RemoveBefore(cutoff); got != 20 {
+		t.Errorf("RemoveBefore(%v) = %d, want 20", cutoff, got)
+	}
+	if s.Len() != 20 {
+		t.Errorf("Len() = %d, want 20", s.Len())
+	}
+	for i, u := range uuids {
+		if got, want := s.Contains(u), i >= 20; got != want {
+			t.Errorf("Contains(%v) = %t, want %t", u, got, want)
+		}
+	}
+
+	// UUIDs a minute apart fall in different chunks, removed in whole or in
+	// part.
+	s = NewV7Set()
+	start := time.UnixMilli(1700000000000)
+	for i := 0; i < 5; i++ {
+		s.Add(MinV7AtTime(start.Add(time.Duration(i) * time.Minute)))
+		s.Add(MaxV7AtTime(start.Add(time.Duration(i) * time.Minute)))
+	}
+	cutoff = start.Add(2*time.Minute + time.Millisecond)
+	if got := s.RemoveBefore(cutoff); got != 6 || s.Len() != 4 {
+		t.Errorf("RemoveBefore(%v) = %d, Len() = %d, want 6, 4", cutoff, got, s.Len())
+	}
+	if !s.Contains(MinV7AtTime(start.Add(3*time.Minute))) || s.Contains(MaxV7AtTime(start.Add(2*time.Minute))) {
+		t.Errorf("RemoveBefore(%v) removed the wrong UUIDs", cutoff)
+	}
+}
+
+func testV7SetMemory(t *testing.T) {
+	// Five UUIDs per millisecond, a usual rate, take at most 16 bytes each.
+	const n = 100000
+	g := NewGenWithOptions(WithCustomPRNG(1))
+	start := time.UnixMilli(1700000000000)
+	uuids := make([]UUID, n)
+	for i := range uuids {
+		uuids[i] = Must(g.NewV7AtTime(start.Add(time.Duration(i/5) * time.Millisecond)))
+	}
+	heapAlloc := func() uint64 {
+		var m runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&m)
+		return m.HeapAlloc
+	}
+	before := heapAlloc()
+	s := NewV7Set()
+	for _, u := range uuids {
+		s.Add(u)
+	}
+	after := heapAlloc()
+	runtime.KeepAlive(uuids)
+	runtime.KeepAlive(s)
+	if perMember := float64(after-before) / n; perMember > 16 {
+		t.Errorf("V7Set uses %.1f bytes per member, want at most 16", perMember)
+	}
+}
+
+func testV7SetRange(t *testing.T) {
+	s, uuids := newTestV7Set(t, 50)
+	s.Add(Nil)
+	s.Add(Max)
+	var got []UUID
+	s.Range(func(u UUID) bool {
+		got = append(got, u)
+		return true
+	})
+	if len(got) != len(uuids)+2 {
+		t.Fatalf("Range visited %d UUIDs, want %d", len(got), len(uuids)+2)
+	}
+	if got[0] != Nil || got[len(got)-1] != Max {
+		t.Errorf("Range visited %v first and %v last, want %v and %v", got[0], got[len(got)-1], Nil, Max)
+	}
+	for i := 1; i < len(got); i++ {
+		if bytes.Compare(got[i-1][:], got[i][:]) >= 0 {
+			t.Fatalf("Range visited %v before %v", got[i-1], got[i])
+		}
+	}
+
+	visited := 0
+	s.Range(func(UUID) bool {
+		visited++
+		return visited < 3
+	})
+	if visited != 3 {
+		t.Errorf("Range visited %d UUIDs after stopping at 3", visited)
+	}
+}
+
+func testV7SetBinary(t *testing.T) {
+	s, uuids := newTestV7Set(t, 1000)
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if max := len(uuids) * 12; len(data) > max {
+		t.Errorf("MarshalBinary() encoded %d UUIDs in %d bytes, want at most %d", len(uuids), len(data), max)
+	}
+	got := NewV7Set()
+	got.Add(Max)
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if got.Len() != len(uuids) {
+		t.Errorf("Len() = %d after UnmarshalBinary, want %d", got.Len(), len(uuids))
+	}
+	for _, u := range uuids {
+		if !got.Contains(u) {
+			t.Errorf("Contains(%v) = false after UnmarshalBinary", u)
+		}
+	}
+	if got.Contains(Max) {
+		t.Errorf("UnmarshalBinary did not replace the existing members")
+	}
+
+	empty, err := NewV7Set().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := got.UnmarshalBinary(empty); err != nil || got.Len() != 0 {
+		t.Errorf("UnmarshalBinary(%x) = %v, Len() = %d", empty, err, got.Len())
+	}
+}
+
+func testV7SetBinaryErrors(t *testing.T) {
+	s, _ := newTestV7Set(t, 8)
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsorted := NewV7Set()
+	unsorted.Add(UUID{15: 1})
+	unsorted.Add(UUID{15: 2})
+	bad, _ := unsorted.MarshalBinary()
+	bad[len(bad)-1], bad[len(bad)-1-v7SuffixSize] = bad[len(bad)-1-v7SuffixSize], bad[len(bad)-1]
+
+	tests := map[string][]byte{
+		"Empty":     nil,
+		"Version":   append([]byte{0}, data[1:]...),
+		"Truncated": data[:len(data)-1],
+		"Trailing":  append(append([]byte(nil), data...), 0),
+		"Unsorted":  bad,
+		"Varint":    {v7SetFormatVersion, 0x80},
+	}
+	for name, data := range tests {
+		got := NewV7Set()
+		if err := got.UnmarshalBinary(data); !errors.Is(err, ErrInvalidFormat) {
+			t.Errorf("%s: UnmarshalBinary() error = %v, want %v", name, err, ErrInvalidFormat)
+		}
+	}
+}
+
+func BenchmarkV7Set(b *testing.B) {
+	s, uuids := newTestV7Set(b, 10000)
+	b.Run("Contains", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s.Contains(uuids[i%len(uuids)])
+		}
+	})
+	b.Run("Add", func(b *testing.B) {
+		g := NewGen()
+		for i := 0; i < b.N; i++ {
+			s.Add(Must(g.NewV7()))
+		}
+	})
+}
@@ -0,0 +1,40 @@
+package uuid
+
+import (
+	"fmt"
+	"time"
+)
+
+// maxV7Skew is how far in the future the timestamp of a V7 UUID accepted by
+// Validate may be, to allow for clocks ahead of the local one.
+const maxV7Skew = 24 * time.Hour
+
+// validateNow returns the current time for Validate.
+var validateNow = time.Now
+
+// Validate returns an error if u could not have been generated as specified
+// by RFC-9562, so that identifiers that are 16 bytes long but otherwise
+// meaningless can be rejected with a single call. The error wraps:
+//
+//   - ErrInvalidVariant if u is not of the RFC 9562 variant, as the Nil and
+//     Max UUIDs are not;
+//   - ErrInvalidVersion if its version is not one of the versions 1 to 8;
+//   - ErrInvalidTimestamp if it is a V7 UUID whose timestamp is more than a
+//     day ahead of the current time.
+func (u UUID) Validate() error {
+	if err := checkVariant(u); err != nil {
+		return err
+	}
+	switch v := u.Version(); {
+	case v < V1 || v > 8:
+		return fmt.Errorf("%w %s is version %d, not version 1 to 8", ErrInvalidVersion, u, v)
+	case v == V7:
+		ms := int64(u[0])<<40 | int64(u[1])<<32 | int64(u[2])<<24 |
+			int64(u[3])<<16 | int64(u[4])<<8 | int64(u[5])
+		if limit := validateNow().Add(maxV7Skew); ms > limit.UnixMilli() {
+			return fmt.Errorf("%w: %s has timestamp %s, after %s", ErrInvalidTimestamp, u,
+				time.UnixMilli(ms).UTC().Format(time.RFC3339Nano), limit.UTC().Format(time.RFC3339Nano))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package uuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	validateNow = func() time.Time { return now }
+	defer func() { validateNow = time.Now }()
+
+	v7At := func(at time.Time) UUID {
+		g := NewGenWithOptions(WithEpochFunc(func() time.Time { return at }))
+		return Must(g.NewV7())
+	}
+	withVersion := func(u UUID, v byte) UUID {
+		u.SetVersion(v)
+		return u
+	}
+
+	tests := []struct {
+		name string
+		u    UUID
+		want error
+	}{
+		{"V1", NamespaceDNS, nil},
+		{"V3", withVersion(codecTestUUID, V3), nil},
+		{"V4", withVersion(codecTestUUID, V4), nil},
+		{"V5", withVersion(codecTestUUID, V5), nil},
+		{"V6", Must(FromString("1ec9414c-232a-6b00-b3c8-9f6bdeced846")), nil},
+		{"V7", v7At(now), nil},
+		{"V7Skewed", v7At(now.Add(23 * time.Hour)), nil},
+		{"V8", NewV8SHA256(NamespaceDNS, "www.example.com"), nil},
+		{"Version2", withVersion(codecTestUUID, 2), nil},
+		{"Nil", Nil, ErrInvalidVariant},
+		{"Max", Max, ErrInvalidVariant},
+		{"Microsoft", Must(FromString("6ba7b810-9dad-11d1-c0b4-00c04fd430c8")), ErrInvalidVariant},
+		{"Version0", withVersion(codecTestUUID, 0), ErrInvalidVersion},
+		{"Version9", withVersion(codecTestUUID, 9), ErrInvalidVersion},
+		{"V7Future", v7At(now.Add(25 * time.Hour)), ErrInvalidTimestamp},
+		{"V7MaxTimestamp", withVersion(Must(FromString("ffffffff-ffff-7fff-bfff-ffffffffffff")), V7), ErrInvalidTimestamp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.u.Validate()
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("%v.Validate() = %v, want <nil>", tt.u, err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("%v.Validate() = %v, want %v", tt.u, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,443 @@
+package uuid
+
+import (
+	"fmt"
+	"time"
+)
+
+// The versioned UUID types below are UUIDs whose constructors and decoders
+// guarantee the RFC 9562 variant and a specific version, so that APIs can
+// require e.g. a version 7 UUID in a type signature. Each type embeds UUID and
+// inherits its methods; the decoding methods are overridden to reject UUIDs of
+// any other version or variant, leaving the receiver unchanged.
+
+// checkVersion returns an error if u is not an RFC 9562 UUID of version v,
+// wrapping ErrInvalidVariant or ErrInvalidVersion.
+func checkVersion(u UUID, v byte) error {
+	if err := checkVariant(u); err != nil {
+		return err
+	}
+	if u.Version() != v {
+		return fmt.Errorf("%w %s is version %d, not version %d", ErrInvalidVersion, u, u.Version(), v)
+	}
+	return nil
+}
+
+// decodeVersioned decodes into a temporary UUID with decode and stores it in
+// dst only if it is an RFC 9562 UUID of version v.
+func decodeVersioned(dst *UUID, v byte, decode func(*UUID) error) error {
+	var u UUID
+	if err := decode(&u); err != nil {
+		return err
+	}
+	if err := checkVersion(u, v); err != nil {
+		return err
+	}
+	*dst = u
+	return nil
+}
+
+// V1UUID is a Version 1 (date-time and MAC address) UUID.
+type V1UUID struct {
+	UUID
+}
+
+// NewV1UUID returns a V1UUID based on the current timestamp and MAC address,
+// generated by VersionGenerator(V1), the generator set with
+// SetVersionGenerator or DefaultGenerator.
+func NewV1UUID() (V1UUID, error) {
+	u, err := NewV1()
+	return V1UUID{u}, err
+}
+
+// NewV1UUIDAtTime returns a V1UUID based on the provided time, generated by
+// VersionGenerator(V1).
+func NewV1UUIDAtTime(atTime time.Time) (V1UUID, error) {
+	u, err := NewV1AtTime(atTime)
+	return V1UUID{u}, err
+}
+
+// V1UUIDFrom returns u as a V1UUID. It will return an error if u is not an
+// RFC 9562 version 1 UUID.
+func V1UUIDFrom(u UUID) (V1UUID, error) {
+	if err := checkVersion(u, V1); err != nil {
+		return V1UUID{}, err
+	}
+	return V1UUID{u}, nil
+}
+
+// Parse parses the version 1 UUID stored in the string s. It accepts the same
+// formats as UUID.Parse, and returns an error for any other version.
+func (u *V1UUID) Parse(s string) error {
+	return decodeVersioned(&u.UUID, V1, func(d *UUID) error { return d.Parse(s) })
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts
+// the same formats as UUID.UnmarshalText, and returns an error for any other
+// version.
+func (u *V1UUID) UnmarshalText(b []byte) error {
+	return decodeVersioned(&u.UUID, V1, func(d *UUID) error { return d.UnmarshalText(b) })
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// returns an error for any other version.
+func (u *V1UUID) UnmarshalBinary(data []byte) error {
+	return decodeVersioned(&u.UUID, V1, func(d *UUID) error { return d.UnmarshalBinary(data) })
+}
+
+// Scan implements the sql.Scanner interface. It accepts the same values as
+// UUID.Scan, and returns an error for any other version.
+func (u *V1UUID) Scan(src interface{}) error {
+	return decodeVersioned(&u.UUID, V1, func(d *UUID) error { return d.Scan(src) })
+}
+
+// GobDecode implements the gob.GobDecoder interface. It returns an error for
+// any other version.
+func (u *V1UUID) GobDecode(data []byte) error {
+	return decodeVersioned(&u.UUID, V1, func(d *UUID) error { return d.GobDecode(data) })
+}
+
+// V3UUID is a Version 3 (namespace name-based, MD5) UUID.
+type V3UUID struct {
+	UUID
+}
+
+// NewV3UUID returns a V3UUID based on the MD5 hash of the namespace UUID and name.
+func NewV3UUID(ns UUID, name string) V3UUID {
+	return V3UUID{NewV3(ns, name)}
+}
+
+// V3UUIDFrom returns u as a V3UUID. It will return an error if u is not an
+// RFC 9562 version 3 UUID.
+func V3UUIDFrom(u UUID) (V3UUID, error) {
+	if err := checkVersion(u, V3); err != nil {
+		return V3UUID{}, err
+	}
+	return V3UUID{u}, nil
+}
+
+// Parse parses the version 3 UUID stored in the string s. It accepts the same
+// formats as UUID.Parse, and returns an error for any other version.
+func (u *V3UUID) Parse(s string) error {
+	return decodeVersioned(&u.UUID, V3, func(d *UUID) error { return d.Parse(s) })
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts
+// the same formats as UUID.UnmarshalText, and returns an error for any other
+// version.
+func (u *V3UUID) UnmarshalText(b []byte) error {
+	return decodeVersioned(&u.UUID, V3, func(d *UUID) error { return d.UnmarshalText(b) })
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// returns an error for any other version.
+func (u *V3UUID) UnmarshalBinary(data []byte) error {
+	return decodeVersioned(&u.UUID, V3, func(d *UUID) error { return d.UnmarshalBinary(data) })
+}
+
+// Scan implements the sql.Scanner interface. It accepts the same values as
+// UUID.Scan, and returns an error for any other version.
+func (u *V3UUID) Scan(src interface{}) error {
+	return decodeVersioned(&u.UUID, V3, func(d *UUID) error { return d.Scan(src) })
+}
+
+// GobDecode implements the gob.GobDecoder interface. It returns an error for
+// any other version.
+func (u *V3UUID) GobDecode(data []byte) error {
+	return decodeVersioned(&u.UUID, V3, func(d *UUID) error { return d.GobDecode(data) })
+}
+
+// V4UUID is a Version 4 (random) UUID.
+type V4UUID struct {
+	UUID
+}
+
+// NewV4UUID returns a V4UUID based on random data, generated by
+// VersionGenerator(V4), the generator set with SetVersionGenerator or
+// DefaultGenerator.
+func NewV4UUID() (V4UUID, error) {
+	u, err := NewV4()
+	return V4UUID{u}, err
+}
+
+// V4UUIDFrom returns u as a V4UUID. It will return an error if u is not an
+// RFC 9562 version 4 UUID.
+func V4UUIDFrom(u UUID) (V4UUID, error) {
+	if err := checkVersion(u, V4); err != nil {
+		return V4UUID{}, err
+	}
+	return V4UUID{u}, nil
+}
+
+// Parse parses the version 4 UUID stored in the string s. It accepts the same
+// formats as UUID.Parse, and returns an error for any other version.
+func (u *V4UUID) Parse(s string) error {
+	return decodeVersioned(&u.UUID, V4, func(d *UUID) error { return d.Parse(s) })
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts
+// the same formats as UUID.UnmarshalText, and returns an error for any other
+// version.
+func (u *V4UUID) UnmarshalText(b []byte) error {
+	return decodeVersioned(&u.UUID, V4, func(d *UUID) error { return d.UnmarshalText(b) })
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// returns an error for any other version.
+func (u *V4UUID) UnmarshalBinary(data []byte) error {
+	return decodeVersioned(&u.UUID, V4, func(d *UUID) error { return d.UnmarshalBinary(data) })
+}
+
+// Scan implements the sql.Scanner interface. It accepts the same values as
+// UUID.Scan, and returns an error for any other version.
+func (u *V4UUID) Scan(src interface{}) error {
+	return decodeVersioned(&u.UUID, V4, func(d *UUID) error { return d.Scan(src) })
+}
+
+// GobDecode implements the gob.GobDecoder interface. It returns an error for
+// any other version.
+func (u *V4UUID) GobDecode(data []byte) error {
+	return decodeVersioned(&u.UUID, V4, func(d *UUID) error { return d.GobDecode(data) })
+}
+
+// V5UUID is a Version 5 (namespace name-based, SHA-1) UUID.
+type V5UUID struct {
+	UUID
+}
+
+// NewV5UUID returns a V5UUID based on the SHA-1 hash of the namespace UUID and name.
+func NewV5UUID(ns UUID, name string) V5UUID {
+	return V5UUID{NewV5(ns, name)}
+}
+
+// V5UUIDFrom returns u as a V5UUID. It will return an error if u is not an
+// RFC 9562 version 5 UUID.
+func V5UUIDFrom(u UUID) (V5UUID, error) {
+	if err := checkVersion(u, V5); err != nil {
+		return V5UUID{}, err
+	}
+	return V5UUID{u}, nil
+}
+
+// Parse parses the version 5 UUID stored in the string s. It accepts the same
+// formats as UUID.Parse, and returns an error for any other version.
+func (u *V5UUID) Parse(s string) error {
+	return decodeVersioned(&u.UUID, V5, func(d *UUID) error { return d.Parse(s) })
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts
+// the same formats as UUID.UnmarshalText, and returns an error for any other
+// version.
+func (u *V5UUID) UnmarshalText(b []byte) error {
+	return decodeVersioned(&u.UUID, V5, func(d *UUID) error { return d.UnmarshalText(b) })
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// returns an error for any other version.
+func (u *V5UUID) UnmarshalBinary(data []byte) error {
+	return decodeVersioned(&u.UUID, V5, func(d *UUID) error { return d.UnmarshalBinary(data) })
+}
+
+// Scan implements the sql.Scanner interface. It accepts the same values as
+// UUID.Scan, and returns an error for any other version.
+func (u *V5UUID) Scan(src interface{}) error {
+	return decodeVersioned(&u.UUID, V5, func(d *UUID) error { return d.Scan(src) })
+}
+
+// GobDecode implements the gob.GobDecoder interface. It returns an error for
+// any other version.
+func (u *V5UUID) GobDecode(data []byte) error {
+	return decodeVersioned(&u.UUID, V5, func(d *UUID) error { return d.GobDecode(data) })
+}
+
+// V6UUID is a Version 6 (k-sortable timestamp, field-compatible with v1) UUID.
+type V6UUID struct {
+	UUID
+}
+
+// NewV6UUID returns a V6UUID based on the current timestamp, generated by
+// VersionGenerator(V6), the generator set with SetVersionGenerator or
+// DefaultGenerator.
+func NewV6UUID() (V6UUID, error) {
+	u, err := NewV6()
+	return V6UUID{u}, err
+}
+
+// NewV6UUIDAtTime returns a V6UUID based on the provided time, generated by
+// VersionGenerator(V6).
+func NewV6UUIDAtTime(atTime time.Time) (V6UUID, error) {
+	u, err := NewV6AtTime(atTime)
+	return V6UUID{u}, err
+}
+
+// V6UUIDFrom returns u as a V6UUID. It will return an error if u is not an
+// RFC 9562 version 6 UUID.
+func V6UUIDFrom(u UUID) (V6UUID, error) {
+	if err := checkVersion(u, V6); err != nil {
+		return V6UUID{}, err
+	}
+	return V6UUID{u}, nil
+}
+
+// Parse parses the version 6 UUID stored in the string s. It accepts the same
+// formats as UUID.Parse, and returns an error for any other version.
+func (u *V6UUID) Parse(s string) error {
+	return decodeVersioned(&u.UUID, V6, func(d *UUID) error { return d.Parse(s) })
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts
+// the same formats as UUID.UnmarshalText, and returns an error for any other
+// version.
+func (u *V6UUID) UnmarshalText(b []byte) error {
+	return decodeVersioned(&u.UUID, V6, func(d *UUID) error { return d.UnmarshalText(b) })
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// returns an error for any other version.
+func (u *V6UUID) UnmarshalBinary(data []byte) error {
+	return decodeVersioned(&u.UUID, V6, func(d *UUID) error { return d.UnmarshalBinary(data) })
+}
+
+// Scan implements the sql.Scanner interface. It accepts the same values as
+// UUID.Scan, and returns an error for any other version.
+func (u *V6UUID) Scan(src interface{}) error {
+	return decodeVersioned(&u.UUID, V6, func(d *UUID) error { return d.Scan(src) })
+}
+
+// GobDecode implements the gob.GobDecoder interface. It returns an error for
+// any other version.
+func (u *V6UUID) GobDecode(data []byte) error {
+	return decodeVersioned(&u.UUID, V6, func(d *UUID) error { return d.GobDecode(data) })
+}
+
+// V7UUID is a Version 7 (k-sortable Unix timestamp) UUID.
+type V7UUID struct {
+	UUID
+}
+
+// NewV7UUID returns a V7UUID based on the current millisecond-precision Unix
+// epoch, generated by VersionGenerator(V7), the generator set with
+// SetVersionGenerator or DefaultGenerator.
+func NewV7UUID() (V7UUID, error) {
+	u, err := NewV7()
+	return V7UUID{u}, err
+}
+
+// NewV7UUIDAtTime returns a V7UUID based on the provided time, generated by
+// VersionGenerator(V7).
+func NewV7UUIDAtTime(atTime time.Time) (V7UUID, error) {
+	u, err := NewV7AtTime(atTime)
+	return V7UUID{u}, err
+}
+
+// V7UUIDFrom returns u as a V7UUID. It will return an error if u is not an
+// RFC 9562 version 7 UUID.
+func V7UUIDFrom(u UUID) (V7UUID, error) {
+	if err := checkVersion(u, V7); err != nil {
+		return V7UUID{}, err
+	}
+	return V7UUID{u}, nil
+}
+
+// Parse parses the version 7 UUID stored in the string s. It accepts the same
+// formats as UUID.Parse, and returns an error for any other version.
+func (u *V7UUID) Parse(s string) error {
+	return decodeVersioned(&u.UUID, V7, func(d *UUID) error { return d.Parse(s) })
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts
+// the same formats as UUID.UnmarshalText, and returns an error for any other
+// version.
+func (u *V7UUID) UnmarshalText(b []byte) error {
+	return decodeVersioned(&u.UUID, V7, func(d *UUID) error { return d.UnmarshalText(b) })
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// returns an error for any other version.
+func (u *V7UUID) UnmarshalBinary(data []byte) error {
+	return decodeVersioned(&u.UUID, V7, func(d *UUID) error { return d.UnmarshalBinary(data) })
+}
+
+// Scan implements the sql.Scanner interface. It accepts the same values as
+// UUID.Scan, and returns an error for any other version.
+func (u *V7UUID) Scan(src interface{}) error {
+	return decodeVersioned(&u.UUID, V7, func(d *UUID) error { return d.Scan(src) })
+}
+
+// GobDecode implements the gob.GobDecoder interface. It returns an error for
+// any other version.
+func (u *V7UUID) GobDecode(data []byte) error {
+	return decodeVersioned(&u.UUID, V7, func(d *UUID) error { return d.GobDecode(data) })
+}
+
+// checkVariant returns an error if u is not of the RFC 9562 variant.
+func checkVariant(u UUID) error {
+	if u.Variant() != VariantRFC9562 {
+		return fmt.Errorf("%w: %s has variant %d, not the RFC 9562 variant", ErrInvalidVariant, u, u.Variant())
+	}
+	return nil
+}
+
+// decodeRFC decodes into a temporary UUID with decode and stores it in dst
+// only if it is of the RFC 9562 variant.
+func decodeRFC(dst *UUID, decode func(*UUID) error) error {
+	var u UUID
+	if err := decode(&u); err != nil {
+		return err
+	}
+	if err := checkVariant(u); err != nil {
+		return err
+	}
+	*dst = u
+	return nil
+}
+
+// RFCUUID is a UUID of the RFC 9562 variant, of any version. Its decoders
+// reject the UUIDs of the NCS, Microsoft and future variants, such as the
+// GUIDs of some Windows clients, whose fields do not have the RFC 9562
+// layout, and the Nil and Max UUIDs.
+type RFCUUID struct {
+	UUID
+}
+
+// RFCUUIDFrom returns u as an RFCUUID. It will return an error wrapping
+// ErrInvalidVariant if u is not of the RFC 9562 variant.
+func RFCUUIDFrom(u UUID) (RFCUUID, error) {
+	if err := checkVariant(u); err != nil {
+		return RFCUUID{}, err
+	}
+	return RFCUUID{u}, nil
+}
+
+// Parse parses the UUID stored in the string s. It accepts the same formats
+// as UUID.Parse, and returns an error wrapping ErrInvalidVariant for any
+// other variant.
+func (u *RFCUUID) Parse(s string) error {
+	return decodeRFC(&u.UUID, func(d *UUID) error { return d.Parse(s) })
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It accepts
+// the same formats as UUID.UnmarshalText, and returns an error wrapping
+// ErrInvalidVariant for any other variant.
+func (u *RFCUUID) UnmarshalText(b []byte) error {
+	return decodeRFC(&u.UUID, func(d *UUID) error { return d.UnmarshalText(b) })
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// returns an error wrapping ErrInvalidVariant for any other variant.
+func (u *RFCUUID) UnmarshalBinary(data []byte) error {
+	return decodeRFC(&u.UUID, func(d *UUID) error { return d.UnmarshalBinary(data) })
+}
+
+// Scan implements the sql.Scanner interface. It accepts the same values as
+// UUID.Scan, and returns an error wrapping ErrInvalidVariant for any other
+// variant.
+func (u *RFCUUID) Scan(src interface{}) error {
+	return decodeRFC(&u.UUID, func(d *UUID) error { return d.Scan(src) })
+}
+
+// GobDecode implements the gob.GobDecoder interface. It returns an error
+// wrapping ErrInvalidVariant for any other variant.
+func (u *RFCUUID) GobDecode(data []byte) error {
+	return decodeRFC(&u.UUID, func(d *UUID) error { return d.GobDecode(data) })
+}
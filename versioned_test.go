@@ -0,0 +1,225 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// versionedUUID is implemented by pointers to the versioned UUID types.
+type versionedUUID interface {
+	Parse(string) error
+	UnmarshalText([]byte) error
+	UnmarshalBinary([]byte) error
+	Scan(interface{}) error
+	GobDecode([]byte) error
+	String() string
+}
+
+// gobDecode decodes the gob encoding of u into d.
+func gobDecode(d interface{}, u UUID) error {
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(u); err != nil {
+		return err
+	}
+	return gob.NewDecoder(&b).Decode(d)
+}
+
+func TestVersionedUUID(t *testing.T) {
+	v1 := Must(NewV1())
+	v3 := Must(FromString("5df41881-3aed-3515-88a7-2f4a814cf09e"))
+	v4 := Must(NewV4())
+	v5 := Must(FromString("2ed6657d-e927-568b-95e1-2665a8aea6a2"))
+	v6 := Must(NewV6())
+	v7 := Must(NewV7())
+	all := []UUID{v1, v3, v4, v5, v6, v7}
+
+	tests := []struct {
+		name string
+		want UUID
+		new  func() versionedUUID
+	}{
+		{name: "V1", want: v1, new: func() versionedUUID { return new(V1UUID) }},
+		{name: "V3", want: v3, new: func() versionedUUID { return new(V3UUID) }},
+		{name: "V4", want: v4, new: func() versionedUUID { return new(V4UUID) }},
+		{name: "V5", want: v5, new: func() versionedUUID { return new(V5UUID) }},
+		{name: "V6", want: v6, new: func() versionedUUID { return new(V6UUID) }},
+		{name: "V7", want: v7, new: func() versionedUUID { return new(V7UUID) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decoders := map[string]func(versionedUUID, UUID) error{
+				"Parse":           func(d versionedUUID, u UUID) error { return d.Parse(u.String()) },
+				"UnmarshalText":   func(d versionedUUID, u UUID) error { return d.UnmarshalText([]byte(u.String())) },
+				"UnmarshalBinary": func(d versionedUUID, u UUID) error { return d.UnmarshalBinary(u.Bytes()) },
+				"Scan":            func(d versionedUUID, u UUID) error { return d.Scan(u.Bytes()) },
+				"Gob":             func(d versionedUUID, u UUID) error { return gobDecode(d, u) },
+			}
+			for name, decode := range decoders {
+				for _, u := range all {
+					d := tt.new()
+					err := decode(d, u)
+					if u == tt.want {
+						if err != nil {
+							t.Errorf("%s(%v) unexpected error: %v", name, u, err)
+						} else if d.String() != u.String() {
+							t.Errorf("%s(%v) = %s", name, u, d)
+						}
+						continue
+					}
+					if !errors.Is(err, ErrInvalidVersion) {
+						t.Errorf("%s(%v) error = %v, want %v", name, u, err, ErrInvalidVersion)
+					}
+					if d.String() != Nil.String() {
+						t.Errorf("%s(%v) modified the receiver: %s", name, u, d)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestVersionedUUIDConstructors(t *testing.T) {
+	check := func(name string, u UUID, v byte) {
+		t.Helper()
+		if err := checkVersion(u, v); err != nil {
+			t.Errorf("%s() = %v: %v", name, u, err)
+		}
+	}
+	now := time.Now()
+
+	v1, err := NewV1UUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("NewV1UUID", v1.UUID, V1)
+	v1, err = NewV1UUIDAtTime(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("NewV1UUIDAtTime", v1.UUID, V1)
+	if !FIPS {
+		check("NewV3UUID", NewV3UUID(NamespaceDNS, "www.example.com").UUID, V3)
+	}
+	v4, err := NewV4UUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("NewV4UUID", v4.UUID, V4)
+	if !FIPS {
+		check("NewV5UUID", NewV5UUID(NamespaceDNS, "www.example.com").UUID, V5)
+	}
+	v6, err := NewV6UUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("NewV6UUID", v6.UUID, V6)
+	v6, err = NewV6UUIDAtTime(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("NewV6UUIDAtTime", v6.UUID, V6)
+	v7, err := NewV7UUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("NewV7UUID", v7.UUID, V7)
+	v7, err = NewV7UUIDAtTime(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("NewV7UUIDAtTime", v7.UUID, V7)
+}
+
+func TestVersionedUUIDFrom(t *testing.T) {
+	v4 := Must(NewV4())
+	if got, err := V4UUIDFrom(v4); err != nil || got.UUID != v4 {
+		t.Errorf("V4UUIDFrom(%v) = %v, %v", v4, got, err)
+	}
+	if _, err := V7UUIDFrom(v4); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("V7UUIDFrom(%v) error = %v, want %v", v4, err, ErrInvalidVersion)
+	}
+	microsoft := v4
+	microsoft.SetVariant(VariantMicrosoft)
+	if _, err := V4UUIDFrom(microsoft); !errors.Is(err, ErrInvalidVariant) {
+		t.Errorf("V4UUIDFrom(%v) error = %v, want %v", microsoft, err, ErrInvalidVariant)
+	}
+	if _, err := V4UUIDFrom(Nil); !errors.Is(err, ErrInvalidVariant) {
+		t.Errorf("V4UUIDFrom(%v) error = %v, want %v", Nil, err, ErrInvalidVariant)
+	}
+}
+
+func TestVersionedUUIDJSON(t *testing.T) {
+	type event struct {
+		ID V7UUID
+	}
+	id, err := NewV7UUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := event{ID: id}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out event
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("json.Unmarshal(%s) = %v, want %v", b, out, in)
+	}
+
+	v4 := []byte(`{"ID":"` + Must(NewV4()).String() + `"}`)
+	if err := json.Unmarshal(v4, &out); !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("json.Unmarshal(%s) error = %v, want %v", v4, err, ErrInvalidVersion)
+	}
+}
+
+func TestRFCUUID(t *testing.T) {
+	v4 := Must(NewV4())
+	microsoft := v4
+	microsoft.SetVariant(VariantMicrosoft)
+	ncs := v4
+	ncs.SetVariant(VariantNCS)
+	future := v4
+	future.SetVariant(VariantFuture)
+	valid := []UUID{Must(NewV1()), Must(FromString("5df41881-3aed-3515-88a7-2f4a814cf09e")), v4, Must(NewV7())}
+	invalid := []UUID{microsoft, ncs, future, Nil, Max}
+
+	decoders := map[string]func(*RFCUUID, UUID) error{
+		"Parse":           func(d *RFCUUID, u UUID) error { return d.Parse(u.String()) },
+		"UnmarshalText":   func(d *RFCUUID, u UUID) error { return d.UnmarshalText([]byte(u.String())) },
+		"UnmarshalBinary": func(d *RFCUUID, u UUID) error { return d.UnmarshalBinary(u.Bytes()) },
+		"Scan":            func(d *RFCUUID, u UUID) error { return d.Scan(u.String()) },
+		"Gob":             func(d *RFCUUID, u UUID) error { return gobDecode(d, u) },
+		"JSON":            func(d *RFCUUID, u UUID) error { return json.Unmarshal([]byte(`"`+u.String()+`"`), d) },
+	}
+	for name, decode := range decoders {
+		for _, u := range valid {
+			var d RFCUUID
+			if err := decode(&d, u); err != nil || d.UUID != u {
+				t.Errorf("%s(%v) = %v, %v", name, u, d, err)
+			}
+		}
+		for _, u := range invalid {
+			d := RFCUUID{v4}
+			if err := decode(&d, u); !errors.Is(err, ErrInvalidVariant) {
+				t.Errorf("%s(%v) error = %v, want %v", name, u, err, ErrInvalidVariant)
+			}
+			if d.UUID != v4 {
+				t.Errorf("%s(%v) modified the receiver: %s", name, u, d)
+			}
+		}
+	}
+
+	if got, err := RFCUUIDFrom(v4); err != nil || got.UUID != v4 {
+		t.Errorf("RFCUUIDFrom(%v) = %v, %v", v4, got, err)
+	}
+	if _, err := RFCUUIDFrom(microsoft); !errors.Is(err, ErrInvalidVariant) {
+		t.Errorf("RFCUUIDFrom(%v) error = %v, want %v", microsoft, err, ErrInvalidVariant)
+	}
+}
@@ -0,0 +1,50 @@
+package uuid
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// versionGenerators holds the generators set with SetVersionGenerator, by
+// version. They are read on each call of a package-level constructor, so
+// they are atomic rather than guarded by a lock.
+var versionGenerators [16]atomic.Pointer[Generator]
+
+// SetVersionGenerator sets gen as the generator of the UUIDs of version v
+// returned by the package-level constructors, such as NewV7 and NewV7UUID
+// for V7, in place of DefaultGenerator. It lets an application tune the
+// generator of a version, such as a MonotonicGen for V7, or a Gen with a
+// fixed hardware address for V1, without changing the other versions. Only
+// the method of gen for version v is used.
+//
+// A nil gen removes the generator of version v, which DefaultGenerator then
+// generates again. It returns an error wrapping ErrInvalidVersion if v is
+// not V1, V3, V4, V5, V6 or V7.
+//
+// SetVersionGenerator is safe for concurrent use with the package-level
+// constructors, but is meant to be called during initialization.
+func SetVersionGenerator(v byte, gen Generator) error {
+	switch v {
+	case V1, V3, V4, V5, V6, V7:
+	default:
+		return fmt.Errorf("%w version %d has no package-level constructor", ErrInvalidVersion, v)
+	}
+	if gen == nil {
+		versionGenerators[v].Store(nil)
+	} else {
+		versionGenerators[v].Store(&gen)
+	}
+	return nil
+}
+
+// VersionGenerator returns the generator of the UUIDs of version v returned
+// by the package-level constructors: the generator set with
+// SetVersionGenerator, or DefaultGenerator.
+func VersionGenerator(v byte) Generator {
+	if v < byte(len(versionGenerators)) {
+		if gen := versionGenerators[v].Load(); gen != nil {
+			return *gen
+		}
+	}
+	return DefaultGenerator
+}
@@ -0,0 +1,103 @@
+package uuid
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSetVersionGenerator(t *testing.T) {
+	t.Cleanup(func() {
+		for _, v := range []byte{V1, V3, V4, V5, V6, V7} {
+			SetVersionGenerator(v, nil)
+		}
+	})
+
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	hwAddr := HWAddr{0x02, 0, 0, 0, 0, 0x01}
+	v1Gen := NewGenWithOptions(
+		WithEpochFunc(func() time.Time { return at }),
+		WithHWAddrFunc(func() (HWAddr, error) { return hwAddr, nil }),
+	)
+	if err := SetVersionGenerator(V1, v1Gen); err != nil {
+		t.Fatalf("SetVersionGenerator(V1) unexpected error: %v", err)
+	}
+	if got := VersionGenerator(V1); got != Generator(v1Gen) {
+		t.Errorf("VersionGenerator(V1) = %v, want %v", got, v1Gen)
+	}
+	if got := VersionGenerator(V7); got != DefaultGenerator {
+		t.Errorf("VersionGenerator(V7) = %v, want DefaultGenerator", got)
+	}
+
+	u, err := NewV1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := u.Fields(); string(f.Node[:]) != string(hwAddr) {
+		t.Errorf("NewV1() node = %x, want %x", f.Node, hwAddr)
+	}
+	if ts, _ := u.Time(); !ts.Equal(at) {
+		t.Errorf("NewV1() time = %v, want %v", ts, at)
+	}
+	if u, err := NewV1UUID(); err != nil || string(u.UUID[10:]) != string(hwAddr) {
+		t.Errorf("NewV1UUID() = %v, %v, want node %x", u, err, hwAddr)
+	}
+
+	// The other versions are generated by DefaultGenerator.
+	if u, err := NewV6(); err != nil || u.Version() != V6 {
+		t.Fatalf("NewV6() = %v, %v", u, err)
+	} else if ts, _ := u.Time(); ts.Equal(at) {
+		t.Errorf("NewV6() time = %v, want the current time", ts)
+	}
+
+	v7Gen := NewMonotonicGen(WithEpochFunc(func() time.Time { return at }))
+	if err := SetVersionGenerator(V7, v7Gen); err != nil {
+		t.Fatal(err)
+	}
+	prev := Nil
+	for i := 0; i < 10; i++ {
+		u, err := NewV7()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ts, _ := u.Time(); !ts.Equal(at) || prev.Compare(u) >= 0 {
+			t.Fatalf("NewV7() = %v at %v after %v, want a later UUID at %v", u, ts, prev, at)
+		}
+		prev = u
+	}
+	if m := NewMigrator(nil, MigrateText); m.gen != Generator(v7Gen) {
+		t.Errorf("NewMigrator(nil) generator = %v, want %v", m.gen, v7Gen)
+	}
+
+	// Removing the generator of V1 restores DefaultGenerator.
+	if err := SetVersionGenerator(V1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := VersionGenerator(V1); got != DefaultGenerator {
+		t.Errorf("VersionGenerator(V1) after removal = %v, want DefaultGenerator", got)
+	}
+	if got := VersionGenerator(V7); got != Generator(v7Gen) {
+		t.Errorf("VersionGenerator(V7) after the removal of V1 = %v, want %v", got, v7Gen)
+	}
+
+	for _, v := range []byte{0, 2, 8, 15, 16} {
+		if err := SetVersionGenerator(v, v1Gen); !errors.Is(err, ErrInvalidVersion) {
+			t.Errorf("SetVersionGenerator(%d) error = %v, want %v", v, err, ErrInvalidVersion)
+		}
+		if got := VersionGenerator(v); got != DefaultGenerator {
+			t.Errorf("VersionGenerator(%d) = %v, want DefaultGenerator", v, got)
+		}
+	}
+}
+
+func BenchmarkVersionGenerator(b *testing.B) {
+	if err := SetVersionGenerator(V4, NewGen()); err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { SetVersionGenerator(V4, nil) })
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			NewV4()
+		}
+	})
+}